@@ -0,0 +1,295 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/dictionary"
+)
+
+// arcList is one of the four lists of an ARC cache: a recency-ordered list of keys with
+// their values, most recent at the front, indexed by key. Ghost lists store no values.
+type arcList[K comparable, V any] struct {
+	order *list.List // of *dictionary.Entry[K, V]
+	index dictionary.Dictionary[K, *list.Element]
+}
+
+func newARCList[K comparable, V any]() *arcList[K, V] {
+	return &arcList[K, V]{order: list.New(), index: dictionary.DefaultDictionary[K, *list.Element]()}
+}
+
+func (l *arcList[K, V]) len() int { return l.order.Len() }
+
+func (l *arcList[K, V]) contains(key K) bool { return l.index.ContainsKey(key) }
+
+// pushFront adds key as the most recent entry.
+func (l *arcList[K, V]) pushFront(key K, value V) {
+	l.index[key] = l.order.PushFront(&dictionary.Entry[K, V]{Key: key, Value: value})
+}
+
+// remove deletes key and returns its entry.
+func (l *arcList[K, V]) remove(key K) (dictionary.Entry[K, V], bool) {
+	elem, ok := l.index[key]
+	if !ok {
+		return dictionary.Entry[K, V]{}, false
+	}
+	delete(l.index, key)
+	return *l.order.Remove(elem).(*dictionary.Entry[K, V]), true
+}
+
+// removeOldest deletes the least recent entry and returns it. The list must not be empty.
+func (l *arcList[K, V]) removeOldest() dictionary.Entry[K, V] {
+	e, _ := l.remove(l.order.Back().Value.(*dictionary.Entry[K, V]).Key)
+	return e
+}
+
+// get returns the entry of key.
+func (l *arcList[K, V]) get(key K) (*dictionary.Entry[K, V], bool) {
+	elem, ok := l.index[key]
+	if !ok {
+		return nil, false
+	}
+	return elem.Value.(*dictionary.Entry[K, V]), true
+}
+
+func (l *arcList[K, V]) clear() {
+	l.order.Init()
+	l.index.ClearDictionary()
+}
+
+// ARC is an adaptive replacement cache. It splits its capacity between entries seen once
+// recently (recency) and entries seen at least twice (frequency), and remembers the keys
+// it recently evicted from each side as ghosts. A miss on a ghost key shows which side
+// was too small, and the split adapts toward it, so ARC does well on workloads where
+// either LRU or LFU alone would thrash. It offers the same operations as LRU, and all of
+// them take constant time.
+type ARC[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	target   int // target size of t1, the "p" of the ARC paper
+	t1, t2   *arcList[K, V]
+	b1, b2   *arcList[K, struct{}]
+	onEvict  func(K, V)
+	metrics
+}
+
+var _ collection.Cache[string, int] = (*ARC[string, int])(nil)
+
+// NewARC creates an empty ARC cache.
+//
+// Parameters:
+//   - capacity: The maximum number of cached entries; values below 1 are treated as 1. Up
+//     to as many evicted keys, without values, are remembered besides.
+//   - onEvict: The function called with each entry evicted to make room, or nil. It runs
+//     with the cache locked, so it must not use the cache.
+//
+// Returns:
+//   - A new empty ARC.
+//
+// Example:
+//
+//	c := NewARC[string, []byte](4096, nil)
+//	c.Add(path, body)
+func NewARC[K comparable, V any](capacity int, onEvict func(K, V)) *ARC[K, V] {
+	return &ARC[K, V]{
+		capacity: max(capacity, 1),
+		t1:       newARCList[K, V](),
+		t2:       newARCList[K, V](),
+		b1:       newARCList[K, struct{}](),
+		b2:       newARCList[K, struct{}](),
+		onEvict:  onEvict,
+	}
+}
+
+// Get returns the value stored under key and counts a use of it.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (c *ARC[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.t1.remove(key); ok {
+		c.lookup(true)
+		c.t2.pushFront(e.Key, e.Value)
+		return e.Value, true
+	}
+	if e, ok := c.t2.get(key); ok {
+		c.lookup(true)
+		c.t2.order.MoveToFront(c.t2.index[key])
+		return e.Value, true
+	}
+	c.lookup(false)
+	var zero V
+	return zero, false
+}
+
+// Peek returns the value stored under key without counting a use.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (c *ARC[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.t1.get(key); ok {
+		return e.Value, true
+	}
+	if e, ok := c.t2.get(key); ok {
+		return e.Value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains checks if key is cached, without counting a use.
+func (c *ARC[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t1.contains(key) || c.t2.contains(key)
+}
+
+// Add stores value under key and counts a use of it, evicting an entry if the cache is full.
+//
+// Parameters:
+//   - key: The key for which the value needs to be cached.
+//   - value: The value to be cached.
+//
+// Returns:
+//   - dictionary.Entry[K, V]: The evicted entry, or the zero Entry if none was evicted.
+//   - bool: True if an entry was evicted, false otherwise.
+func (c *ARC[K, V]) Add(key K, value V) (dictionary.Entry[K, V], bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// A cached key moves to the frequency side.
+	if _, ok := c.t1.remove(key); ok {
+		c.t2.pushFront(key, value)
+		return dictionary.Entry[K, V]{}, false
+	}
+	if e, ok := c.t2.get(key); ok {
+		e.Value = value
+		c.t2.order.MoveToFront(c.t2.index[key])
+		return dictionary.Entry[K, V]{}, false
+	}
+
+	// A ghost hit grows the side it was evicted from, then the key returns on the frequency side.
+	if c.b1.contains(key) {
+		c.target = min(c.capacity, c.target+max(c.b2.len()/c.b1.len(), 1))
+		evicted, ok := c.replace(false)
+		c.b1.remove(key)
+		c.t2.pushFront(key, value)
+		return evicted, ok
+	}
+	if c.b2.contains(key) {
+		c.target = max(0, c.target-max(c.b1.len()/c.b2.len(), 1))
+		evicted, ok := c.replace(true)
+		c.b2.remove(key)
+		c.t2.pushFront(key, value)
+		return evicted, ok
+	}
+
+	// A new key enters the recency side.
+	var (
+		evicted    dictionary.Entry[K, V]
+		hasEvicted bool
+	)
+	if c.t1.len()+c.b1.len() >= c.capacity {
+		if c.t1.len() < c.capacity {
+			c.b1.removeOldest()
+			evicted, hasEvicted = c.replace(false)
+		} else {
+			evicted, hasEvicted = c.evict(c.t1.removeOldest()), true
+		}
+	} else if total := c.t1.len() + c.t2.len() + c.b1.len() + c.b2.len(); total >= c.capacity {
+		if total >= 2*c.capacity {
+			c.b2.removeOldest()
+		}
+		evicted, hasEvicted = c.replace(false)
+	}
+	c.t1.pushFront(key, value)
+	return evicted, hasEvicted
+}
+
+// replace evicts one cached entry into its ghost list if the cache is full, choosing the
+// side whose size exceeds its target. inB2 reports whether the key being added is a ghost
+// of the frequency side. The caller must hold c.mu.
+func (c *ARC[K, V]) replace(inB2 bool) (dictionary.Entry[K, V], bool) {
+	if c.t1.len()+c.t2.len() < c.capacity {
+		return dictionary.Entry[K, V]{}, false
+	}
+	if n := c.t1.len(); n > 0 && (n > c.target || (inB2 && n == c.target)) {
+		e := c.t1.removeOldest()
+		c.b1.pushFront(e.Key, struct{}{})
+		return c.evict(e), true
+	}
+	e := c.t2.removeOldest()
+	c.b2.pushFront(e.Key, struct{}{})
+	return c.evict(e), true
+}
+
+// evict records the eviction of e, reports it to the eviction callback and returns it.
+// The caller must hold c.mu.
+func (c *ARC[K, V]) evict(e dictionary.Entry[K, V]) dictionary.Entry[K, V] {
+	c.evicted()
+	if c.onEvict != nil {
+		c.onEvict(e.Key, e.Value)
+	}
+	return e
+}
+
+// Set stores value under key, as Add does without reporting evictions.
+func (c *ARC[K, V]) Set(key K, value V) {
+	c.Add(key, value)
+}
+
+// Delete removes key, and forgets it as a ghost, and reports whether it was cached. The
+// eviction callback is not called.
+func (c *ARC[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.b1.remove(key)
+	c.b2.remove(key)
+	_, in1 := c.t1.remove(key)
+	_, in2 := c.t2.remove(key)
+	return in1 || in2
+}
+
+// Keys returns the cached keys: the frequency side from most to least recently used,
+// then the recency side likewise.
+func (c *ARC[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]K, 0, c.t1.len()+c.t2.len())
+	for _, l := range []*arcList[K, V]{c.t2, c.t1} {
+		for elem := l.order.Front(); elem != nil; elem = elem.Next() {
+			keys = append(keys, elem.Value.(*dictionary.Entry[K, V]).Key)
+		}
+	}
+	return keys
+}
+
+// GetLength returns the number of cached entries, not counting ghosts.
+func (c *ARC[K, V]) GetLength() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t1.len() + c.t2.len()
+}
+
+// Capacity returns the maximum number of cached entries.
+func (c *ARC[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Purge removes every entry and ghost, and resets the adaptation. The eviction callback
+// is not called.
+func (c *ARC[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t1.clear()
+	c.t2.clear()
+	c.b1.clear()
+	c.b2.clear()
+	c.target = 0
+}
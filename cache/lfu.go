@@ -0,0 +1,312 @@
+package cache
+
+import (
+	"container/list"
+	"math"
+	"sync"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/dictionary"
+)
+
+// lfuItem is a cached entry of an LFU cache.
+type lfuItem[K comparable, V any] struct {
+	entry  dictionary.Entry[K, V]
+	bucket *list.Element // of *lfuBucket, the bucket of the item's frequency
+	elem   *list.Element // the item's element in that bucket
+}
+
+// lfuBucket holds the items used exactly count times, most recently used at the front.
+type lfuBucket[K comparable, V any] struct {
+	count int
+	items *list.List // of *lfuItem
+}
+
+// LFU is a cache that evicts the least frequently used entry when it is full, breaking
+// ties by evicting the least recently used of them. Unlike LRU, a burst of one-off keys,
+// as in a scan, cannot push out entries that are used often. Get and Add count as uses;
+// Peek does not. All operations take constant time, plus one call of the weigher per entry
+// added or removed for a cache made by NewWeightedLFU, which is full when the total weight
+// of its entries exceeds its budget.
+type LFU[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	buckets  *list.List // of *lfuBucket, by ascending count
+	items    dictionary.Dictionary[K, *lfuItem[K, V]]
+	onEvict  func(K, V)
+	weights[K, V]
+	metrics
+}
+
+var _ collection.Cache[string, int] = (*LFU[string, int])(nil)
+
+// NewLFU creates an empty LFU cache.
+//
+// Parameters:
+//   - capacity: The maximum number of entries; values below 1 are treated as 1.
+//   - onEvict: The function called with each entry evicted to make room, or nil. It runs
+//     with the cache locked, so it must not use the cache.
+//
+// Returns:
+//   - A new empty LFU.
+//
+// Example:
+//
+//	c := NewLFU[string, *Template](256, nil)
+//	c.Add(name, tmpl)
+func NewLFU[K comparable, V any](capacity int, onEvict func(K, V)) *LFU[K, V] {
+	return &LFU[K, V]{
+		capacity: max(capacity, 1),
+		buckets:  list.New(),
+		items:    dictionary.NewDictionaryWithCapacity[K, *lfuItem[K, V]](max(capacity, 1)),
+		onEvict:  onEvict,
+	}
+}
+
+// NewWeightedLFU creates an empty LFU cache bounded by the total weight of its entries
+// instead of their number, for values whose sizes vary widely.
+//
+// Parameters:
+//   - maxWeight: The maximum total weight; values below 1 are treated as 1.
+//   - weigher: The function giving the weight of each entry.
+//   - onEvict: The function called with each entry evicted to make room, or nil. It runs
+//     with the cache locked, so it must not use the cache.
+//
+// Returns:
+//   - A new empty LFU with no limit on the number of entries.
+//
+// Example:
+//
+//	c := NewWeightedLFU(1<<30, func(id string, img *Image) int64 {
+//		return int64(len(img.Pix))
+//	}, nil)
+func NewWeightedLFU[K comparable, V any](maxWeight int64, weigher Weigher[K, V], onEvict func(K, V)) *LFU[K, V] {
+	return &LFU[K, V]{
+		capacity: math.MaxInt,
+		buckets:  list.New(),
+		items:    dictionary.DefaultDictionary[K, *lfuItem[K, V]](),
+		onEvict:  onEvict,
+		weights:  weights[K, V]{weigher: weigher, maxWeight: max(maxWeight, 1)},
+	}
+}
+
+// Get returns the value stored under key and counts a use of it.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (c *LFU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	it, ok := c.items[key]
+	c.lookup(ok)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.touch(it)
+	return it.entry.Value, true
+}
+
+// Peek returns the value stored under key without counting a use.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (c *LFU[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	it, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return it.entry.Value, true
+}
+
+// Contains checks if key is cached, without counting a use.
+func (c *LFU[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.items.ContainsKey(key)
+}
+
+// Frequency returns how many times key has been used since it was cached, or 0 if it is absent.
+func (c *LFU[K, V]) Frequency(key K) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	it, ok := c.items[key]
+	if !ok {
+		return 0
+	}
+	return it.bucket.Value.(*lfuBucket[K, V]).count
+}
+
+// Add stores value under key and counts a use of it, evicting the least frequently used
+// entry if the cache is full. A new key starts with a count of one.
+//
+// A cache bounded by weight evicts least frequently used entries until its total weight is
+// within the budget again. That may include the new entry, which is then the least
+// frequently used, so an entry is only kept if it is used as often as those it would push out.
+//
+// Parameters:
+//   - key: The key for which the value needs to be cached.
+//   - value: The value to be cached.
+//
+// Returns:
+//   - dictionary.Entry[K, V]: The evicted entry, or the zero Entry if none was evicted. If
+//     several were evicted, it is the first of them; all of them go to the eviction callback.
+//   - bool: True if an entry was evicted, false otherwise.
+func (c *LFU[K, V]) Add(key K, value V) (dictionary.Entry[K, V], bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var (
+		evicted    dictionary.Entry[K, V]
+		hasEvicted bool
+	)
+	if it, ok := c.items[key]; ok {
+		c.total += c.weigh(key, value) - c.weigh(key, it.entry.Value)
+		it.entry.Value = value
+		c.touch(it)
+	} else {
+		if len(c.items) >= c.capacity {
+			evicted, hasEvicted = c.evict(), true
+		}
+		first := c.buckets.Front()
+		if first == nil || first.Value.(*lfuBucket[K, V]).count != 1 {
+			first = c.buckets.PushFront(&lfuBucket[K, V]{count: 1, items: list.New()})
+		}
+		it := &lfuItem[K, V]{entry: dictionary.Entry[K, V]{Key: key, Value: value}, bucket: first}
+		it.elem = first.Value.(*lfuBucket[K, V]).items.PushFront(it)
+		c.items[key] = it
+		c.total += c.weigh(key, value)
+	}
+	for c.overweight() {
+		e := c.evict()
+		if !hasEvicted {
+			evicted, hasEvicted = e, true
+		}
+	}
+	return evicted, hasEvicted
+}
+
+// Set stores value under key, as Add does without reporting evictions.
+func (c *LFU[K, V]) Set(key K, value V) {
+	c.Add(key, value)
+}
+
+// Delete removes key and reports whether it was present. The eviction callback is not called.
+func (c *LFU[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	it, ok := c.items[key]
+	if ok {
+		c.unlink(it)
+		delete(c.items, key)
+		c.total -= c.weigh(it.entry.Key, it.entry.Value)
+	}
+	return ok
+}
+
+// Keys returns the cached keys from most to least frequently used.
+func (c *LFU[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]K, 0, len(c.items))
+	for b := c.buckets.Back(); b != nil; b = b.Prev() {
+		for e := b.Value.(*lfuBucket[K, V]).items.Front(); e != nil; e = e.Next() {
+			keys = append(keys, e.Value.(*lfuItem[K, V]).entry.Key)
+		}
+	}
+	return keys
+}
+
+// GetLength returns the number of cached entries.
+func (c *LFU[K, V]) GetLength() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Weight returns the total weight of the cached entries, or 0 if the cache is not bounded
+// by weight.
+func (c *LFU[K, V]) Weight() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.total
+}
+
+// Capacity returns the maximum number of entries, which is math.MaxInt for a cache
+// bounded by weight.
+func (c *LFU[K, V]) Capacity() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.capacity
+}
+
+// Resize changes the capacity, evicting least frequently used entries if the cache holds
+// more than the new capacity.
+//
+// Parameters:
+//   - capacity: The new maximum number of entries; values below 1 are treated as 1.
+//
+// Returns:
+//   - int: The number of entries evicted.
+func (c *LFU[K, V]) Resize(capacity int) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capacity = max(capacity, 1)
+	evicted := 0
+	for len(c.items) > c.capacity {
+		c.evict()
+		evicted++
+	}
+	return evicted
+}
+
+// Purge removes every entry. The eviction callback is not called.
+func (c *LFU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.buckets.Init()
+	c.items.ClearDictionary()
+	c.total = 0
+}
+
+// touch moves it to the bucket of the next count. The caller must hold c.mu.
+func (c *LFU[K, V]) touch(it *lfuItem[K, V]) {
+	cur := it.bucket
+	count := cur.Value.(*lfuBucket[K, V]).count + 1
+	next := cur.Next()
+	if next == nil || next.Value.(*lfuBucket[K, V]).count != count {
+		next = c.buckets.InsertAfter(&lfuBucket[K, V]{count: count, items: list.New()}, cur)
+	}
+	c.unlink(it)
+	it.bucket = next
+	it.elem = next.Value.(*lfuBucket[K, V]).items.PushFront(it)
+}
+
+// unlink removes it from its bucket, dropping the bucket if it becomes empty. The caller
+// must hold c.mu.
+func (c *LFU[K, V]) unlink(it *lfuItem[K, V]) {
+	b := it.bucket.Value.(*lfuBucket[K, V])
+	b.items.Remove(it.elem)
+	if b.items.Len() == 0 {
+		c.buckets.Remove(it.bucket)
+	}
+}
+
+// evict removes the least recently used of the least frequently used entries and returns
+// it. The caller must hold c.mu and ensure the cache is not empty.
+func (c *LFU[K, V]) evict() dictionary.Entry[K, V] {
+	b := c.buckets.Front().Value.(*lfuBucket[K, V])
+	it := b.items.Back().Value.(*lfuItem[K, V])
+	c.unlink(it)
+	delete(c.items, it.entry.Key)
+	c.total -= c.weigh(it.entry.Key, it.entry.Value)
+	c.evicted()
+	if c.onEvict != nil {
+		c.onEvict(it.entry.Key, it.entry.Value)
+	}
+	return it.entry
+}
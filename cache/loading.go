@@ -0,0 +1,399 @@
+package cache
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// LoadingOptions configures a LoadingCache.
+type LoadingOptions[K comparable, V any] struct {
+	// Loader produces the value of a missing key. It is required. Its context carries the
+	// values of the context of the Get that started the load, but is not canceled with it,
+	// since other callers may be waiting for the same load.
+	Loader func(ctx context.Context, key K) (V, error)
+	// BatchLoader, if set, is used by GetManyOrLoad to load several missing keys in one
+	// call. It returns the values it found; a key it leaves out fails with
+	// errs.ErrKeyNotFound, and an error fails every key of the batch.
+	BatchLoader func(ctx context.Context, keys []K) (dictionary.Dictionary[K, V], error)
+	// MaxBatch, when positive, splits the keys given to BatchLoader into batches of at
+	// most MaxBatch keys, loaded in parallel. Zero or less means a single batch.
+	MaxBatch int
+	// Capacity bounds the number of stored results, evicting the least recently used;
+	// zero or less means unbounded.
+	Capacity int
+	// Weigher and MaxWeight, when both set, bound the stored values by their total weight
+	// instead of by Capacity. Cached errors weigh nothing.
+	Weigher   Weigher[K, V]
+	MaxWeight int64
+	// TTL is how long a loaded value is served before it must be loaded again; zero or
+	// less means values never expire.
+	TTL time.Duration
+	// RefreshAfter, when positive, reloads a value in the background once it is older than
+	// RefreshAfter, while Gets keep returning the current value. It should be shorter than TTL.
+	RefreshAfter time.Duration
+	// NegativeTTL, when positive, caches loader errors for that long, so a failing key is
+	// not loaded again on every Get. Zero or less means errors are not cached.
+	NegativeTTL time.Duration
+}
+
+// loadResult is a stored outcome of the loader.
+type loadResult[V any] struct {
+	value  V
+	err    error
+	loaded time.Time
+}
+
+// resultStore is where a LoadingCache keeps its results: an LRU when it is bounded and a
+// TTLCache otherwise, which frees results once they expire.
+type resultStore[K comparable, V any] interface {
+	collection.Cache[K, loadResult[V]]
+	Purge()
+}
+
+// loadCall is a load in progress that any number of Gets can wait for.
+type loadCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// LoadingCache is a cache that fills misses from a loader function. Concurrent Gets of the
+// same missing key share a single load, loaded values can be refreshed ahead of expiry, and
+// errors can be cached for a while.
+//
+// LoadingCache does not implement collection.Cache, because its Get can fail and takes a
+// context; Peek offers the plain lookup.
+type LoadingCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	results resultStore[K, V]
+	calls   dictionary.Dictionary[K, *loadCall[V]]
+	opts    LoadingOptions[K, V]
+	metrics
+}
+
+// NewLoadingCache creates an empty LoadingCache. It panics if opts.Loader is nil.
+//
+// Parameters:
+//   - opts: The loader, capacity, expiry, refresh and error caching settings.
+//
+// Returns:
+//   - A new empty LoadingCache.
+//
+// Example:
+//
+//	users := NewLoadingCache(LoadingOptions[int, User]{
+//		Loader:       db.LoadUser,
+//		Capacity:     10000,
+//		TTL:          10 * time.Minute,
+//		RefreshAfter: 5 * time.Minute,
+//	})
+//	u, err := users.Get(ctx, id)
+func NewLoadingCache[K comparable, V any](opts LoadingOptions[K, V]) *LoadingCache[K, V] {
+	if opts.Loader == nil {
+		panic("cache: NewLoadingCache needs a Loader")
+	}
+	c := &LoadingCache[K, V]{
+		calls: dictionary.DefaultDictionary[K, *loadCall[V]](),
+		opts:  opts,
+	}
+	onEvict := func(K, loadResult[V]) { c.evicted() }
+	switch {
+	case opts.Weigher != nil && opts.MaxWeight > 0:
+		c.results = NewWeightedLRU(opts.MaxWeight, func(key K, r loadResult[V]) int64 {
+			if r.err != nil {
+				return 0
+			}
+			return opts.Weigher(key, r.value)
+		}, onEvict)
+	case opts.Capacity > 0:
+		c.results = NewLRU(opts.Capacity, onEvict)
+	default:
+		c.results = NewTTLCache(TTLOptions[K, loadResult[V]]{DefaultTTL: opts.TTL})
+	}
+	return c
+}
+
+// Get returns the value of key, loading it if it is missing or expired. If a load of key
+// is already in progress, Get waits for it instead of starting another. If the value is
+// due for refresh, Get returns it and starts a reload in the background.
+//
+// Parameters:
+//   - ctx: Bounds how long Get waits for the load; canceling it does not cancel the load.
+//   - key: The key whose value is needed.
+//
+// Returns:
+//   - V: The value, or the zero value on error.
+//   - error: The loader's error, possibly cached, or ctx.Err() if ctx ends first.
+func (c *LoadingCache[K, V]) Get(ctx context.Context, key K) (V, error) {
+	now := time.Now()
+	c.mu.Lock()
+	if r, ok := c.results.Get(key); ok && !c.expired(r, now) {
+		c.lookup(true)
+		if r.err == nil && c.opts.RefreshAfter > 0 && now.Sub(r.loaded) >= c.opts.RefreshAfter {
+			c.load(ctx, key)
+		}
+		c.mu.Unlock()
+		return r.value, r.err
+	}
+	c.lookup(false)
+	call := c.load(ctx, key)
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// GetManyOrLoad returns the values of keys, loading the missing and expired ones together:
+// with a BatchLoader, in one call per batch of at most MaxBatch keys, and otherwise with
+// one Loader call per key, all in parallel. Keys whose load is already in progress are
+// waited for instead of loaded again, and the keys of a batch are visible as in progress
+// to other Gets until it completes. Values due for refresh are returned and reloaded in
+// the background, as by Get.
+//
+// Parameters:
+//   - ctx: Bounds how long GetManyOrLoad waits for the loads; canceling it does not cancel them.
+//   - keys: The keys whose values are needed. Repeated keys are looked up once.
+//
+// Returns:
+//   - dictionary.Dictionary[K, V]: The values of the keys that were found or loaded.
+//   - error: A *errs.KeyError wrapping the error of the first key, in the order of keys,
+//     that failed to load, or ctx.Err() if ctx ends first; nil otherwise.
+//
+// Example:
+//
+//	users := NewLoadingCache(LoadingOptions[int, User]{
+//		Loader: db.LoadUser,
+//		BatchLoader: func(ctx context.Context, ids []int) (dictionary.Dictionary[int, User], error) {
+//			return db.LoadUsers(ctx, ids) // SELECT ... WHERE id IN (...)
+//		},
+//		MaxBatch: 500,
+//		TTL:      10 * time.Minute,
+//	})
+//	found, err := users.GetManyOrLoad(ctx, ids)
+func (c *LoadingCache[K, V]) GetManyOrLoad(ctx context.Context, keys []K) (dictionary.Dictionary[K, V], error) {
+	now := time.Now()
+	values := dictionary.NewDictionaryWithCapacity[K, V](len(keys))
+	failed := dictionary.DefaultDictionary[K, error]()
+	waits := dictionary.DefaultDictionary[K, *loadCall[V]]()
+	var misses []K
+	c.mu.Lock()
+	for _, key := range keys {
+		if values.ContainsKey(key) || failed.ContainsKey(key) || waits.ContainsKey(key) {
+			continue
+		}
+		if r, ok := c.results.Get(key); ok && !c.expired(r, now) {
+			c.lookup(true)
+			if r.err != nil {
+				failed[key] = r.err
+				continue
+			}
+			values[key] = r.value
+			if c.opts.RefreshAfter > 0 && now.Sub(r.loaded) >= c.opts.RefreshAfter {
+				c.load(ctx, key)
+			}
+			continue
+		}
+		c.lookup(false)
+		if call, ok := c.calls[key]; ok {
+			waits[key] = call
+			continue
+		}
+		misses = append(misses, key)
+	}
+	switch {
+	case len(misses) == 0:
+	case c.opts.BatchLoader == nil:
+		for _, key := range misses {
+			waits[key] = c.load(ctx, key)
+		}
+	default:
+		size := len(misses)
+		if c.opts.MaxBatch > 0 {
+			size = c.opts.MaxBatch
+		}
+		for batch := range slices.Chunk(misses, size) {
+			calls := make([]*loadCall[V], len(batch))
+			for i, key := range batch {
+				calls[i] = &loadCall[V]{done: make(chan struct{})}
+				c.calls[key] = calls[i]
+				waits[key] = calls[i]
+			}
+			go c.runBatch(context.WithoutCancel(ctx), batch, calls)
+		}
+	}
+	c.mu.Unlock()
+
+	var firstErr error
+	for _, key := range keys {
+		if call, ok := waits[key]; ok {
+			select {
+			case <-call.done:
+			case <-ctx.Done():
+				return values, ctx.Err()
+			}
+			delete(waits, key)
+			if call.err != nil {
+				failed[key] = call.err
+			} else {
+				values[key] = call.value
+			}
+		}
+		if err, ok := failed[key]; ok && firstErr == nil {
+			firstErr = errs.NewKeyError("load", key, err)
+		}
+	}
+	return values, firstErr
+}
+
+// Peek returns the loaded value of key without loading it.
+//
+// Returns:
+//   - V: The value, or the zero value if it is not loaded, expired, or a cached error.
+//   - bool: True if a loaded value was found, false otherwise.
+func (c *LoadingCache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.results.Get(key)
+	if !ok || r.err != nil || c.expired(r, time.Now()) {
+		var zero V
+		return zero, false
+	}
+	return r.value, true
+}
+
+// Set stores value under key as if it had just been loaded. A load of key in progress
+// is not waited for, but its result is discarded.
+func (c *LoadingCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.calls, key)
+	c.results.Set(key, loadResult[V]{value: value, loaded: time.Now()})
+}
+
+// Refresh starts a background reload of key, unless one is already in progress. The
+// current value, if any, is served until the reload finishes.
+func (c *LoadingCache[K, V]) Refresh(ctx context.Context, key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load(ctx, key)
+}
+
+// Invalidate removes the stored result of key and reports whether there was one. A load
+// of key in progress still completes for its waiters, but its result is not stored.
+func (c *LoadingCache[K, V]) Invalidate(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.calls, key)
+	return c.results.Delete(key)
+}
+
+// GetLength returns the number of stored results, including cached errors and results
+// that have expired but not yet been replaced.
+func (c *LoadingCache[K, V]) GetLength() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.results.GetLength()
+}
+
+// Purge removes every stored result. Loads in progress complete for their waiters, but
+// their results are not stored.
+func (c *LoadingCache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results.Purge()
+	c.calls.ClearDictionary()
+}
+
+// expired reports whether r must be loaded again at now.
+func (c *LoadingCache[K, V]) expired(r loadResult[V], now time.Time) bool {
+	age := now.Sub(r.loaded)
+	if r.err != nil {
+		return age >= c.opts.NegativeTTL
+	}
+	return c.opts.TTL > 0 && age >= c.opts.TTL
+}
+
+// load returns the load of key in progress, starting one if there is none. The caller
+// must hold c.mu.
+func (c *LoadingCache[K, V]) load(ctx context.Context, key K) *loadCall[V] {
+	if call, ok := c.calls[key]; ok {
+		return call
+	}
+	call := &loadCall[V]{done: make(chan struct{})}
+	c.calls[key] = call
+	go c.run(context.WithoutCancel(ctx), key, call)
+	return call
+}
+
+// run calls the loader for key and publishes the outcome of call.
+func (c *LoadingCache[K, V]) run(ctx context.Context, key K, call *loadCall[V]) {
+	start := time.Now()
+	value, err := c.opts.Loader(ctx, key)
+	now := time.Now()
+	c.loaded(now.Sub(start), err)
+	c.mu.Lock()
+	c.publish(key, call, value, err, now)
+	c.mu.Unlock()
+	close(call.done)
+}
+
+// runBatch calls the batch loader for keys and publishes the outcome of each call.
+func (c *LoadingCache[K, V]) runBatch(ctx context.Context, keys []K, calls []*loadCall[V]) {
+	start := time.Now()
+	found, err := c.opts.BatchLoader(ctx, keys)
+	now := time.Now()
+	c.loaded(now.Sub(start), err)
+	c.mu.Lock()
+	for i, key := range keys {
+		value, ok := found[key]
+		keyErr := err
+		if keyErr == nil && !ok {
+			keyErr = errs.ErrKeyNotFound
+		}
+		c.publish(key, calls[i], value, keyErr, now)
+	}
+	c.mu.Unlock()
+	for _, call := range calls {
+		close(call.done)
+	}
+}
+
+// publish stores the outcome of the load call of key, unless the call was superseded,
+// and records it in call for its waiters, who are released when call.done is closed.
+// The caller must hold c.mu.
+func (c *LoadingCache[K, V]) publish(key K, call *loadCall[V], value V, err error, now time.Time) {
+	if c.calls[key] == call {
+		delete(c.calls, key)
+		switch {
+		case err == nil:
+			c.results.Set(key, loadResult[V]{value: value, loaded: now})
+		case c.opts.NegativeTTL > 0:
+			// A failed refresh keeps serving the current value until it expires.
+			if r, ok := c.results.Get(key); !ok || r.err != nil || c.expired(r, now) {
+				c.storeError(key, loadResult[V]{err: err, loaded: now})
+			}
+		}
+	}
+	call.value, call.err = value, err
+}
+
+// storeError stores the cached error r, which expires after NegativeTTL rather than TTL.
+// The caller must hold c.mu.
+func (c *LoadingCache[K, V]) storeError(key K, r loadResult[V]) {
+	if store, ok := c.results.(*TTLCache[K, loadResult[V]]); ok {
+		store.SetWithTTL(key, r, c.opts.NegativeTTL)
+		return
+	}
+	c.results.Set(key, r)
+}
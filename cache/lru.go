@@ -0,0 +1,268 @@
+// Package cache provides in-memory caches, bounded by size or by time, with different
+// eviction policies. Every cache is safe for concurrent use, and every cache but
+// LoadingCache implements collection.Cache, so callers can swap policies without other
+// changes. LoadingCache fills misses from a loader function.
+package cache
+
+import (
+	"container/list"
+	"math"
+	"sync"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/dictionary"
+)
+
+// LRU is a cache that evicts the least recently used entry when it is full. Get and Add
+// count as uses; Peek does not. All operations take constant time, plus one call of the
+// weigher per entry added or removed for a cache made by NewWeightedLRU, which is full
+// when the total weight of its entries exceeds its budget.
+type LRU[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // of *dictionary.Entry[K, V], most recent at the front
+	items    dictionary.Dictionary[K, *list.Element]
+	onEvict  func(K, V)
+	weights[K, V]
+	metrics
+}
+
+var _ collection.Cache[string, int] = (*LRU[string, int])(nil)
+
+// NewLRU creates an empty LRU cache.
+//
+// Parameters:
+//   - capacity: The maximum number of entries; values below 1 are treated as 1.
+//   - onEvict: The function called with each entry evicted to make room, or nil. It runs
+//     with the cache locked, so it must not use the cache.
+//
+// Returns:
+//   - A new empty LRU.
+//
+// Example:
+//
+//	c := NewLRU[string, []byte](1024, nil)
+//	c.Add("a", data)
+//	if v, ok := c.Get("a"); ok {
+//		serve(v)
+//	}
+func NewLRU[K comparable, V any](capacity int, onEvict func(K, V)) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: max(capacity, 1),
+		order:    list.New(),
+		items:    dictionary.NewDictionaryWithCapacity[K, *list.Element](max(capacity, 1)),
+		onEvict:  onEvict,
+	}
+}
+
+// NewWeightedLRU creates an empty LRU cache bounded by the total weight of its entries
+// instead of their number, for values whose sizes vary widely.
+//
+// Parameters:
+//   - maxWeight: The maximum total weight; values below 1 are treated as 1.
+//   - weigher: The function giving the weight of each entry.
+//   - onEvict: The function called with each entry evicted to make room, or nil. It runs
+//     with the cache locked, so it must not use the cache.
+//
+// Returns:
+//   - A new empty LRU with no limit on the number of entries.
+//
+// Example:
+//
+//	c := NewWeightedLRU(64<<20, func(path string, body []byte) int64 {
+//		return int64(len(path) + len(body))
+//	}, nil)
+func NewWeightedLRU[K comparable, V any](maxWeight int64, weigher Weigher[K, V], onEvict func(K, V)) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: math.MaxInt,
+		order:    list.New(),
+		items:    dictionary.DefaultDictionary[K, *list.Element](),
+		onEvict:  onEvict,
+		weights:  weights[K, V]{weigher: weigher, maxWeight: max(maxWeight, 1)},
+	}
+}
+
+// entryOf returns the entry stored in elem.
+func entryOf[K comparable, V any](elem *list.Element) *dictionary.Entry[K, V] {
+	return elem.Value.(*dictionary.Entry[K, V])
+}
+
+// Get returns the value stored under key and marks it as most recently used.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	c.lookup(ok)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return entryOf[K, V](elem).Value, true
+}
+
+// Peek returns the value stored under key without changing its recency.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return entryOf[K, V](elem).Value, true
+}
+
+// Contains checks if key is cached, without changing its recency.
+func (c *LRU[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.items.ContainsKey(key)
+}
+
+// Add stores value under key as the most recently used entry, evicting the least recently
+// used entry if the cache is full. A cache bounded by weight evicts least recently used
+// entries until its total weight is within the budget again, which evicts the new entry
+// too if it is heavier than the whole budget.
+//
+// Parameters:
+//   - key: The key for which the value needs to be cached.
+//   - value: The value to be cached.
+//
+// Returns:
+//   - dictionary.Entry[K, V]: The evicted entry, or the zero Entry if none was evicted. If
+//     several were evicted, it is the first of them; all of them go to the eviction callback.
+//   - bool: True if an entry was evicted, false otherwise.
+//
+// Example:
+//
+//	if old, evicted := c.Add(key, value); evicted {
+//		log.Printf("dropped %v", old.Key)
+//	}
+func (c *LRU[K, V]) Add(key K, value V) (dictionary.Entry[K, V], bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		e := entryOf[K, V](elem)
+		c.total += c.weigh(key, value) - c.weigh(key, e.Value)
+		e.Value = value
+		c.order.MoveToFront(elem)
+	} else {
+		c.items[key] = c.order.PushFront(&dictionary.Entry[K, V]{Key: key, Value: value})
+		c.total += c.weigh(key, value)
+	}
+	var (
+		evicted    dictionary.Entry[K, V]
+		hasEvicted bool
+	)
+	for c.order.Len() > c.capacity || c.overweight() {
+		e := c.evictOldest()
+		if !hasEvicted {
+			evicted, hasEvicted = e, true
+		}
+	}
+	return evicted, hasEvicted
+}
+
+// Set stores value under key, as Add does without reporting evictions.
+func (c *LRU[K, V]) Set(key K, value V) {
+	c.Add(key, value)
+}
+
+// Delete removes key and reports whether it was present. The eviction callback is not called.
+func (c *LRU[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if ok {
+		e := entryOf[K, V](elem)
+		c.total -= c.weigh(e.Key, e.Value)
+		c.order.Remove(elem)
+		delete(c.items, key)
+	}
+	return ok
+}
+
+// Keys returns the cached keys from most to least recently used.
+func (c *LRU[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]K, 0, c.order.Len())
+	for elem := c.order.Front(); elem != nil; elem = elem.Next() {
+		keys = append(keys, entryOf[K, V](elem).Key)
+	}
+	return keys
+}
+
+// GetLength returns the number of cached entries.
+func (c *LRU[K, V]) GetLength() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Weight returns the total weight of the cached entries, or 0 if the cache is not bounded
+// by weight.
+func (c *LRU[K, V]) Weight() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.total
+}
+
+// Capacity returns the maximum number of entries, which is math.MaxInt for a cache
+// bounded by weight.
+func (c *LRU[K, V]) Capacity() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.capacity
+}
+
+// Resize changes the capacity, evicting least recently used entries if the cache holds
+// more than the new capacity.
+//
+// Parameters:
+//   - capacity: The new maximum number of entries; values below 1 are treated as 1.
+//
+// Returns:
+//   - int: The number of entries evicted.
+func (c *LRU[K, V]) Resize(capacity int) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capacity = max(capacity, 1)
+	evicted := 0
+	for c.order.Len() > c.capacity {
+		c.evictOldest()
+		evicted++
+	}
+	return evicted
+}
+
+// Purge removes every entry. The eviction callback is not called.
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.items.ClearDictionary()
+	c.total = 0
+}
+
+// evictOldest removes the least recently used entry and returns it. The caller must hold
+// c.mu and ensure the cache is not empty.
+func (c *LRU[K, V]) evictOldest() dictionary.Entry[K, V] {
+	e := *c.order.Remove(c.order.Back()).(*dictionary.Entry[K, V])
+	delete(c.items, e.Key)
+	c.total -= c.weigh(e.Key, e.Value)
+	c.evicted()
+	if c.onEvict != nil {
+		c.onEvict(e.Key, e.Value)
+	}
+	return e
+}
@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"expvar"
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a snapshot of the counters of a cache.
+type Stats struct {
+	// Hits and Misses count the lookups that counted as uses: Get, but not Peek or Contains.
+	Hits, Misses uint64
+	// Evictions counts the entries removed by the cache itself, to make room or because
+	// they expired, but not those removed by Delete or Purge.
+	Evictions uint64
+	// Loads and LoadErrors count the calls of a LoadingCache's loader and those that failed.
+	Loads, LoadErrors uint64
+	// LoadTime is the total time spent in the loader.
+	LoadTime time.Duration
+}
+
+// HitRate returns the fraction of lookups that were hits, or 0 if there were none.
+func (s Stats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// MetricsSink receives the events of a cache as they happen, so they can be exported to a
+// monitoring system. Its methods may be called concurrently, sometimes with the cache
+// locked, so they must be fast and must not use the cache.
+type MetricsSink interface {
+	RecordHit()
+	RecordMiss()
+	RecordEviction()
+	RecordLoad(d time.Duration, err error)
+}
+
+// metrics keeps the counters of a cache and forwards its events to a MetricsSink. Every
+// cache embeds one, which provides its Stats and SetMetricsSink methods.
+type metrics struct {
+	hits, misses, evictions atomic.Uint64
+	loads, loadErrors       atomic.Uint64
+	loadTime                atomic.Int64
+	sink                    atomic.Pointer[MetricsSink]
+}
+
+// Stats returns the current counters of the cache.
+func (m *metrics) Stats() Stats {
+	return Stats{
+		Hits:       m.hits.Load(),
+		Misses:     m.misses.Load(),
+		Evictions:  m.evictions.Load(),
+		Loads:      m.loads.Load(),
+		LoadErrors: m.loadErrors.Load(),
+		LoadTime:   time.Duration(m.loadTime.Load()),
+	}
+}
+
+// SetMetricsSink sends the future events of the cache to sink, or to none if sink is nil.
+// The counters reported by Stats are kept either way.
+func (m *metrics) SetMetricsSink(sink MetricsSink) {
+	if sink == nil {
+		m.sink.Store(nil)
+		return
+	}
+	m.sink.Store(&sink)
+}
+
+// lookup records a hit or a miss.
+func (m *metrics) lookup(hit bool) {
+	if hit {
+		m.hits.Add(1)
+	} else {
+		m.misses.Add(1)
+	}
+	if sink := m.sink.Load(); sink != nil {
+		if hit {
+			(*sink).RecordHit()
+		} else {
+			(*sink).RecordMiss()
+		}
+	}
+}
+
+// evicted records an eviction.
+func (m *metrics) evicted() {
+	m.evictions.Add(1)
+	if sink := m.sink.Load(); sink != nil {
+		(*sink).RecordEviction()
+	}
+}
+
+// loaded records a call of the loader that took d and returned err.
+func (m *metrics) loaded(d time.Duration, err error) {
+	m.loads.Add(1)
+	if err != nil {
+		m.loadErrors.Add(1)
+	}
+	m.loadTime.Add(int64(d))
+	if sink := m.sink.Load(); sink != nil {
+		(*sink).RecordLoad(d, err)
+	}
+}
+
+// ExpvarSink is a MetricsSink that publishes the events of a cache as an expvar.Map with
+// the counters hits, misses, evictions, loads, load_errors and load_time_ns.
+type ExpvarSink struct {
+	m *expvar.Map
+}
+
+var _ MetricsSink = (*ExpvarSink)(nil)
+
+// NewExpvarSink creates an ExpvarSink published under name. Like expvar.NewMap, it panics
+// if name is already in use.
+//
+// Parameters:
+//   - name: The expvar name of the counters.
+//
+// Returns:
+//   - A new ExpvarSink with every counter at zero.
+//
+// Example:
+//
+//	c := NewLRU[string, []byte](1024, nil)
+//	c.SetMetricsSink(NewExpvarSink("cache.thumbnails"))
+func NewExpvarSink(name string) *ExpvarSink {
+	return &ExpvarSink{m: expvar.NewMap(name)}
+}
+
+func (s *ExpvarSink) RecordHit()      { s.m.Add("hits", 1) }
+func (s *ExpvarSink) RecordMiss()     { s.m.Add("misses", 1) }
+func (s *ExpvarSink) RecordEviction() { s.m.Add("evictions", 1) }
+
+func (s *ExpvarSink) RecordLoad(d time.Duration, err error) {
+	s.m.Add("loads", 1)
+	if err != nil {
+		s.m.Add("load_errors", 1)
+	}
+	s.m.Add("load_time_ns", int64(d))
+}
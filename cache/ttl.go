@@ -0,0 +1,389 @@
+package cache
+
+import (
+	"container/heap"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/dictionary"
+)
+
+// ttlEntry is a cached value with its lifetime. Entries that never expire have a zero
+// deadline and are not in the deadline heap, which is marked by index -1.
+type ttlEntry[K comparable, V any] struct {
+	key      K
+	value    V
+	ttl      time.Duration
+	deadline time.Time
+	index    int
+	// stored is when value was stored, and version counts the stores, so a repair can
+	// tell whether the value it re-validated is still current.
+	stored    time.Time
+	version   uint64
+	repairing bool
+}
+
+// ttlHeap is a min-heap of entries by deadline that keeps each entry's index up to date,
+// so an entry can be moved or removed in logarithmic time.
+type ttlHeap[K comparable, V any] []*ttlEntry[K, V]
+
+func (h ttlHeap[K, V]) Len() int           { return len(h) }
+func (h ttlHeap[K, V]) Less(i, j int) bool { return h[i].deadline.Before(h[j].deadline) }
+
+func (h ttlHeap[K, V]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+
+func (h *ttlHeap[K, V]) Push(x any) {
+	e := x.(*ttlEntry[K, V])
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *ttlHeap[K, V]) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	e.index = -1
+	return e
+}
+
+// TTLOptions configures a TTLCache.
+type TTLOptions[K comparable, V any] struct {
+	// DefaultTTL is the time to live of entries stored with Set. Zero or less means they
+	// never expire.
+	DefaultTTL time.Duration
+	// Sliding, when true, restarts an entry's time to live whenever Get finds it, so only
+	// entries that go unused expire.
+	Sliding bool
+	// JanitorInterval is how often a background goroutine removes expired entries. Zero or
+	// less starts no janitor; expired entries are then removed only when the cache is used.
+	JanitorInterval time.Duration
+	// OnEvict, if not nil, is called with each entry removed because it expired. It runs
+	// without the cache's lock held, so it may use the cache.
+	OnEvict func(K, V)
+	// Jitter, between 0 and 1, spreads every time to live uniformly within ±Jitter of its
+	// length, so entries stored together do not all expire together. For example, 0.1
+	// turns a time to live of 10 minutes into one between 9 and 11 minutes.
+	Jitter float64
+	// RepairAfter and Repair, when both set, enable read-repair: a Get finding an entry
+	// stored at least RepairAfter ago returns it and calls Repair in the background to
+	// re-validate it. The value Repair returns replaces the entry, with a new time to live;
+	// an error leaves the entry to expire as usual. At most one repair of a key runs at a
+	// time, and a repair is discarded if the key is set or removed meanwhile.
+	RepairAfter time.Duration
+	Repair      func(key K, old V) (V, error)
+}
+
+// TTLCache is a cache whose entries expire after a time to live, given per entry or by
+// default. An expired entry is never returned. It is removed, and reported to the
+// eviction callback exactly once, by the next operation on the cache or by the
+// background janitor, whichever comes first.
+//
+// Call Close to stop the janitor; the cache stays usable afterwards.
+type TTLCache[K comparable, V any] struct {
+	mu        sync.Mutex
+	items     dictionary.Dictionary[K, *ttlEntry[K, V]]
+	deadlines ttlHeap[K, V]
+	opts      TTLOptions[K, V]
+	metrics
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var _ collection.Cache[string, int] = (*TTLCache[string, int])(nil)
+
+// NewTTLCache creates an empty TTLCache.
+//
+// Parameters:
+//   - opts: The default TTL, expiration mode, janitor interval and eviction callback.
+//
+// Returns:
+//   - A new empty TTLCache, whose janitor is running if opts.JanitorInterval is positive.
+//
+// Example:
+//
+//	sessions := NewTTLCache(TTLOptions[string, Session]{
+//		DefaultTTL:      30 * time.Minute,
+//		Sliding:         true,
+//		JanitorInterval: time.Minute,
+//	})
+//	defer sessions.Close()
+//	sessions.Set(id, session)
+//
+//	flags := NewTTLCache(TTLOptions[string, Flag]{
+//		DefaultTTL:  5 * time.Minute,
+//		Jitter:      0.2,
+//		RepairAfter: time.Minute,
+//		Repair: func(name string, old Flag) (Flag, error) {
+//			return flagService.Fetch(name)
+//		},
+//	})
+func NewTTLCache[K comparable, V any](opts TTLOptions[K, V]) *TTLCache[K, V] {
+	c := &TTLCache[K, V]{
+		items: dictionary.DefaultDictionary[K, *ttlEntry[K, V]](),
+		opts:  opts,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	if opts.JanitorInterval > 0 {
+		go c.janitor(opts.JanitorInterval)
+	} else {
+		close(c.done)
+	}
+	return c
+}
+
+// Get returns the value stored under key. In sliding mode it also restarts the entry's
+// time to live, and with read-repair it may start a repair of the entry.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent or expired.
+//   - bool: True if the key is present and not expired, false otherwise.
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	return c.get(key, true, c.opts.Sliding)
+}
+
+// Peek returns the value stored under key without restarting its time to live.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent or expired.
+//   - bool: True if the key is present and not expired, false otherwise.
+func (c *TTLCache[K, V]) Peek(key K) (V, bool) {
+	return c.get(key, false, false)
+}
+
+// Contains checks if key is present and not expired, without restarting its time to live.
+func (c *TTLCache[K, V]) Contains(key K) bool {
+	_, ok := c.get(key, false, false)
+	return ok
+}
+
+// get implements Get, Peek and Contains; count tells whether the lookup counts in Stats.
+func (c *TTLCache[K, V]) get(key K, count, slide bool) (V, bool) {
+	now := time.Now()
+	c.mu.Lock()
+	expired := c.removeExpired(now)
+	e, ok := c.items[key]
+	if count {
+		c.lookup(ok)
+	}
+	if ok && slide && e.index >= 0 {
+		e.deadline = now.Add(c.jitter(e.ttl))
+		heap.Fix(&c.deadlines, e.index)
+	}
+	var value V
+	if ok {
+		value = e.value
+		if count && c.opts.Repair != nil && c.opts.RepairAfter > 0 && !e.repairing &&
+			now.Sub(e.stored) >= c.opts.RepairAfter {
+			e.repairing = true
+			go c.repair(e, e.version, value)
+		}
+	}
+	c.mu.Unlock()
+	c.evict(expired)
+	return value, ok
+}
+
+// repair re-validates the entry e, whose value was old at the given version, with
+// opts.Repair, and stores the result if e still holds that version.
+func (c *TTLCache[K, V]) repair(e *ttlEntry[K, V], version uint64, old V) {
+	value, err := c.opts.Repair(e.key, old)
+	now := time.Now()
+	c.mu.Lock()
+	e.repairing = false
+	expired := c.removeExpired(now)
+	if err == nil && c.items[e.key] == e && e.version == version {
+		c.store(e.key, value, e.ttl, now)
+	}
+	c.mu.Unlock()
+	c.evict(expired)
+}
+
+// Set stores value under key with the default time to live.
+func (c *TTLCache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.opts.DefaultTTL)
+}
+
+// SetWithTTL stores value under key, to expire after ttl.
+//
+// Parameters:
+//   - key: The key for which the value needs to be cached.
+//   - value: The value to be cached.
+//   - ttl: How long the entry lives, or, in sliding mode, how long it lives unused; zero
+//     or less means it never expires.
+func (c *TTLCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	now := time.Now()
+	c.mu.Lock()
+	expired := c.removeExpired(now)
+	c.store(key, value, ttl, now)
+	c.mu.Unlock()
+	c.evict(expired)
+}
+
+// store implements SetWithTTL. The caller must hold c.mu.
+func (c *TTLCache[K, V]) store(key K, value V, ttl time.Duration, now time.Time) {
+	e, ok := c.items[key]
+	if !ok {
+		e = &ttlEntry[K, V]{key: key, index: -1}
+		c.items[key] = e
+	}
+	e.value, e.ttl = value, max(ttl, 0)
+	e.stored = now
+	e.version++
+	switch {
+	case ttl <= 0:
+		e.deadline = time.Time{}
+		if e.index >= 0 {
+			heap.Remove(&c.deadlines, e.index)
+		}
+	case e.index >= 0:
+		e.deadline = now.Add(c.jitter(ttl))
+		heap.Fix(&c.deadlines, e.index)
+	default:
+		e.deadline = now.Add(c.jitter(ttl))
+		heap.Push(&c.deadlines, e)
+	}
+}
+
+// jitter spreads the positive ttl within ±opts.Jitter of its length.
+func (c *TTLCache[K, V]) jitter(ttl time.Duration) time.Duration {
+	j := min(max(c.opts.Jitter, 0), 1)
+	if j == 0 {
+		return ttl
+	}
+	// Never less than a nanosecond: a time to live of zero would mean no expiry.
+	return max(time.Duration(float64(ttl)*(1+j*(2*rand.Float64()-1))), 1)
+}
+
+// Delete removes key and reports whether it was present and not expired. The eviction
+// callback is not called for it.
+func (c *TTLCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	expired := c.removeExpired(time.Now())
+	e, ok := c.items[key]
+	if ok {
+		c.remove(e)
+	}
+	c.mu.Unlock()
+	c.evict(expired)
+	return ok
+}
+
+// TTL returns how long key has left to live.
+//
+// Returns:
+//   - time.Duration: The remaining time to live, or 0 if the entry never expires.
+//   - bool: True if the key is present and not expired, false otherwise.
+func (c *TTLCache[K, V]) TTL(key K) (time.Duration, bool) {
+	now := time.Now()
+	c.mu.Lock()
+	expired := c.removeExpired(now)
+	e, ok := c.items[key]
+	var ttl time.Duration
+	if ok && e.index >= 0 {
+		ttl = e.deadline.Sub(now)
+	}
+	c.mu.Unlock()
+	c.evict(expired)
+	return ttl, ok
+}
+
+// Keys returns the keys that have not expired, in unspecified order.
+func (c *TTLCache[K, V]) Keys() []K {
+	c.mu.Lock()
+	expired := c.removeExpired(time.Now())
+	keys := c.items.GetKeys()
+	c.mu.Unlock()
+	c.evict(expired)
+	return keys
+}
+
+// GetLength returns the number of entries that have not expired.
+func (c *TTLCache[K, V]) GetLength() int {
+	c.mu.Lock()
+	expired := c.removeExpired(time.Now())
+	n := len(c.items)
+	c.mu.Unlock()
+	c.evict(expired)
+	return n
+}
+
+// Purge removes every entry without calling the eviction callback.
+func (c *TTLCache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items.ClearDictionary()
+	clear(c.deadlines)
+	c.deadlines = c.deadlines[:0]
+}
+
+// DeleteExpired removes every expired entry now and calls the eviction callback for each.
+//
+// Returns:
+//   - int: The number of entries removed.
+func (c *TTLCache[K, V]) DeleteExpired() int {
+	c.mu.Lock()
+	expired := c.removeExpired(time.Now())
+	c.mu.Unlock()
+	c.evict(expired)
+	return len(expired)
+}
+
+// Close stops the janitor and waits for it to exit. Calling Close more than once is harmless.
+func (c *TTLCache[K, V]) Close() {
+	c.closeOnce.Do(func() { close(c.stop) })
+	<-c.done
+}
+
+// janitor removes expired entries every interval until Close is called.
+func (c *TTLCache[K, V]) janitor(interval time.Duration) {
+	defer close(c.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.DeleteExpired()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// removeExpired removes the entries expired at now and returns them. The caller must hold c.mu.
+func (c *TTLCache[K, V]) removeExpired(now time.Time) []dictionary.Entry[K, V] {
+	var expired []dictionary.Entry[K, V]
+	for len(c.deadlines) > 0 && !now.Before(c.deadlines[0].deadline) {
+		e := heap.Pop(&c.deadlines).(*ttlEntry[K, V])
+		delete(c.items, e.key)
+		c.evicted()
+		expired = append(expired, dictionary.Entry[K, V]{Key: e.key, Value: e.value})
+	}
+	return expired
+}
+
+// remove deletes e from the cache. The caller must hold c.mu.
+func (c *TTLCache[K, V]) remove(e *ttlEntry[K, V]) {
+	delete(c.items, e.key)
+	if e.index >= 0 {
+		heap.Remove(&c.deadlines, e.index)
+	}
+}
+
+// evict reports expired entries to the eviction callback. The caller must not hold c.mu.
+func (c *TTLCache[K, V]) evict(expired []dictionary.Entry[K, V]) {
+	if c.opts.OnEvict == nil {
+		return
+	}
+	for _, e := range expired {
+		c.opts.OnEvict(e.Key, e.Value)
+	}
+}
@@ -0,0 +1,27 @@
+package cache
+
+// Weigher returns the weight of a cache entry, such as the size of its value in bytes. It
+// must return the same weight for the same entry every time it is called. Negative
+// weights count as zero.
+type Weigher[K comparable, V any] func(key K, value V) int64
+
+// weights keeps the total weight of a cache bounded by weight. Without a weigher, every
+// entry weighs nothing and the cache is never over its budget.
+type weights[K comparable, V any] struct {
+	weigher   Weigher[K, V]
+	total     int64
+	maxWeight int64
+}
+
+// weigh returns the weight of an entry.
+func (w *weights[K, V]) weigh(key K, value V) int64 {
+	if w.weigher == nil {
+		return 0
+	}
+	return max(w.weigher(key, value), 0)
+}
+
+// overweight reports whether the total weight exceeds the budget.
+func (w *weights[K, V]) overweight() bool {
+	return w.weigher != nil && w.total > w.maxWeight
+}
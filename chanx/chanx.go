@@ -0,0 +1,268 @@
+// Package chanx provides helpers for composing typed channels: merging,
+// splitting, duplicating, batching and draining, all bounded by a context.
+//
+// Every helper that returns a channel starts goroutines which stop, and close
+// their output channels, when either the input is closed or the context is
+// done. Callers that stop reading early must cancel the context so those
+// goroutines can exit.
+package chanx
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// OrDone forwards values from in until in is closed or ctx is done.
+// It lets a range loop over a channel also honor cancellation.
+//
+// Example:
+//
+//	for v := range OrDone(ctx, events) {
+//		handle(v)
+//	}
+func OrDone[T any](ctx context.Context, in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				if !send(ctx, out, v) {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// FanIn merges several channels into one. The output is closed once every
+// input is closed or ctx is done. Values from a single input keep their order;
+// values from different inputs are interleaved arbitrarily.
+//
+// Example:
+//
+//	for v := range FanIn(ctx, a, b, c) {
+//		handle(v)
+//	}
+func FanIn[T any](ctx context.Context, ins ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(ins))
+	for _, in := range ins {
+		go func(in <-chan T) {
+			defer wg.Done()
+			for v := range OrDone(ctx, in) {
+				if !send(ctx, out, v) {
+					return
+				}
+			}
+		}(in)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// FanOut distributes the values of in across n output channels. Each value is
+// delivered to exactly one output, whichever is ready first, so a slow consumer
+// does not hold back the others. n values below 1 are treated as 1.
+//
+// Example:
+//
+//	for _, ch := range FanOut(ctx, jobs, 4) {
+//		go worker(ch)
+//	}
+func FanOut[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	n = max(n, 1)
+	outs := make([]<-chan T, n)
+	for i := range outs {
+		out := make(chan T)
+		outs[i] = out
+		go func() {
+			defer close(out)
+			for v := range OrDone(ctx, in) {
+				if !send(ctx, out, v) {
+					return
+				}
+			}
+		}()
+	}
+	return outs
+}
+
+// Tee duplicates every value of in onto two output channels. A value is only
+// read from in after both outputs accepted the previous one, so the slower
+// consumer paces both.
+//
+// Example:
+//
+//	toDisk, toNetwork := Tee(ctx, records)
+func Tee[T any](ctx context.Context, in <-chan T) (<-chan T, <-chan T) {
+	out1 := make(chan T)
+	out2 := make(chan T)
+	go func() {
+		defer close(out1)
+		defer close(out2)
+		for v := range OrDone(ctx, in) {
+			// Nil out each channel once it has received v so the select
+			// waits on the other one.
+			o1, o2 := out1, out2
+			for o1 != nil || o2 != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case o1 <- v:
+					o1 = nil
+				case o2 <- v:
+					o2 = nil
+				}
+			}
+		}
+	}()
+	return out1, out2
+}
+
+// Batch groups the values of in into slices of at most size elements. A
+// partial batch is emitted once maxWait has elapsed since its first value, and
+// any remaining values are flushed when in is closed. When ctx is done the
+// pending batch is dropped. size values below 1 are treated as 1, and a
+// non-positive maxWait disables the time limit.
+//
+// Example:
+//
+//	for batch := range Batch(ctx, rows, 500, time.Second) {
+//		insert(batch)
+//	}
+func Batch[T any](ctx context.Context, in <-chan T, size int, maxWait time.Duration) <-chan []T {
+	size = max(size, 1)
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+		var (
+			batch   []T
+			timer   *time.Timer
+			timeout <-chan time.Time
+		)
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+			if len(batch) == 0 {
+				return true
+			}
+			b := batch
+			batch = nil
+			return send(ctx, out, b)
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timeout:
+				timer, timeout = nil, nil
+				if !flush() {
+					return
+				}
+			case v, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				if batch == nil {
+					batch = make([]T, 0, size)
+					if maxWait > 0 {
+						timer = time.NewTimer(maxWait)
+						timeout = timer.C
+					}
+				}
+				batch = append(batch, v)
+				if len(batch) >= size && !flush() {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// Drain reads and discards values from ch until it is closed or ctx is done.
+// It is useful for unblocking producers after a consumer has given up.
+//
+// Returns:
+//   - int: The number of values discarded.
+//   - error: ctx.Err() if ctx was done before ch was closed, nil otherwise.
+func Drain[T any](ctx context.Context, ch <-chan T) (int, error) {
+	n := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return n, ctx.Err()
+		case _, ok := <-ch:
+			if !ok {
+				return n, nil
+			}
+			n++
+		}
+	}
+}
+
+// Receive reads a single value from ch, giving up when ctx is done. Use
+// context.WithTimeout to receive with a deadline.
+//
+// Returns:
+//   - T: The received value, or the zero value on error.
+//   - error: errs.ErrClosed if ch was closed, ctx.Err() if ctx was done, nil otherwise.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(ctx, time.Second)
+//	defer cancel()
+//	v, err := Receive(ctx, results)
+//	if errors.Is(err, context.DeadlineExceeded) {
+//		// timed out
+//	}
+func Receive[T any](ctx context.Context, ch <-chan T) (T, error) {
+	var zero T
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case v, ok := <-ch:
+		if !ok {
+			return zero, errs.ErrClosed
+		}
+		return v, nil
+	}
+}
+
+// Send writes v to ch, giving up when ctx is done.
+//
+// Returns:
+//   - error: ctx.Err() if ctx was done before ch accepted v, nil otherwise.
+func Send[T any](ctx context.Context, ch chan<- T, v T) error {
+	if !send(ctx, ch, v) {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// send writes v to ch and reports whether it was delivered before ctx was done.
+func send[T any](ctx context.Context, ch chan<- T, v T) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case ch <- v:
+		return true
+	}
+}
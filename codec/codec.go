@@ -0,0 +1,319 @@
+// Package codec selects a wire format for the collection types of this module by
+// content type, so HTTP handlers can honour Accept and Content-Type headers
+// without switching on each type they serialize.
+//
+// Every collection in this module encodes through the standard interfaces
+// (json.Marshaler, gob.GobEncoder and the plain-value rules of binarycodec),
+// so any registered Codec can handle any of them.
+package codec
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bhanurp/gotypes/dictionary/binarycodec"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// Content types of the built-in codecs.
+const (
+	JSON        = "application/json"
+	Gob         = "application/x-gob"
+	MessagePack = "application/msgpack"
+	CBOR        = "application/cbor"
+)
+
+// Codec encodes and decodes values in one wire format.
+type Codec interface {
+	// ContentType returns the media type the codec reads and writes, without parameters.
+	ContentType() string
+	// Encode writes the encoding of v to w.
+	Encode(w io.Writer, v any) error
+	// Decode reads one value from r into the value pointed to by v.
+	Decode(r io.Reader, v any) error
+}
+
+// Registry maps content types to codecs. It is safe for concurrent use.
+type Registry struct {
+	mu     sync.RWMutex
+	codecs map[string]Codec
+	order  []string
+}
+
+// NewRegistry returns an empty Registry.
+//
+// Returns:
+//   - *Registry: A Registry with no codecs.
+//
+// Example:
+//
+//	r := NewRegistry()
+//	err := r.Register(JSONCodec())
+func NewRegistry() *Registry {
+	return &Registry{codecs: make(map[string]Codec)}
+}
+
+// DefaultRegistry returns a new Registry holding the JSON, gob, MessagePack and CBOR codecs,
+// with JSON as the fallback for requests that accept anything.
+//
+// Returns:
+//   - *Registry: A Registry with the built-in codecs.
+//
+// Example:
+//
+//	r := DefaultRegistry()
+//	c, err := r.Negotiate(req.Header.Get("Accept"))
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	for _, c := range []Codec{JSONCodec(), GobCodec(), MessagePackCodec(), CBORCodec()} {
+		// The built-in content types are distinct, so registration cannot fail.
+		_ = r.Register(c)
+	}
+	return r
+}
+
+// Register adds a codec under its content type. The first codec registered is the one
+// Negotiate prefers when the client accepts any type.
+//
+// Parameters:
+//   - c: The codec to be added.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrDuplicateKey if a codec is already registered
+//     for the same content type, nil otherwise.
+//
+// Example:
+//
+//	err := r.Register(myYAMLCodec)
+func (r *Registry) Register(c Codec) error {
+	ct := normalize(c.ContentType())
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.codecs[ct]; ok {
+		return errs.NewKeyError("register", ct, errs.ErrDuplicateKey)
+	}
+	r.codecs[ct] = c
+	r.order = append(r.order, ct)
+	return nil
+}
+
+// Lookup returns the codec for a Content-Type header value. Parameters such as charset are ignored.
+//
+// Parameters:
+//   - contentType: The content type, for example "application/json; charset=utf-8".
+//
+// Returns:
+//   - Codec: The matching codec, or nil if there is none.
+//   - error: An error wrapping errs.ErrUnsupportedMediaType if no codec matches, nil otherwise.
+//
+// Example:
+//
+//	c, err := r.Lookup(req.Header.Get("Content-Type"))
+//	if err == nil {
+//		err = c.Decode(req.Body, &dict)
+//	}
+func (r *Registry) Lookup(contentType string) (Codec, error) {
+	ct := normalize(contentType)
+	r.mu.RLock()
+	c, ok := r.codecs[ct]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("codec: %q: %w", contentType, errs.ErrUnsupportedMediaType)
+	}
+	return c, nil
+}
+
+// ContentTypes returns the registered content types in registration order.
+//
+// Returns:
+//   - []string: The registered content types.
+func (r *Registry) ContentTypes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string(nil), r.order...)
+}
+
+// Negotiate picks the codec best matching an Accept header value. Each codec takes the q value
+// of the most specific media range matching its content type (type/subtype over type/* over
+// */*), and a q of 0 rules it out. The codec with the highest q wins; ties go to the codec whose
+// range is listed first in the header, then to the codec registered first. An empty header
+// accepts anything and selects the first registered codec.
+//
+// Parameters:
+//   - accept: The Accept header value, for example "application/cbor, application/json;q=0.5".
+//
+// Returns:
+//   - Codec: The selected codec, or nil if none is acceptable.
+//   - error: An error wrapping errs.ErrNotAcceptable if no registered codec is acceptable, nil otherwise.
+//
+// Example:
+//
+//	c, err := r.Negotiate("application/cbor;q=0.9, application/json")
+//	// c.ContentType() will be "application/json"
+func (r *Registry) Negotiate(accept string) (Codec, error) {
+	ranges := parseAccept(accept)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		best      Codec
+		bestMatch mediaRange
+		found     bool
+	)
+	for _, ct := range r.order {
+		m, ok := bestRange(ranges, ct)
+		if !ok || m.q == 0 {
+			continue
+		}
+		if !found || m.q > bestMatch.q || (m.q == bestMatch.q && m.pos < bestMatch.pos) {
+			best, bestMatch, found = r.codecs[ct], m, true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("codec: %q: %w", accept, errs.ErrNotAcceptable)
+	}
+	return best, nil
+}
+
+// mediaRange is one parsed element of an Accept header.
+type mediaRange struct {
+	typ, subtype string
+	q            float64
+	pos          int
+}
+
+// specificity ranks a media range: 2 for type/subtype, 1 for type/*, 0 for */*.
+func (m mediaRange) specificity() int {
+	switch {
+	case m.typ == "*":
+		return 0
+	case m.subtype == "*":
+		return 1
+	}
+	return 2
+}
+
+// matches reports whether the media range covers the content type.
+func (m mediaRange) matches(contentType string) bool {
+	typ, subtype, _ := strings.Cut(contentType, "/")
+	return (m.typ == "*" || m.typ == typ) && (m.subtype == "*" || m.subtype == subtype)
+}
+
+// parseAccept parses an Accept header value, skipping malformed elements.
+// An empty value is treated as "*/*".
+func parseAccept(accept string) []mediaRange {
+	if strings.TrimSpace(accept) == "" {
+		return []mediaRange{{typ: "*", subtype: "*", q: 1}}
+	}
+	var ranges []mediaRange
+	for i, part := range strings.Split(accept, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		typ, subtype, ok := strings.Cut(mt, "/")
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(s, 64); err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange{typ: typ, subtype: subtype, q: q, pos: i})
+	}
+	return ranges
+}
+
+// bestRange returns the most specific media range matching the content type, which is
+// the one whose q value applies to it. Equally specific ranges resolve to the first listed.
+func bestRange(ranges []mediaRange, contentType string) (mediaRange, bool) {
+	var (
+		best  mediaRange
+		found bool
+	)
+	for _, m := range ranges {
+		if !m.matches(contentType) {
+			continue
+		}
+		if !found || m.specificity() > best.specificity() {
+			best, found = m, true
+		}
+	}
+	return best, found
+}
+
+// normalize strips parameters and case from a content type.
+func normalize(contentType string) string {
+	if mt, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mt
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+// funcCodec adapts a pair of functions to the Codec interface.
+type funcCodec struct {
+	contentType string
+	encode      func(io.Writer, any) error
+	decode      func(io.Reader, any) error
+}
+
+func (c funcCodec) ContentType() string             { return c.contentType }
+func (c funcCodec) Encode(w io.Writer, v any) error { return c.encode(w, v) }
+func (c funcCodec) Decode(r io.Reader, v any) error { return c.decode(r, v) }
+
+// JSONCodec returns a codec for application/json using encoding/json.
+//
+// Returns:
+//   - Codec: The JSON codec.
+func JSONCodec() Codec {
+	return funcCodec{
+		contentType: JSON,
+		encode:      func(w io.Writer, v any) error { return json.NewEncoder(w).Encode(v) },
+		decode:      func(r io.Reader, v any) error { return json.NewDecoder(r).Decode(v) },
+	}
+}
+
+// GobCodec returns a codec for application/x-gob using encoding/gob.
+//
+// Returns:
+//   - Codec: The gob codec.
+func GobCodec() Codec {
+	return funcCodec{
+		contentType: Gob,
+		encode:      func(w io.Writer, v any) error { return gob.NewEncoder(w).Encode(v) },
+		decode:      func(r io.Reader, v any) error { return gob.NewDecoder(r).Decode(v) },
+	}
+}
+
+// MessagePackCodec returns a codec for application/msgpack using binarycodec.
+//
+// Returns:
+//   - Codec: The MessagePack codec.
+func MessagePackCodec() Codec {
+	return binaryCodec(MessagePack, binarycodec.MessagePack)
+}
+
+// CBORCodec returns a codec for application/cbor using binarycodec.
+//
+// Returns:
+//   - Codec: The CBOR codec.
+func CBORCodec() Codec {
+	return binaryCodec(CBOR, binarycodec.CBOR)
+}
+
+// binaryCodec wraps a binarycodec format.
+func binaryCodec(contentType string, f binarycodec.Format) Codec {
+	return funcCodec{
+		contentType: contentType,
+		encode:      func(w io.Writer, v any) error { return binarycodec.NewEncoder(w, f).Encode(v) },
+		decode:      func(r io.Reader, v any) error { return binarycodec.NewDecoder(r, f).Decode(v) },
+	}
+}
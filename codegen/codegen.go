@@ -0,0 +1,317 @@
+// Package codegen writes Dictionaries out as Go source code, so static lookup
+// data can be compiled into a binary instead of being parsed at startup.
+//
+// It is meant to be driven from a small generator program invoked by
+// go:generate:
+//
+//	//go:generate go run ./gen -out countries_gen.go
+//
+// where the generator loads the data and calls WriteDictionary.
+package codegen
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"go/format"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// Options controls the generated file.
+type Options struct {
+	// Package is the name of the package the generated file belongs to.
+	Package string
+	// Var is the name of the generated variable.
+	Var string
+	// Generator, if set, is named in the "Code generated by" header.
+	Generator string
+}
+
+// WriteDictionary writes a gofmt-formatted Go source file declaring opts.Var as a
+// Dictionary literal holding the entries of d, sorted by key.
+//
+// Parameters:
+//   - w: The writer receiving the source file.
+//   - d: The Dictionary to be written.
+//   - opts: The package and variable names.
+//
+// Returns:
+//   - error: An error wrapping errs.ErrInvalidEntry if opts.Package or opts.Var is empty, one
+//     wrapping errs.ErrUnsupportedType if a key or value cannot be written as a Go literal
+//     without importing other packages, as is the case for named types, pointers, channels,
+//     functions and interfaces holding such values, and for NaN and infinite floats; or the
+//     error returned by w.
+//
+// Example:
+//
+//	dict := dictionary.Dictionary[string, int]{"de": 49, "fr": 33}
+//	err := WriteDictionary(f, dict, Options{Package: "phone", Var: "CountryCodes"})
+//	// f now holds:
+//	//
+//	//	// Code generated by codegen. DO NOT EDIT.
+//	//
+//	//	package phone
+//	//
+//	//	import "github.com/bhanurp/gotypes/dictionary"
+//	//
+//	//	var CountryCodes = dictionary.Dictionary[string, int]{
+//	//		"de": 49,
+//	//		"fr": 33,
+//	//	}
+func WriteDictionary[K comparable, V any](w io.Writer, d dictionary.Dictionary[K, V], opts Options) error {
+	if opts.Package == "" || opts.Var == "" {
+		return fmt.Errorf("codegen: package and variable names are required: %w", errs.ErrInvalidEntry)
+	}
+	keyType, err := typeName(reflect.TypeFor[K]())
+	if err != nil {
+		return err
+	}
+	valueType, err := typeName(reflect.TypeFor[V]())
+	if err != nil {
+		return err
+	}
+
+	type line struct {
+		key   reflect.Value
+		text  string
+		value string
+	}
+	lines := make([]line, 0, len(d))
+	for k, v := range d {
+		kv, vv := reflect.ValueOf(&k).Elem(), reflect.ValueOf(&v).Elem()
+		kt, err := literal(kv)
+		if err != nil {
+			return err
+		}
+		vt, err := literal(vv)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, line{kv, kt, vt})
+	}
+	slices.SortFunc(lines, func(a, b line) int {
+		if c := compareNumeric(a.key, b.key); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.text, b.text)
+	})
+
+	generator := opts.Generator
+	if generator == "" {
+		generator = "codegen"
+	}
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by %s. DO NOT EDIT.\n\n", generator)
+	fmt.Fprintf(&src, "package %s\n\n", opts.Package)
+	src.WriteString("import \"github.com/bhanurp/gotypes/dictionary\"\n\n")
+	fmt.Fprintf(&src, "var %s = dictionary.Dictionary[%s, %s]{\n", opts.Var, keyType, valueType)
+	for _, l := range lines {
+		fmt.Fprintf(&src, "%s: %s,\n", l.text, l.value)
+	}
+	src.WriteString("}\n")
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return fmt.Errorf("codegen: format generated source: %w", err)
+	}
+	_, err = w.Write(formatted)
+	return err
+}
+
+// typeName returns the Go spelling of t, which must be built from predeclared types only.
+func typeName(t reflect.Type) (string, error) {
+	if t.PkgPath() != "" || (t.Name() == "" && t.Kind() != reflect.Slice && t.Kind() != reflect.Array &&
+		t.Kind() != reflect.Map && t.Kind() != reflect.Struct && t.Kind() != reflect.Interface) {
+		return "", fmt.Errorf("codegen: %s: %w", t, errs.ErrUnsupportedType)
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		elem, err := typeName(t.Elem())
+		return "[]" + elem, err
+	case reflect.Array:
+		elem, err := typeName(t.Elem())
+		return fmt.Sprintf("[%d]%s", t.Len(), elem), err
+	case reflect.Map:
+		key, err := typeName(t.Key())
+		if err != nil {
+			return "", err
+		}
+		elem, err := typeName(t.Elem())
+		return fmt.Sprintf("map[%s]%s", key, elem), err
+	case reflect.Struct:
+		var b strings.Builder
+		b.WriteString("struct{")
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			ft, err := typeName(f.Type)
+			if err != nil {
+				return "", err
+			}
+			if i > 0 {
+				b.WriteString("; ")
+			}
+			fmt.Fprintf(&b, "%s %s", f.Name, ft)
+		}
+		b.WriteString("}")
+		return b.String(), nil
+	case reflect.Interface:
+		if t.NumMethod() != 0 {
+			return "", fmt.Errorf("codegen: %s: %w", t, errs.ErrUnsupportedType)
+		}
+		return "any", nil
+	case reflect.Pointer, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return "", fmt.Errorf("codegen: %s: %w", t, errs.ErrUnsupportedType)
+	}
+	return t.String(), nil
+}
+
+// literal returns a Go expression evaluating to v.
+func literal(v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.String:
+		return strconv.Quote(v.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", fmt.Errorf("codegen: float %v has no literal: %w", f, errs.ErrUnsupportedType)
+		}
+		return strconv.FormatFloat(f, 'g', -1, v.Type().Bits()), nil
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		bits := v.Type().Bits() / 2
+		return fmt.Sprintf("complex(%s, %s)", strconv.FormatFloat(real(c), 'g', -1, bits), strconv.FormatFloat(imag(c), 'g', -1, bits)), nil
+	case reflect.Interface:
+		if v.IsNil() {
+			return "nil", nil
+		}
+		return typedLiteral(v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			return "nil", nil
+		}
+		return compositeLiteral(v)
+	case reflect.Array, reflect.Map, reflect.Struct:
+		return compositeLiteral(v)
+	}
+	return "", fmt.Errorf("codegen: %s: %w", v.Type(), errs.ErrUnsupportedType)
+}
+
+// typedLiteral returns an expression of v's dynamic type, for use where the static type is any.
+func typedLiteral(v reflect.Value) (string, error) {
+	name, err := typeName(v.Type())
+	if err != nil {
+		return "", err
+	}
+	lit, err := literal(v)
+	if err != nil {
+		return "", err
+	}
+	switch v.Kind() {
+	case reflect.Bool, reflect.String, reflect.Int:
+		// Untyped constants of these kinds already default to the dynamic type.
+		return lit, nil
+	case reflect.Array, reflect.Map, reflect.Struct:
+		return lit, nil
+	case reflect.Slice:
+		if !v.IsNil() {
+			return lit, nil
+		}
+	}
+	return name + "(" + lit + ")", nil
+}
+
+// compositeLiteral returns a slice, array, map or struct literal spelling out v's type.
+func compositeLiteral(v reflect.Value) (string, error) {
+	name, err := typeName(v.Type())
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteString("{")
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			lit, err := literal(v.Index(i))
+			if err != nil {
+				return "", err
+			}
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(lit)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		texts := make(map[int]string, len(keys))
+		order := make([]int, len(keys))
+		for i, k := range keys {
+			lit, err := literal(k)
+			if err != nil {
+				return "", err
+			}
+			texts[i] = lit
+			order[i] = i
+		}
+		slices.SortFunc(order, func(i, j int) int {
+			if c := compareNumeric(keys[i], keys[j]); c != 0 {
+				return c
+			}
+			return cmp.Compare(texts[i], texts[j])
+		})
+		for n, i := range order {
+			lit, err := literal(v.MapIndex(keys[i]))
+			if err != nil {
+				return "", err
+			}
+			if n > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%s: %s", texts[i], lit)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			lit, err := literal(v.Field(i))
+			if err != nil {
+				return "", err
+			}
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%s: %s", v.Type().Field(i).Name, lit)
+		}
+	}
+	b.WriteString("}")
+	return b.String(), nil
+}
+
+// compareNumeric orders two numeric values by value, returning 0 for any other kinds
+// so the caller can fall back to comparing literal text.
+func compareNumeric(a, b reflect.Value) int {
+	if a.Kind() != b.Kind() {
+		return 0
+	}
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	}
+	return 0
+}
@@ -0,0 +1,114 @@
+// Package collection defines the small interfaces shared by the collection types of
+// this module, so application code can accept an interface and swap implementations,
+// such as a plain map for a concurrent or sorted one, without rewrites.
+//
+// The interfaces use the method names of dictionary.Dictionary. Concrete types assert
+// their conformance at compile time next to their declarations.
+package collection
+
+// Map is a read-only view of a keyed collection.
+type Map[K comparable, V any] interface {
+	// Lookup returns the value stored under key and whether the key is present.
+	Lookup(key K) (V, bool)
+	// ContainsKey reports whether key is present.
+	ContainsKey(key K) bool
+	// GetKeys returns the keys. Their order is defined by the implementation.
+	GetKeys() []K
+	// GetLength returns the number of entries.
+	GetLength() int
+}
+
+// MutableMap is a Map that can be written to and may reject an entry, for example
+// because it is full. A WritableMap becomes a MutableMap through AsMutableMap.
+type MutableMap[K comparable, V any] interface {
+	Map[K, V]
+	// SetValue stores value under key, replacing any previous value.
+	// It returns an error if the map cannot accept the entry.
+	SetValue(key K, value V) error
+	// DeleteValue removes key. Deleting an absent key is a no-op.
+	DeleteValue(key K)
+}
+
+// WritableMap is a Map whose writes always succeed, such as dictionary.Dictionary.
+type WritableMap[K comparable, V any] interface {
+	Map[K, V]
+	// SetValue stores value under key, replacing any previous value.
+	SetValue(key K, value V)
+	// DeleteValue removes key. Deleting an absent key is a no-op.
+	DeleteValue(key K)
+}
+
+// AsMutableMap adapts m to MutableMap, so code accepting a MutableMap can be given a
+// map whose writes cannot fail. The SetValue of the result always returns nil.
+//
+// Parameters:
+//   - m: The map to be adapted. Writes through the result go to m.
+//
+// Returns:
+//   - MutableMap[K, V]: A MutableMap backed by m.
+//
+// Example:
+//
+//	var store MutableMap[string, int] = AsMutableMap[string, int](dictionary.Dictionary[string, int]{})
+func AsMutableMap[K comparable, V any](m WritableMap[K, V]) MutableMap[K, V] {
+	return mutableMap[K, V]{m}
+}
+
+// mutableMap implements AsMutableMap.
+type mutableMap[K comparable, V any] struct {
+	WritableMap[K, V]
+}
+
+func (m mutableMap[K, V]) SetValue(key K, value V) error {
+	m.WritableMap.SetValue(key, value)
+	return nil
+}
+
+// OrderedMap is a Map whose keys have a defined order, such as insertion or sort
+// order. GetKeys returns the keys in that order.
+type OrderedMap[K comparable, V any] interface {
+	Map[K, V]
+	// First returns the first entry, or false if the map is empty.
+	First() (K, V, bool)
+	// Last returns the last entry, or false if the map is empty.
+	Last() (K, V, bool)
+	// Ascend calls fn for each entry in order until fn returns false.
+	Ascend(fn func(key K, value V) bool)
+}
+
+// Collection is a finite group of elements.
+type Collection[T any] interface {
+	// Contains reports whether the element is present.
+	Contains(elem T) bool
+	// ToSlice returns the elements. Their order is defined by the implementation.
+	ToSlice() []T
+	// GetLength returns the number of elements.
+	GetLength() int
+}
+
+// Queue is a collection that hands out elements in an order defined by the
+// implementation, such as first-in first-out or by priority.
+type Queue[T any] interface {
+	// Push adds an element. It returns an error if the queue cannot accept it.
+	Push(elem T) error
+	// Pop removes and returns the next element, or false if the queue is empty.
+	Pop() (T, bool)
+	// Peek returns the next element without removing it, or false if the queue is empty.
+	Peek() (T, bool)
+	// GetLength returns the number of queued elements.
+	GetLength() int
+}
+
+// Cache is a bounded key-value store that may drop entries on its own, for example
+// when it is full or an entry expires.
+type Cache[K comparable, V any] interface {
+	// Get returns the value stored under key and whether it was found, counting as a use
+	// of the entry for the eviction policy.
+	Get(key K) (V, bool)
+	// Set stores value under key, possibly evicting other entries.
+	Set(key K, value V)
+	// Delete removes key and reports whether it was present.
+	Delete(key K) bool
+	// GetLength returns the number of cached entries.
+	GetLength() int
+}
@@ -0,0 +1,137 @@
+// Package dedupe provides helpers for suppressing duplicate events, such as
+// repeated alerts or log lines, within a time window.
+package dedupe
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bhanurp/gotypes/dictionary"
+)
+
+// Window remembers which keys were seen during the last window duration.
+//
+// Sightings are stored in two generations that rotate once per window, so
+// expired keys are discarded a whole generation at a time instead of being
+// swept individually. Memory is bounded by the number of distinct keys seen
+// in the last two windows. A Window is safe for concurrent use.
+type Window[K comparable] struct {
+	mu        sync.Mutex
+	window    time.Duration
+	current   dictionary.Dictionary[K, time.Time]
+	previous  dictionary.Dictionary[K, time.Time]
+	rotatedAt time.Time
+}
+
+// NewWindow creates a Window that remembers keys for the given duration.
+//
+// Parameters:
+//   - window: How long a sighting suppresses later sightings of the same key.
+//
+// Returns:
+//   - A new empty Window.
+//
+// Example:
+//
+//	w := NewWindow[string](time.Minute)
+//	w.SeenRecently("disk full") // false, the alert should be sent
+//	w.SeenRecently("disk full") // true, the duplicate should be dropped
+func NewWindow[K comparable](window time.Duration) *Window[K] {
+	return &Window[K]{
+		window:    window,
+		current:   dictionary.DefaultDictionary[K, time.Time](),
+		previous:  dictionary.DefaultDictionary[K, time.Time](),
+		rotatedAt: time.Now(),
+	}
+}
+
+// SeenRecently records a sighting of key and reports whether it had already
+// been seen within the window. Every sighting restarts the key's window, so a
+// key that keeps recurring more often than the window stays suppressed.
+//
+// Parameters:
+//   - key: The key being observed.
+//
+// Returns:
+//   - bool: True if the key was seen within the window, false otherwise.
+func (w *Window[K]) SeenRecently(key K) bool {
+	now := time.Now()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rotate(now)
+	seen := w.seen(key, now)
+	w.current[key] = now
+	return seen
+}
+
+// Contains reports whether key was seen within the window without recording a sighting.
+//
+// Parameters:
+//   - key: The key to be checked.
+//
+// Returns:
+//   - bool: True if the key was seen within the window, false otherwise.
+func (w *Window[K]) Contains(key K) bool {
+	now := time.Now()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rotate(now)
+	return w.seen(key, now)
+}
+
+// Forget removes any record of key, so its next sighting is reported as new.
+func (w *Window[K]) Forget(key K) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.current.DeleteValue(key)
+	w.previous.DeleteValue(key)
+}
+
+// Len returns the number of keys currently retained, which may include keys
+// whose window has expired but whose generation has not yet been discarded.
+func (w *Window[K]) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current.GetLength() + w.previous.GetLength()
+}
+
+// Reset forgets every key.
+func (w *Window[K]) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.current.ClearDictionary()
+	w.previous.ClearDictionary()
+	w.rotatedAt = time.Now()
+}
+
+// seen reports whether key has a sighting newer than the window. The caller must hold w.mu.
+func (w *Window[K]) seen(key K, now time.Time) bool {
+	cutoff := now.Add(-w.window)
+	if t, ok := w.current[key]; ok {
+		return t.After(cutoff)
+	}
+	if t, ok := w.previous[key]; ok {
+		return t.After(cutoff)
+	}
+	return false
+}
+
+// rotate advances the generations so that previous only ever holds sightings
+// from the last two windows. The caller must hold w.mu.
+func (w *Window[K]) rotate(now time.Time) {
+	elapsed := now.Sub(w.rotatedAt)
+	if elapsed < w.window {
+		return
+	}
+	if elapsed >= 2*w.window {
+		// Both generations are older than the window.
+		w.previous.ClearDictionary()
+		w.current.ClearDictionary()
+	} else {
+		w.previous, w.current = w.current, w.previous
+		w.current.ClearDictionary()
+	}
+	w.rotatedAt = now
+}
@@ -0,0 +1,135 @@
+package dictionary
+
+import (
+	"math"
+	"slices"
+
+	"github.com/bhanurp/gotypes/numx"
+)
+
+// SumValues returns the sum of all values in the Dictionary.
+// The sum is accumulated in V, so it wraps around on integer overflow like ordinary Go arithmetic.
+//
+// Parameters:
+//   - d: The Dictionary whose values are to be summed.
+//
+// Returns:
+//   - V: The sum of the values, or zero if the Dictionary is empty.
+//
+// Example:
+//
+//	hits := Dictionary[string, int]{"/": 10, "/about": 3}
+//	total := SumValues(hits) // total will be 13
+func SumValues[K comparable, V numx.Number](d Dictionary[K, V]) V {
+	var sum V
+	for _, v := range d {
+		sum += v
+	}
+	return sum
+}
+
+// AverageValues returns the arithmetic mean of all values in the Dictionary.
+// Values are accumulated as float64, so integer values cannot overflow the sum.
+//
+// Parameters:
+//   - d: The Dictionary whose values are to be averaged.
+//
+// Returns:
+//   - float64: The mean of the values, or 0 if the Dictionary is empty.
+//   - bool: True if the Dictionary has at least one entry, false otherwise.
+//
+// Example:
+//
+//	latency := Dictionary[string, float64]{"eu": 12.5, "us": 7.5}
+//	avg, ok := AverageValues(latency) // avg will be 10, ok will be true
+func AverageValues[K comparable, V numx.Number](d Dictionary[K, V]) (float64, bool) {
+	if len(d) == 0 {
+		return 0, false
+	}
+	var sum float64
+	for _, v := range d {
+		sum += float64(v)
+	}
+	return sum / float64(len(d)), true
+}
+
+// ValueStats summarizes the values of a numeric Dictionary.
+type ValueStats struct {
+	Count  int
+	Min    float64
+	Max    float64
+	Mean   float64
+	StdDev float64 // population standard deviation
+	P50    float64
+	P90    float64
+	P99    float64
+
+	sorted []float64
+}
+
+// Percentile returns the p-th percentile of the values, interpolating linearly between
+// the closest ranks. p is clamped to [0, 100].
+//
+// Parameters:
+//   - p: The percentile to compute, between 0 and 100.
+//
+// Returns:
+//   - float64: The percentile, or 0 if there are no values.
+//
+// Example:
+//
+//	s, _ := Stats(latency)
+//	p95 := s.Percentile(95)
+func (s ValueStats) Percentile(p float64) float64 {
+	n := len(s.sorted)
+	if n == 0 {
+		return 0
+	}
+	rank := min(max(p, 0), 100) / 100 * float64(n-1)
+	lo := int(math.Floor(rank))
+	hi := min(lo+1, n-1)
+	return s.sorted[lo] + (s.sorted[hi]-s.sorted[lo])*(rank-float64(lo))
+}
+
+// Stats summarizes the values of the Dictionary: their count, extremes, mean, population
+// standard deviation and common percentiles. Values are converted to float64.
+//
+// Parameters:
+//   - d: The Dictionary whose values are to be summarized.
+//
+// Returns:
+//   - ValueStats: The summary, or the zero ValueStats if the Dictionary is empty.
+//   - bool: True if the Dictionary has at least one entry, false otherwise.
+//
+// Example:
+//
+//	latency := Dictionary[string, float64]{"eu": 12, "us": 8, "ap": 40}
+//	s, ok := Stats(latency)
+//	// s.Count will be 3, s.Min 8, s.Max 40, s.Mean 20, s.P50 12
+func Stats[K comparable, V numx.Number](d Dictionary[K, V]) (ValueStats, bool) {
+	if len(d) == 0 {
+		return ValueStats{}, false
+	}
+	values := make([]float64, 0, len(d))
+	var sum float64
+	for _, v := range d {
+		values = append(values, float64(v))
+		sum += float64(v)
+	}
+	slices.Sort(values)
+	mean := sum / float64(len(values))
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	s := ValueStats{
+		Count:  len(values),
+		Min:    values[0],
+		Max:    values[len(values)-1],
+		Mean:   mean,
+		StdDev: math.Sqrt(squares / float64(len(values))),
+		sorted: values,
+	}
+	s.P50, s.P90, s.P99 = s.Percentile(50), s.Percentile(90), s.Percentile(99)
+	return s, true
+}
@@ -0,0 +1,66 @@
+package dictionary
+
+import (
+	"slices"
+
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// Append appends vals to the slice stored under key, creating the slice if the key is
+// absent, so a Dictionary of slices can accumulate values without nil checks. With no
+// values, the Dictionary is left unchanged, so no key holding a nil slice is created.
+//
+// Parameters:
+//   - d: The Dictionary of slices.
+//   - key: The key whose slice is to be extended.
+//   - vals: The values to be appended.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrNilCollection if the Dictionary is nil, nil otherwise.
+//
+// Example:
+//
+//	byLetter := Dictionary[string, []string]{}
+//	Append(byLetter, "g", "go", "gopher")
+//	Append(byLetter, "g", "gc")
+//	// byLetter is Dictionary[string, []string]{"g": {"go", "gopher", "gc"}}
+func Append[K comparable, V any](d Dictionary[K, []V], key K, vals ...V) error {
+	if d == nil {
+		return errs.NewKeyError("append", key, errs.ErrNilCollection)
+	}
+	if len(vals) == 0 {
+		return nil
+	}
+	d[key] = append(d[key], vals...)
+	return nil
+}
+
+// RemoveFrom removes every occurrence of vals from the slice stored under key, keeping the
+// order of the remaining values. The key is deleted when its slice becomes empty.
+//
+// Parameters:
+//   - d: The Dictionary of slices.
+//   - key: The key whose slice is to be shrunk.
+//   - vals: The values to be removed.
+//
+// Returns:
+//   - int: The number of elements removed.
+//
+// Example:
+//
+//	tags := Dictionary[string, []string]{"post": {"go", "draft", "go"}}
+//	n := RemoveFrom(tags, "post", "go") // n will be 2, tags["post"] will be ["draft"]
+//	RemoveFrom(tags, "post", "draft")   // tags is now empty
+func RemoveFrom[K comparable, V comparable](d Dictionary[K, []V], key K, vals ...V) int {
+	s, ok := d[key]
+	if !ok {
+		return 0
+	}
+	kept := slices.DeleteFunc(s, func(v V) bool { return slices.Contains(vals, v) })
+	if len(kept) == 0 {
+		delete(d, key)
+	} else {
+		d[key] = kept
+	}
+	return len(s) - len(kept)
+}
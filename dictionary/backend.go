@@ -0,0 +1,276 @@
+package dictionary
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// Backend is the durable storage behind a PersistentDictionary. Keys and values arrive
+// already encoded, so a Backend only stores bytes: a file, Bolt bucket or Redis hash
+// backend is a thin adapter. A Backend must be safe for concurrent use.
+type Backend interface {
+	// Put stores value under key, replacing any previous value.
+	Put(key string, value []byte) error
+	// Get returns the value stored under key, and false if there is none.
+	Get(key string) ([]byte, bool, error)
+	// Delete removes key. Deleting an absent key is not an error.
+	Delete(key string) error
+	// Scan calls fn for every stored entry, in any order, and stops at the first error fn returns.
+	Scan(fn func(key string, value []byte) error) error
+}
+
+// MemoryBackend is a Backend that keeps its entries in memory. It is not durable, but is
+// useful in tests and as a reference for writing other backends.
+type MemoryBackend struct {
+	mu      sync.RWMutex
+	entries Dictionary[string, []byte]
+}
+
+var _ Backend = (*MemoryBackend)(nil)
+
+// NewMemoryBackend creates an empty MemoryBackend.
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{entries: DefaultDictionary[string, []byte]()}
+}
+
+// Put stores a copy of value under key.
+func (b *MemoryBackend) Put(key string, value []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries[key] = append([]byte(nil), value...)
+	return nil
+}
+
+// Get returns a copy of the value stored under key.
+func (b *MemoryBackend) Get(key string) ([]byte, bool, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	value, ok := b.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	return append([]byte(nil), value...), true, nil
+}
+
+// Delete removes key.
+func (b *MemoryBackend) Delete(key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.entries, key)
+	return nil
+}
+
+// Scan calls fn for every entry. fn must not call Put or Delete.
+func (b *MemoryBackend) Scan(fn func(key string, value []byte) error) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for k, v := range b.entries {
+		if err := fn(k, append([]byte(nil), v...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DirBackend is a Backend that stores each entry as a file in a directory, named after
+// the hex encoding of its key. A key too long for that name to fit the limits of common
+// file systems is stored in a file named after its SHA-256 hash instead, which holds the
+// key ahead of the value. Writes go to a temporary file that is synced and renamed into
+// place, so an entry is never seen half written, even after a crash.
+type DirBackend struct {
+	dir string
+}
+
+var _ Backend = (*DirBackend)(nil)
+
+// Prefixes of the entry file names, setting them apart from each other and from temporary
+// files. A file named with dirEntryPrefix holds just the value; one named with
+// dirHashedPrefix holds the key length as a uvarint, the key and the value.
+const (
+	dirEntryPrefix  = "k"
+	dirHashedPrefix = "h"
+)
+
+// maxEntryName is the longest entry file name derived from the hex encoding of a key,
+// safely below the 255-byte name limit of most file systems.
+const maxEntryName = 200
+
+// NewDirBackend creates a DirBackend storing its entries in dir, creating the directory
+// if needed.
+//
+// Parameters:
+//   - dir: The directory holding the entry files. It should be used by nothing else.
+//
+// Returns:
+//   - *DirBackend: The backend.
+//   - error: An error if the directory cannot be created.
+//
+// Example:
+//
+//	backend, err := NewDirBackend(filepath.Join(stateDir, "settings"))
+//	if err != nil {
+//		return err
+//	}
+//	settings, err := OpenPersistentDictionary[string, Setting](backend)
+func NewDirBackend(dir string) (*DirBackend, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("dictionary: %w", err)
+	}
+	return &DirBackend{dir: dir}, nil
+}
+
+// path returns the file name of key, and whether the file is named after the hash of key.
+func (b *DirBackend) path(key string) (string, bool) {
+	name := dirEntryPrefix + hex.EncodeToString([]byte(key))
+	if len(name) <= maxEntryName {
+		return filepath.Join(b.dir, name), false
+	}
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(b.dir, dirHashedPrefix+hex.EncodeToString(sum[:])), true
+}
+
+// Put writes value to the file of key and syncs the directory, so the entry survives a crash
+// once Put returns.
+func (b *DirBackend) Put(key string, value []byte) error {
+	path, hashed := b.path(key)
+	if hashed {
+		data := binary.AppendUvarint(nil, uint64(len(key)))
+		data = append(data, key...)
+		value = append(data, value...)
+	}
+	f, err := os.CreateTemp(b.dir, "tmp-*")
+	if err != nil {
+		return fmt.Errorf("dictionary: %w", err)
+	}
+	_, err = f.Write(value)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("dictionary: %w", err)
+	}
+	return syncDir(b.dir)
+}
+
+// Get reads the file of key.
+func (b *DirBackend) Get(key string) ([]byte, bool, error) {
+	path, hashed := b.path(key)
+	_, value, ok, err := readEntryFile(path, hashed)
+	return value, ok, err
+}
+
+// Delete removes the file of key and syncs the directory.
+func (b *DirBackend) Delete(key string) error {
+	path, _ := b.path(key)
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("dictionary: %w", err)
+	}
+	return syncDir(b.dir)
+}
+
+// Scan reads every entry file in the directory, skipping files of other names.
+func (b *DirBackend) Scan(fn func(key string, value []byte) error) error {
+	files, err := os.ReadDir(b.dir)
+	if err != nil {
+		return fmt.Errorf("dictionary: %w", err)
+	}
+	for _, file := range files {
+		if !file.Type().IsRegular() {
+			continue
+		}
+		path := filepath.Join(b.dir, file.Name())
+		var (
+			key   string
+			value []byte
+			ok    bool
+		)
+		if name, isEntry := strings.CutPrefix(file.Name(), dirEntryPrefix); isEntry {
+			k, err := hex.DecodeString(name)
+			if err != nil {
+				continue
+			}
+			key = string(k)
+			_, value, ok, err = readEntryFile(path, false)
+			if err != nil {
+				return err
+			}
+		} else if strings.HasPrefix(file.Name(), dirHashedPrefix) {
+			key, value, ok, err = readEntryFile(path, true)
+			if err != nil {
+				return err
+			}
+		} else {
+			continue
+		}
+		if !ok {
+			continue // deleted since the directory was read
+		}
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readEntryFile reads the entry file at path. For a file named after the hash of its key,
+// it also returns the key stored in the file.
+//
+// Returns:
+//   - string: The key stored in a hashed file, or "" otherwise.
+//   - []byte: The value.
+//   - bool: True if the file exists, false otherwise.
+//   - error: An error if the file cannot be read, or one wrapping errs.ErrMalformed if a
+//     hashed file does not start with a key.
+func readEntryFile(path string, hashed bool) (string, []byte, bool, error) {
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return "", nil, false, nil
+	case err != nil:
+		return "", nil, false, fmt.Errorf("dictionary: %w", err)
+	case !hashed:
+		return "", data, true, nil
+	}
+	n, size := binary.Uvarint(data)
+	if size <= 0 || n > uint64(len(data)-size) {
+		return "", nil, false, fmt.Errorf("dictionary: entry file %s: %w", filepath.Base(path), errs.ErrMalformed)
+	}
+	data = data[size:]
+	return string(data[:n]), data[n:], true, nil
+}
+
+// syncDir flushes the directory entries of dir, making a rename or removal in it durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("dictionary: %w", err)
+	}
+	err = d.Sync()
+	if closeErr := d.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("dictionary: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package dictionary
+
+import "github.com/bhanurp/gotypes/errs"
+
+// GetMany retrieves the values of several keys in one call.
+//
+// Parameters:
+//   - keys: The keys whose values are to be returned.
+//
+// Returns:
+//   - Dictionary[K, V]: A new Dictionary holding the keys that are present and their values.
+//     Absent keys are left out, so its length tells how many keys were found.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "two": 2, "three": 3}
+//	found := dict.GetMany([]string{"one", "three", "four"})
+//	// found is Dictionary[string, int]{"one": 1, "three": 3}
+func (d Dictionary[K, V]) GetMany(keys []K) Dictionary[K, V] {
+	found := make(Dictionary[K, V], min(len(keys), len(d)))
+	for _, k := range keys {
+		if v, ok := d[k]; ok {
+			found[k] = v
+		}
+	}
+	return found
+}
+
+// SetMany stores several entries in one call. Later entries overwrite earlier ones with the
+// same key. Calling SetMany on a nil Dictionary does not panic; it returns an error instead.
+//
+// Parameters:
+//   - entries: The entries to be stored.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrNilCollection if the Dictionary is nil and
+//     entries is not empty, nil otherwise.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{}
+//	err := dict.SetMany([]Entry[string, int]{{"one", 1}, {"two", 2}})
+//	// dict is Dictionary[string, int]{"one": 1, "two": 2}
+func (d Dictionary[K, V]) SetMany(entries []Entry[K, V]) error {
+	if d == nil && len(entries) > 0 {
+		return errs.NewKeyError("set", entries[0].Key, errs.ErrNilCollection)
+	}
+	for _, e := range entries {
+		d[e.Key] = e.Value
+	}
+	return nil
+}
+
+// DeleteMany removes several keys in one call. Absent keys are ignored.
+//
+// Parameters:
+//   - keys: The keys to be removed.
+//
+// Returns:
+//   - int: The number of keys that were present and removed.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "two": 2, "three": 3}
+//	removed := dict.DeleteMany([]string{"one", "four"}) // removed will be 1
+func (d Dictionary[K, V]) DeleteMany(keys []K) int {
+	removed := 0
+	for _, k := range keys {
+		if _, ok := d[k]; ok {
+			delete(d, k)
+			removed++
+		}
+	}
+	return removed
+}
@@ -0,0 +1,158 @@
+package dictionary
+
+import (
+	"fmt"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// BiDictionary is a one-to-one Dictionary that can be queried in both directions: every
+// value belongs to exactly one key, and GetByValue finds that key in constant time. Both
+// indexes are updated together, so they never disagree. A BiDictionary is not safe for
+// concurrent use.
+type BiDictionary[K comparable, V comparable] struct {
+	forward Dictionary[K, V]
+	inverse Dictionary[V, K]
+}
+
+var _ collection.MutableMap[string, int] = (*BiDictionary[string, int])(nil)
+
+// NewBiDictionary creates an empty BiDictionary.
+//
+// Returns:
+//   - A new empty BiDictionary.
+//
+// Example:
+//
+//	users := NewBiDictionary[int, string]()
+//	users.SetValue(1, "alice")
+//	id, _ := users.GetByValue("alice") // id will be 1
+func NewBiDictionary[K comparable, V comparable]() *BiDictionary[K, V] {
+	return &BiDictionary[K, V]{forward: DefaultDictionary[K, V](), inverse: DefaultDictionary[V, K]()}
+}
+
+// GetValue retrieves the value associated with the specified key, or the zero value if it is absent.
+func (b *BiDictionary[K, V]) GetValue(key K) V {
+	return b.forward[key]
+}
+
+// Lookup retrieves the value associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (b *BiDictionary[K, V]) Lookup(key K) (V, bool) {
+	v, ok := b.forward[key]
+	return v, ok
+}
+
+// GetByValue retrieves the key associated with the specified value.
+//
+// Returns:
+//   - K: The key, or the zero value if the value is absent.
+//   - bool: True if the value is present, false otherwise.
+func (b *BiDictionary[K, V]) GetByValue(value V) (K, bool) {
+	k, ok := b.inverse[value]
+	return k, ok
+}
+
+// ContainsKey checks if the specified key is present.
+func (b *BiDictionary[K, V]) ContainsKey(key K) bool {
+	return b.forward.ContainsKey(key)
+}
+
+// ContainsValue checks if the specified value is present. It takes constant time.
+func (b *BiDictionary[K, V]) ContainsValue(value V) bool {
+	return b.inverse.ContainsKey(value)
+}
+
+// SetValue associates key with value, replacing the previous value of key.
+//
+// Parameters:
+//   - key: The key for which the value needs to be set.
+//   - value: The value to be set for the given key.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrDuplicateKey if value already belongs to
+//     another key, nil otherwise.
+//
+// Example:
+//
+//	users.SetValue(2, "alice") // errors.Is(err, errs.ErrDuplicateKey) will be true
+func (b *BiDictionary[K, V]) SetValue(key K, value V) error {
+	if owner, ok := b.inverse[value]; ok && owner != key {
+		return errs.NewKeyError("set", key, fmt.Errorf("value %v belongs to key %v: %w", value, owner, errs.ErrDuplicateKey))
+	}
+	b.ForceSetValue(key, value)
+	return nil
+}
+
+// ForceSetValue associates key with value, first removing any other key that holds value.
+//
+// Example:
+//
+//	users.ForceSetValue(2, "alice") // key 1 is removed
+func (b *BiDictionary[K, V]) ForceSetValue(key K, value V) {
+	if owner, ok := b.inverse[value]; ok {
+		delete(b.forward, owner)
+	}
+	if old, ok := b.forward[key]; ok {
+		delete(b.inverse, old)
+	}
+	b.forward[key] = value
+	b.inverse[value] = key
+}
+
+// DeleteValue removes the specified key and its value. If the key does not exist, nothing changes.
+func (b *BiDictionary[K, V]) DeleteValue(key K) {
+	if v, ok := b.forward[key]; ok {
+		delete(b.forward, key)
+		delete(b.inverse, v)
+	}
+}
+
+// DeleteByValue removes the specified value and its key. If the value does not exist, nothing changes.
+func (b *BiDictionary[K, V]) DeleteByValue(value V) {
+	if k, ok := b.inverse[value]; ok {
+		delete(b.inverse, value)
+		delete(b.forward, k)
+	}
+}
+
+// GetKeys returns the keys in unspecified order.
+func (b *BiDictionary[K, V]) GetKeys() []K {
+	return b.forward.GetKeys()
+}
+
+// GetValues returns the values in unspecified order.
+func (b *BiDictionary[K, V]) GetValues() []V {
+	return b.inverse.GetKeys()
+}
+
+// GetLength returns the number of pairs.
+func (b *BiDictionary[K, V]) GetLength() int {
+	return len(b.forward)
+}
+
+// Inverse returns the BiDictionary seen from the other side, mapping values to keys. It
+// shares storage with b, so changes made through either are visible through both.
+//
+// Example:
+//
+//	ids := users.Inverse()
+//	id := ids.GetValue("alice")
+func (b *BiDictionary[K, V]) Inverse() *BiDictionary[V, K] {
+	return &BiDictionary[V, K]{forward: b.inverse, inverse: b.forward}
+}
+
+// ClearDictionary removes every pair.
+func (b *BiDictionary[K, V]) ClearDictionary() {
+	b.forward.ClearDictionary()
+	b.inverse.ClearDictionary()
+}
+
+// ToDictionary returns a copy of the key-to-value mapping as a plain Dictionary.
+func (b *BiDictionary[K, V]) ToDictionary() Dictionary[K, V] {
+	return b.forward.CopyDictionary()
+}
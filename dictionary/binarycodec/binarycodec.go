@@ -0,0 +1,757 @@
+// Package binarycodec encodes Dictionaries, and the plain Go values they hold,
+// as MessagePack or CBOR for exchanging data with non-Go services.
+//
+// Encoders and Decoders work on streams: values are written to and read from
+// the underlying io.Writer or io.Reader as they are visited, so a large
+// Dictionary is never buffered in encoded form, and several values may follow
+// each other in one stream.
+//
+// The following Go types are supported: bool, all integer and floating-point
+// types, string, []byte and byte arrays (as binary strings), slices and arrays
+// (as arrays), maps (as maps), structs (as maps keyed by field name), pointers
+// and interfaces (as the value they refer to, or nil). Types implementing
+// encoding.TextMarshaler, such as time.Time, are encoded as text strings and
+// decoded with encoding.TextUnmarshaler. Struct fields can be renamed or
+// skipped with a `codec:"name,omitempty"` or `codec:"-"` tag. Cyclic values
+// are not supported.
+//
+// When decoding into an interface value, integers become int64 (or uint64 if
+// they do not fit), floats become float64, arrays become []any, and maps become
+// map[string]any when every key is a string and map[any]any otherwise.
+package binarycodec
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// Format selects the wire format of an Encoder or Decoder.
+type Format int
+
+const (
+	// MessagePack is the format described at https://msgpack.org.
+	MessagePack Format = iota
+	// CBOR is the Concise Binary Object Representation of RFC 8949.
+	CBOR
+)
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case MessagePack:
+		return "MessagePack"
+	case CBOR:
+		return "CBOR"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// maxPrealloc caps how many elements are allocated up front for a decoded container,
+// so a corrupt length prefix cannot force a huge allocation.
+const maxPrealloc = 4096
+
+var (
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// tokenWriter emits the primitive items of a wire format.
+type tokenWriter interface {
+	writeNil()
+	writeBool(b bool)
+	writeInt(v int64)
+	writeUint(v uint64)
+	writeFloat32(f float32)
+	writeFloat64(f float64)
+	writeString(s string)
+	writeBytes(b []byte)
+	writeArrayHeader(n int)
+	writeMapHeader(n int)
+}
+
+// kind classifies a decoded token.
+type kind int
+
+const (
+	kindNil kind = iota
+	kindBool
+	kindInt   // a negative integer, held in token.i
+	kindUint  // a non-negative integer, held in token.u
+	kindFloat // held in token.f
+	kindString
+	kindBytes
+	kindArray // token.n elements follow, or an indefinite number if token.n < 0
+	kindMap   // token.n key-value pairs follow, or an indefinite number if token.n < 0
+)
+
+// token is a single primitive item read from the wire.
+type token struct {
+	kind kind
+	b    bool
+	i    int64
+	u    uint64
+	f    float64
+	s    []byte
+	n    int
+}
+
+// tokenReader reads the primitive items of a wire format.
+type tokenReader interface {
+	next() (token, error)
+	// atBreak reports, and consumes, the end marker of an indefinite-length container.
+	atBreak() (bool, error)
+}
+
+// Encoder writes values to an output stream.
+type Encoder struct {
+	bw *bufio.Writer
+	tw tokenWriter
+}
+
+// NewEncoder returns an Encoder writing the given format to w.
+//
+// Example:
+//
+//	enc := NewEncoder(conn, CBOR)
+//	err := enc.Encode(dictionary.Dictionary[string, int]{"one": 1})
+func NewEncoder(w io.Writer, f Format) *Encoder {
+	bw := bufio.NewWriter(w)
+	e := &Encoder{bw: bw}
+	if f == CBOR {
+		e.tw = &cborWriter{w: bw}
+	} else {
+		e.tw = &msgpackWriter{w: bw}
+	}
+	return e
+}
+
+// Encode writes the encoding of v to the stream and flushes it.
+//
+// Returns:
+//   - error: An error wrapping errs.ErrUnsupportedType if v contains an unsupported type,
+//     or the first error returned by the underlying writer.
+func (e *Encoder) Encode(v any) error {
+	if err := encodeValue(e.tw, reflect.ValueOf(v)); err != nil {
+		return err
+	}
+	return e.bw.Flush()
+}
+
+// Decoder reads values from an input stream.
+type Decoder struct {
+	tr tokenReader
+}
+
+// NewDecoder returns a Decoder reading the given format from r.
+// The Decoder buffers its input and may read past the last value it decodes.
+//
+// Example:
+//
+//	var dict dictionary.Dictionary[string, int]
+//	err := NewDecoder(conn, CBOR).Decode(&dict)
+func NewDecoder(r io.Reader, f Format) *Decoder {
+	br := bufio.NewReader(r)
+	if f == CBOR {
+		return &Decoder{tr: &cborReader{r: br}}
+	}
+	return &Decoder{tr: &msgpackReader{r: br}}
+}
+
+// Decode reads the next value from the stream and stores it in the value pointed to by v.
+//
+// Returns:
+//   - error: io.EOF if the stream is exhausted before the value starts, an error wrapping
+//     errs.ErrMalformed or errs.ErrUnsupportedType if the value cannot be decoded into v, nil otherwise.
+func (d *Decoder) Decode(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("binarycodec: decode into non-pointer %T: %w", v, errs.ErrUnsupportedType)
+	}
+	t, err := d.tr.next()
+	if err != nil {
+		return err
+	}
+	return decodeValue(d.tr, t, rv.Elem())
+}
+
+// Marshal returns the encoding of v in the given format.
+//
+// Example:
+//
+//	data, err := Marshal(MessagePack, dictionary.Dictionary[int, string]{1: "one"})
+func Marshal(f Format, v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf, f).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes data in the given format into the value pointed to by v.
+//
+// Example:
+//
+//	var dict dictionary.Dictionary[int, string]
+//	err := Unmarshal(MessagePack, data, &dict)
+func Unmarshal(f Format, data []byte, v any) error {
+	err := NewDecoder(bytes.NewReader(data), f).Decode(v)
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("binarycodec: %w: %w", errs.ErrMalformed, io.ErrUnexpectedEOF)
+	}
+	return err
+}
+
+// EncodeDictionary writes d to the stream as a map, encoding entries one at a time.
+//
+// Parameters:
+//   - e: The Encoder to write to.
+//   - d: The Dictionary to be encoded.
+//
+// Returns:
+//   - error: An error if a key or value cannot be encoded or the stream cannot be written.
+func EncodeDictionary[K comparable, V any](e *Encoder, d dictionary.Dictionary[K, V]) error {
+	return e.Encode(map[K]V(d))
+}
+
+// DecodeDictionary reads a map from the stream into a new Dictionary.
+//
+// Parameters:
+//   - dec: The Decoder to read from.
+//
+// Returns:
+//   - dictionary.Dictionary[K, V]: The decoded Dictionary, or nil if the stream held nil.
+//   - error: An error if the next value is not a map or its entries cannot be decoded.
+func DecodeDictionary[K comparable, V any](dec *Decoder) (dictionary.Dictionary[K, V], error) {
+	var d dictionary.Dictionary[K, V]
+	if err := dec.Decode((*map[K]V)(&d)); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// DecodeEntries reads a map from the stream and calls fn for each entry as soon as it is
+// decoded, without materializing the whole map. Decoding stops at the first error from fn.
+//
+// Parameters:
+//   - dec: The Decoder to read from.
+//   - fn: The function receiving each decoded entry.
+//
+// Returns:
+//   - error: An error if the next value is not a map, an entry cannot be decoded, or fn fails.
+//
+// Example:
+//
+//	err := DecodeEntries(dec, func(k string, v int) error {
+//		total += v
+//		return nil
+//	})
+func DecodeEntries[K comparable, V any](dec *Decoder, fn func(K, V) error) error {
+	t, err := dec.tr.next()
+	if err != nil {
+		return err
+	}
+	if t.kind == kindNil {
+		return nil
+	}
+	if t.kind != kindMap {
+		return fmt.Errorf("binarycodec: expected map, found %s: %w", t.describe(), errs.ErrMalformed)
+	}
+	return forEachElement(dec.tr, t.n, func() error {
+		var (
+			k K
+			v V
+		)
+		if err := decodeNext(dec.tr, reflect.ValueOf(&k).Elem()); err != nil {
+			return err
+		}
+		if err := decodeNext(dec.tr, reflect.ValueOf(&v).Elem()); err != nil {
+			return err
+		}
+		return fn(k, v)
+	})
+}
+
+// encodeValue writes v and everything it refers to.
+func encodeValue(w tokenWriter, v reflect.Value) error {
+	if !v.IsValid() {
+		w.writeNil()
+		return nil
+	}
+	if v.Type().Implements(textMarshalerType) {
+		if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+			w.writeNil()
+			return nil
+		}
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return err
+		}
+		w.writeString(string(text))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		w.writeBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		w.writeInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		w.writeUint(v.Uint())
+	case reflect.Float32:
+		w.writeFloat32(float32(v.Float()))
+	case reflect.Float64:
+		w.writeFloat64(v.Float())
+	case reflect.String:
+		w.writeString(v.String())
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			w.writeNil()
+			return nil
+		}
+		return encodeValue(w, v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			w.writeNil()
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			w.writeBytes(v.Bytes())
+			return nil
+		}
+		return encodeArray(w, v)
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			w.writeBytes(b)
+			return nil
+		}
+		return encodeArray(w, v)
+	case reflect.Map:
+		if v.IsNil() {
+			w.writeNil()
+			return nil
+		}
+		w.writeMapHeader(v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := encodeValue(w, iter.Key()); err != nil {
+				return err
+			}
+			if err := encodeValue(w, iter.Value()); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		fields := structFields(v.Type())
+		present := make([]field, 0, len(fields))
+		for _, f := range fields {
+			if f.omitEmpty && v.Field(f.index).IsZero() {
+				continue
+			}
+			present = append(present, f)
+		}
+		w.writeMapHeader(len(present))
+		for _, f := range present {
+			w.writeString(f.name)
+			if err := encodeValue(w, v.Field(f.index)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("binarycodec: %s: %w", v.Type(), errs.ErrUnsupportedType)
+	}
+	return nil
+}
+
+// encodeArray writes the elements of a slice or array.
+func encodeArray(w tokenWriter, v reflect.Value) error {
+	w.writeArrayHeader(v.Len())
+	for i := 0; i < v.Len(); i++ {
+		if err := encodeValue(w, v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// field describes an encodable struct field.
+type field struct {
+	name      string
+	index     int
+	omitEmpty bool
+}
+
+// structFields returns the exported fields of t with their wire names.
+func structFields(t reflect.Type) []field {
+	fields := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(sf.Tag.Get("codec"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, field{name: name, index: i, omitEmpty: opts == "omitempty"})
+	}
+	return fields
+}
+
+// decodeNext reads the next token and decodes it into v.
+func decodeNext(r tokenReader, v reflect.Value) error {
+	t, err := r.next()
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	return decodeValue(r, t, v)
+}
+
+// decodeValue decodes the item starting with t into the settable value v.
+func decodeValue(r tokenReader, t token, v reflect.Value) error {
+	if t.kind == kindNil {
+		v.SetZero()
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeValue(r, t, v.Elem())
+	}
+	if (t.kind == kindString || t.kind == kindBytes) && reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(t.s)
+	}
+
+	mismatch := func() error {
+		return fmt.Errorf("binarycodec: cannot decode %s into %s: %w", t.describe(), v.Type(), errs.ErrMalformed)
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return fmt.Errorf("binarycodec: %s: %w", v.Type(), errs.ErrUnsupportedType)
+		}
+		x, err := decodeAny(r, t)
+		if err != nil {
+			return err
+		}
+		if x == nil {
+			v.SetZero()
+		} else {
+			v.Set(reflect.ValueOf(x))
+		}
+	case reflect.Bool:
+		if t.kind != kindBool {
+			return mismatch()
+		}
+		v.SetBool(t.b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		switch {
+		case t.kind == kindInt:
+			n = t.i
+		case t.kind == kindUint && t.u <= math.MaxInt64:
+			n = int64(t.u)
+		default:
+			return mismatch()
+		}
+		if v.OverflowInt(n) {
+			return fmt.Errorf("binarycodec: %d overflows %s: %w", n, v.Type(), errs.ErrMalformed)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if t.kind != kindUint {
+			return mismatch()
+		}
+		if v.OverflowUint(t.u) {
+			return fmt.Errorf("binarycodec: %d overflows %s: %w", t.u, v.Type(), errs.ErrMalformed)
+		}
+		v.SetUint(t.u)
+	case reflect.Float32, reflect.Float64:
+		switch t.kind {
+		case kindFloat:
+			v.SetFloat(t.f)
+		case kindInt:
+			v.SetFloat(float64(t.i))
+		case kindUint:
+			v.SetFloat(float64(t.u))
+		default:
+			return mismatch()
+		}
+	case reflect.String:
+		if t.kind != kindString && t.kind != kindBytes {
+			return mismatch()
+		}
+		v.SetString(string(t.s))
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 && (t.kind == kindBytes || t.kind == kindString) {
+			b := reflect.MakeSlice(v.Type(), len(t.s), len(t.s))
+			reflect.Copy(b, reflect.ValueOf(t.s))
+			v.Set(b)
+			return nil
+		}
+		if t.kind != kindArray {
+			return mismatch()
+		}
+		s := reflect.MakeSlice(v.Type(), 0, prealloc(t.n))
+		err := forEachElement(r, t.n, func() error {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeNext(r, elem); err != nil {
+				return err
+			}
+			s = reflect.Append(s, elem)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		v.Set(s)
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 && (t.kind == kindBytes || t.kind == kindString) {
+			v.SetZero()
+			reflect.Copy(v, reflect.ValueOf(t.s))
+			return nil
+		}
+		if t.kind != kindArray {
+			return mismatch()
+		}
+		v.SetZero()
+		i := 0
+		return forEachElement(r, t.n, func() error {
+			defer func() { i++ }()
+			if i >= v.Len() {
+				return skipNext(r)
+			}
+			return decodeNext(r, v.Index(i))
+		})
+	case reflect.Map:
+		if t.kind != kindMap {
+			return mismatch()
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(v.Type(), prealloc(t.n)))
+		}
+		return forEachElement(r, t.n, func() error {
+			key := reflect.New(v.Type().Key()).Elem()
+			if err := decodeNext(r, key); err != nil {
+				return err
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeNext(r, elem); err != nil {
+				return err
+			}
+			if key.Kind() == reflect.Interface && !key.IsNil() {
+				if b, ok := key.Interface().([]byte); ok {
+					key = reflect.ValueOf(string(b)) // byte slices are not comparable
+				}
+			}
+			if !key.Comparable() {
+				return fmt.Errorf("binarycodec: map key of type %s: %w", key.Elem().Type(), errs.ErrUnsupportedKey)
+			}
+			v.SetMapIndex(key, elem)
+			return nil
+		})
+	case reflect.Struct:
+		if t.kind != kindMap {
+			return mismatch()
+		}
+		fields := structFields(v.Type())
+		return forEachElement(r, t.n, func() error {
+			var name string
+			if err := decodeNext(r, reflect.ValueOf(&name).Elem()); err != nil {
+				return err
+			}
+			for _, f := range fields {
+				if f.name == name {
+					return decodeNext(r, v.Field(f.index))
+				}
+			}
+			return skipNext(r)
+		})
+	default:
+		return fmt.Errorf("binarycodec: %s: %w", v.Type(), errs.ErrUnsupportedType)
+	}
+	return nil
+}
+
+// decodeAny decodes the item starting with t into its natural Go representation.
+func decodeAny(r tokenReader, t token) (any, error) {
+	switch t.kind {
+	case kindNil:
+		return nil, nil
+	case kindBool:
+		return t.b, nil
+	case kindInt:
+		return t.i, nil
+	case kindUint:
+		if t.u <= math.MaxInt64 {
+			return int64(t.u), nil
+		}
+		return t.u, nil
+	case kindFloat:
+		return t.f, nil
+	case kindString:
+		return string(t.s), nil
+	case kindBytes:
+		return bytes.Clone(t.s), nil
+	case kindArray:
+		s := make([]any, 0, prealloc(t.n))
+		err := forEachElement(r, t.n, func() error {
+			next, err := r.next()
+			if err != nil {
+				return unexpectedEOF(err)
+			}
+			x, err := decodeAny(r, next)
+			if err != nil {
+				return err
+			}
+			s = append(s, x)
+			return nil
+		})
+		return s, err
+	case kindMap:
+		m := make(map[any]any, prealloc(t.n))
+		allStrings := true
+		err := forEachElement(r, t.n, func() error {
+			var k, v any
+			for _, dst := range []*any{&k, &v} {
+				next, err := r.next()
+				if err != nil {
+					return unexpectedEOF(err)
+				}
+				if *dst, err = decodeAny(r, next); err != nil {
+					return err
+				}
+			}
+			if b, ok := k.([]byte); ok {
+				k = string(b) // byte slices are not comparable
+			}
+			switch k.(type) {
+			case string:
+			case []any, map[any]any, map[string]any:
+				return fmt.Errorf("binarycodec: map key of type %T: %w", k, errs.ErrUnsupportedKey)
+			default:
+				allStrings = false
+			}
+			m[k] = v
+			return nil
+		})
+		if err != nil || !allStrings {
+			return m, err
+		}
+		sm := make(map[string]any, len(m))
+		for k, v := range m {
+			sm[k.(string)] = v
+		}
+		return sm, nil
+	}
+	return nil, fmt.Errorf("binarycodec: unknown token: %w", errs.ErrMalformed)
+}
+
+// skipNext reads and discards the next item, including any nested items.
+func skipNext(r tokenReader) error {
+	t, err := r.next()
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	switch t.kind {
+	case kindArray:
+		return forEachElement(r, t.n, func() error { return skipNext(r) })
+	case kindMap:
+		return forEachElement(r, t.n, func() error {
+			if err := skipNext(r); err != nil {
+				return err
+			}
+			return skipNext(r)
+		})
+	}
+	return nil
+}
+
+// forEachElement calls fn once per element of a container of length n, or until the
+// break marker if n is negative.
+func forEachElement(r tokenReader, n int, fn func() error) error {
+	for i := 0; n < 0 || i < n; i++ {
+		if n < 0 {
+			brk, err := r.atBreak()
+			if err != nil {
+				return unexpectedEOF(err)
+			}
+			if brk {
+				return nil
+			}
+		}
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prealloc returns a safe initial capacity for a container announced with n elements.
+func prealloc(n int) int {
+	return max(0, min(n, maxPrealloc))
+}
+
+// unexpectedEOF converts io.EOF inside a value into a malformed-input error.
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("binarycodec: %w: %w", errs.ErrMalformed, io.ErrUnexpectedEOF)
+	}
+	return err
+}
+
+// readN reads exactly n bytes without trusting n for the initial allocation.
+func readN(r io.Reader, n uint64) ([]byte, error) {
+	if n <= maxPrealloc {
+		b := make([]byte, n)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		return b, nil
+	}
+	var buf bytes.Buffer
+	if m, err := io.CopyN(&buf, r, int64(min(n, math.MaxInt64))); err != nil || uint64(m) != n {
+		return nil, unexpectedEOF(io.EOF)
+	}
+	return buf.Bytes(), nil
+}
+
+// describe names the kind of a token for error messages.
+func (t token) describe() string {
+	switch t.kind {
+	case kindNil:
+		return "nil"
+	case kindBool:
+		return "bool"
+	case kindInt, kindUint:
+		return "integer"
+	case kindFloat:
+		return "float"
+	case kindString:
+		return "string"
+	case kindBytes:
+		return "binary"
+	case kindArray:
+		return "array"
+	case kindMap:
+		return "map"
+	}
+	return "unknown"
+}
@@ -0,0 +1,267 @@
+package binarycodec
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// CBOR major types.
+const (
+	cborUint   = 0
+	cborNegInt = 1
+	cborBytes  = 2
+	cborText   = 3
+	cborArray  = 4
+	cborMap    = 5
+	cborTag    = 6
+	cborSimple = 7
+)
+
+// cborBreak terminates an indefinite-length item.
+const cborBreak = 0xff
+
+// cborWriter emits CBOR items using definite lengths and the shortest argument encoding.
+// Write errors are sticky in the underlying bufio.Writer and reported on Flush.
+type cborWriter struct {
+	w   *bufio.Writer
+	buf [9]byte
+}
+
+func (c *cborWriter) writeNil() {
+	c.w.WriteByte(0xf6)
+}
+
+func (c *cborWriter) writeBool(b bool) {
+	if b {
+		c.w.WriteByte(0xf5)
+	} else {
+		c.w.WriteByte(0xf4)
+	}
+}
+
+func (c *cborWriter) writeInt(v int64) {
+	if v >= 0 {
+		c.head(cborUint, uint64(v))
+	} else {
+		c.head(cborNegInt, uint64(-(v + 1)))
+	}
+}
+
+func (c *cborWriter) writeUint(v uint64) {
+	c.head(cborUint, v)
+}
+
+func (c *cborWriter) writeFloat32(f float32) {
+	c.buf[0] = 0xfa
+	binary.BigEndian.PutUint32(c.buf[1:], math.Float32bits(f))
+	c.w.Write(c.buf[:5])
+}
+
+func (c *cborWriter) writeFloat64(f float64) {
+	c.buf[0] = 0xfb
+	binary.BigEndian.PutUint64(c.buf[1:], math.Float64bits(f))
+	c.w.Write(c.buf[:9])
+}
+
+func (c *cborWriter) writeString(s string) {
+	c.head(cborText, uint64(len(s)))
+	c.w.WriteString(s)
+}
+
+func (c *cborWriter) writeBytes(b []byte) {
+	c.head(cborBytes, uint64(len(b)))
+	c.w.Write(b)
+}
+
+func (c *cborWriter) writeArrayHeader(n int) {
+	c.head(cborArray, uint64(n))
+}
+
+func (c *cborWriter) writeMapHeader(n int) {
+	c.head(cborMap, uint64(n))
+}
+
+// head writes the initial byte of an item of the given major type and its argument.
+func (c *cborWriter) head(major byte, arg uint64) {
+	major <<= 5
+	switch {
+	case arg < 24:
+		c.w.WriteByte(major | byte(arg))
+	case arg <= math.MaxUint8:
+		c.w.Write([]byte{major | 24, byte(arg)})
+	case arg <= math.MaxUint16:
+		c.buf[0] = major | 25
+		binary.BigEndian.PutUint16(c.buf[1:], uint16(arg))
+		c.w.Write(c.buf[:3])
+	case arg <= math.MaxUint32:
+		c.buf[0] = major | 26
+		binary.BigEndian.PutUint32(c.buf[1:], uint32(arg))
+		c.w.Write(c.buf[:5])
+	default:
+		c.buf[0] = major | 27
+		binary.BigEndian.PutUint64(c.buf[1:], arg)
+		c.w.Write(c.buf[:9])
+	}
+}
+
+// cborReader reads CBOR items. Indefinite-length strings are concatenated, tags are
+// skipped so the tagged content is decoded on its own, and undefined reads as nil.
+type cborReader struct {
+	r *bufio.Reader
+}
+
+func (c *cborReader) next() (token, error) {
+	for {
+		b, err := c.r.ReadByte()
+		if err != nil {
+			return token{}, err
+		}
+		major, info := b>>5, b&0x1f
+
+		if major == cborSimple {
+			return c.simple(info)
+		}
+		if info == 31 {
+			switch major {
+			case cborBytes, cborText:
+				return c.chunked(major)
+			case cborArray:
+				return token{kind: kindArray, n: -1}, nil
+			case cborMap:
+				return token{kind: kindMap, n: -1}, nil
+			}
+			return token{}, fmt.Errorf("binarycodec: indefinite length for CBOR major type %d: %w", major, errs.ErrMalformed)
+		}
+
+		arg, err := c.arg(info)
+		if err != nil {
+			return token{}, err
+		}
+		switch major {
+		case cborUint:
+			return token{kind: kindUint, u: arg}, nil
+		case cborNegInt:
+			if arg > math.MaxInt64 {
+				return token{}, fmt.Errorf("binarycodec: CBOR negative integer overflows int64: %w", errs.ErrMalformed)
+			}
+			return token{kind: kindInt, i: -1 - int64(arg)}, nil
+		case cborBytes, cborText:
+			s, err := readN(c.r, arg)
+			return token{kind: stringKind(major), s: s}, err
+		case cborArray, cborMap:
+			if arg > math.MaxInt32 {
+				return token{}, fmt.Errorf("binarycodec: CBOR container length %d: %w", arg, errs.ErrMalformed)
+			}
+			if major == cborArray {
+				return token{kind: kindArray, n: int(arg)}, nil
+			}
+			return token{kind: kindMap, n: int(arg)}, nil
+		case cborTag:
+			continue // decode the tagged item itself
+		}
+	}
+}
+
+func (c *cborReader) atBreak() (bool, error) {
+	b, err := c.r.ReadByte()
+	if err != nil {
+		return false, err
+	}
+	if b == cborBreak {
+		return true, nil
+	}
+	return false, c.r.UnreadByte()
+}
+
+// arg reads the argument encoded by the additional information of an initial byte.
+func (c *cborReader) arg(info byte) (uint64, error) {
+	if info < 24 {
+		return uint64(info), nil
+	}
+	if info > 27 {
+		return 0, fmt.Errorf("binarycodec: reserved CBOR additional information %d: %w", info, errs.ErrMalformed)
+	}
+	size := 1 << (info - 24)
+	b, err := readN(c.r, uint64(size))
+	if err != nil {
+		return 0, err
+	}
+	var buf [8]byte
+	copy(buf[8-size:], b)
+	return binary.BigEndian.Uint64(buf[:]), nil
+}
+
+// simple decodes major type 7: booleans, null, undefined and floats.
+func (c *cborReader) simple(info byte) (token, error) {
+	switch info {
+	case 20, 21:
+		return token{kind: kindBool, b: info == 21}, nil
+	case 22, 23:
+		return token{kind: kindNil}, nil
+	case 25:
+		u, err := c.arg(info)
+		return token{kind: kindFloat, f: float16(uint16(u))}, err
+	case 26:
+		u, err := c.arg(info)
+		return token{kind: kindFloat, f: float64(math.Float32frombits(uint32(u)))}, err
+	case 27:
+		u, err := c.arg(info)
+		return token{kind: kindFloat, f: math.Float64frombits(u)}, err
+	}
+	return token{}, fmt.Errorf("binarycodec: unsupported CBOR simple value %d: %w", info, errs.ErrMalformed)
+}
+
+// chunked reads an indefinite-length byte or text string as one token.
+func (c *cborReader) chunked(major byte) (token, error) {
+	var buf bytes.Buffer
+	for {
+		brk, err := c.atBreak()
+		if err != nil {
+			return token{}, unexpectedEOF(err)
+		}
+		if brk {
+			return token{kind: stringKind(major), s: buf.Bytes()}, nil
+		}
+		chunk, err := c.next()
+		if err != nil {
+			return token{}, unexpectedEOF(err)
+		}
+		if chunk.kind != stringKind(major) {
+			return token{}, fmt.Errorf("binarycodec: CBOR string chunk of type %s: %w", chunk.describe(), errs.ErrMalformed)
+		}
+		buf.Write(chunk.s)
+	}
+}
+
+// stringKind maps a CBOR string major type to a token kind.
+func stringKind(major byte) kind {
+	if major == cborText {
+		return kindString
+	}
+	return kindBytes
+}
+
+// float16 converts an IEEE 754 half-precision value to float64.
+func float16(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+	exp := int(h>>10) & 0x1f
+	frac := float64(h & 0x3ff)
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(frac, -24)
+	case 0x1f:
+		if frac == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	}
+	return sign * math.Ldexp(frac+1024, exp-25)
+}
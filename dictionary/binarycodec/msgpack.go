@@ -0,0 +1,221 @@
+package binarycodec
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// msgpackWriter emits MessagePack items, always choosing the shortest encoding.
+// Write errors are sticky in the underlying bufio.Writer and reported on Flush.
+type msgpackWriter struct {
+	w   *bufio.Writer
+	buf [9]byte
+}
+
+func (m *msgpackWriter) writeNil() {
+	m.w.WriteByte(0xc0)
+}
+
+func (m *msgpackWriter) writeBool(b bool) {
+	if b {
+		m.w.WriteByte(0xc3)
+	} else {
+		m.w.WriteByte(0xc2)
+	}
+}
+
+func (m *msgpackWriter) writeInt(v int64) {
+	switch {
+	case v >= 0:
+		m.writeUint(uint64(v))
+	case v >= -32:
+		m.w.WriteByte(byte(v)) // negative fixint
+	case v >= math.MinInt8:
+		m.w.Write([]byte{0xd0, byte(v)})
+	case v >= math.MinInt16:
+		m.prefixed(0xd1, 2, uint64(v))
+	case v >= math.MinInt32:
+		m.prefixed(0xd2, 4, uint64(v))
+	default:
+		m.prefixed(0xd3, 8, uint64(v))
+	}
+}
+
+func (m *msgpackWriter) writeUint(v uint64) {
+	switch {
+	case v <= 0x7f:
+		m.w.WriteByte(byte(v)) // positive fixint
+	case v <= math.MaxUint8:
+		m.w.Write([]byte{0xcc, byte(v)})
+	case v <= math.MaxUint16:
+		m.prefixed(0xcd, 2, v)
+	case v <= math.MaxUint32:
+		m.prefixed(0xce, 4, v)
+	default:
+		m.prefixed(0xcf, 8, v)
+	}
+}
+
+func (m *msgpackWriter) writeFloat32(f float32) {
+	m.prefixed(0xca, 4, uint64(math.Float32bits(f)))
+}
+
+func (m *msgpackWriter) writeFloat64(f float64) {
+	m.prefixed(0xcb, 8, math.Float64bits(f))
+}
+
+func (m *msgpackWriter) writeString(s string) {
+	n := uint64(len(s))
+	switch {
+	case n < 32:
+		m.w.WriteByte(0xa0 | byte(n))
+	case n <= math.MaxUint8:
+		m.w.Write([]byte{0xd9, byte(n)})
+	case n <= math.MaxUint16:
+		m.prefixed(0xda, 2, n)
+	default:
+		m.prefixed(0xdb, 4, n)
+	}
+	m.w.WriteString(s)
+}
+
+func (m *msgpackWriter) writeBytes(b []byte) {
+	n := uint64(len(b))
+	switch {
+	case n <= math.MaxUint8:
+		m.w.Write([]byte{0xc4, byte(n)})
+	case n <= math.MaxUint16:
+		m.prefixed(0xc5, 2, n)
+	default:
+		m.prefixed(0xc6, 4, n)
+	}
+	m.w.Write(b)
+}
+
+func (m *msgpackWriter) writeArrayHeader(n int) {
+	switch {
+	case n < 16:
+		m.w.WriteByte(0x90 | byte(n))
+	case n <= math.MaxUint16:
+		m.prefixed(0xdc, 2, uint64(n))
+	default:
+		m.prefixed(0xdd, 4, uint64(n))
+	}
+}
+
+func (m *msgpackWriter) writeMapHeader(n int) {
+	switch {
+	case n < 16:
+		m.w.WriteByte(0x80 | byte(n))
+	case n <= math.MaxUint16:
+		m.prefixed(0xde, 2, uint64(n))
+	default:
+		m.prefixed(0xdf, 4, uint64(n))
+	}
+}
+
+// prefixed writes the type byte followed by the low size bytes of v in big-endian order.
+func (m *msgpackWriter) prefixed(typ byte, size int, v uint64) {
+	m.buf[0] = typ
+	binary.BigEndian.PutUint64(m.buf[1:], v<<(64-8*size))
+	m.w.Write(m.buf[:1+size])
+}
+
+// msgpackReader reads MessagePack items.
+type msgpackReader struct {
+	r *bufio.Reader
+}
+
+func (m *msgpackReader) next() (token, error) {
+	b, err := m.r.ReadByte()
+	if err != nil {
+		return token{}, err
+	}
+	switch {
+	case b <= 0x7f:
+		return token{kind: kindUint, u: uint64(b)}, nil
+	case b >= 0xe0:
+		return token{kind: kindInt, i: int64(int8(b))}, nil
+	case b&0xe0 == 0xa0:
+		return m.str(kindString, uint64(b&0x1f))
+	case b&0xf0 == 0x90:
+		return token{kind: kindArray, n: int(b & 0x0f)}, nil
+	case b&0xf0 == 0x80:
+		return token{kind: kindMap, n: int(b & 0x0f)}, nil
+	}
+
+	switch b {
+	case 0xc0:
+		return token{kind: kindNil}, nil
+	case 0xc2, 0xc3:
+		return token{kind: kindBool, b: b == 0xc3}, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		u, err := m.uint(1 << (b - 0xcc))
+		return token{kind: kindUint, u: u}, err
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (b - 0xd0)
+		u, err := m.uint(size)
+		// Sign-extend the size-byte value to 64 bits.
+		shift := 64 - 8*size
+		return signed(int64(u<<shift) >> shift), err
+	case 0xca:
+		u, err := m.uint(4)
+		return token{kind: kindFloat, f: float64(math.Float32frombits(uint32(u)))}, err
+	case 0xcb:
+		u, err := m.uint(8)
+		return token{kind: kindFloat, f: math.Float64frombits(u)}, err
+	case 0xd9, 0xda, 0xdb:
+		n, err := m.uint(1 << (b - 0xd9))
+		if err != nil {
+			return token{}, err
+		}
+		return m.str(kindString, n)
+	case 0xc4, 0xc5, 0xc6:
+		n, err := m.uint(1 << (b - 0xc4))
+		if err != nil {
+			return token{}, err
+		}
+		return m.str(kindBytes, n)
+	case 0xdc, 0xdd:
+		n, err := m.uint(2 << (b - 0xdc))
+		return token{kind: kindArray, n: int(n)}, err
+	case 0xde, 0xdf:
+		n, err := m.uint(2 << (b - 0xde))
+		return token{kind: kindMap, n: int(n)}, err
+	}
+	return token{}, fmt.Errorf("binarycodec: unsupported MessagePack type 0x%02x: %w", b, errs.ErrMalformed)
+}
+
+// atBreak always reports false; MessagePack containers have explicit lengths.
+func (m *msgpackReader) atBreak() (bool, error) {
+	return false, nil
+}
+
+// uint reads a big-endian unsigned integer of the given size in bytes.
+func (m *msgpackReader) uint(size int) (uint64, error) {
+	var buf [8]byte
+	b, err := readN(m.r, uint64(size))
+	if err != nil {
+		return 0, err
+	}
+	copy(buf[8-size:], b)
+	return binary.BigEndian.Uint64(buf[:]), nil
+}
+
+// str reads a string or binary payload of n bytes.
+func (m *msgpackReader) str(k kind, n uint64) (token, error) {
+	b, err := readN(m.r, n)
+	return token{kind: k, s: b}, err
+}
+
+// signed returns a token for a signed integer, normalizing non-negative values to kindUint.
+func signed(i int64) token {
+	if i >= 0 {
+		return token{kind: kindUint, u: uint64(i)}
+	}
+	return token{kind: kindInt, i: i}
+}
@@ -0,0 +1,135 @@
+package dictionary
+
+import (
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// BoundedDictionary is a Dictionary holding at most a fixed number of entries. Inserting a
+// new key into a full BoundedDictionary first evicts the entry chosen by its EvictionPolicy,
+// which makes it a building block for simple caches. A BoundedDictionary is not safe for
+// concurrent use; since reads are reported to the policy, this includes concurrent reads.
+type BoundedDictionary[K comparable, V any] struct {
+	entries  Dictionary[K, V]
+	capacity int
+	policy   EvictionPolicy[K, V]
+	onEvict  func(K, V)
+}
+
+var _ collection.MutableMap[string, int] = (*BoundedDictionary[string, int])(nil)
+
+// NewBoundedDictionary creates an empty BoundedDictionary.
+//
+// Parameters:
+//   - capacity: The maximum number of entries; values below 1 are treated as 1.
+//   - policy: The eviction policy, or nil for FIFOEviction.
+//   - onEvict: The function called with each evicted entry, or nil.
+//
+// Returns:
+//   - A new empty BoundedDictionary.
+//
+// Example:
+//
+//	d := NewBoundedDictionary(2, FIFOEviction[string, int](), func(k string, v int) {
+//		log.Printf("evicted %s", k)
+//	})
+//	d.SetValue("a", 1)
+//	d.SetValue("b", 2)
+//	d.SetValue("c", 3) // logs: evicted a
+func NewBoundedDictionary[K comparable, V any](capacity int, policy EvictionPolicy[K, V], onEvict func(K, V)) *BoundedDictionary[K, V] {
+	if policy == nil {
+		policy = FIFOEviction[K, V]()
+	}
+	return &BoundedDictionary[K, V]{
+		entries:  NewDictionaryWithCapacity[K, V](max(capacity, 1)),
+		capacity: max(capacity, 1),
+		policy:   policy,
+		onEvict:  onEvict,
+	}
+}
+
+// GetValue retrieves the value associated with the specified key, or the zero value if it is absent.
+func (d *BoundedDictionary[K, V]) GetValue(key K) V {
+	v, _ := d.Lookup(key)
+	return v
+}
+
+// Lookup retrieves the value associated with the specified key and reports the access to
+// the eviction policy.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (d *BoundedDictionary[K, V]) Lookup(key K) (V, bool) {
+	v, ok := d.entries[key]
+	if ok {
+		d.policy.Accessed(key)
+	}
+	return v, ok
+}
+
+// ContainsKey checks if the specified key is present, without reporting an access.
+func (d *BoundedDictionary[K, V]) ContainsKey(key K) bool {
+	return d.entries.ContainsKey(key)
+}
+
+// SetValue sets the value for a given key. If the key is new and the dictionary is full,
+// the entry chosen by the eviction policy is removed first.
+//
+// Parameters:
+//   - key: The key for which the value needs to be set.
+//   - value: The value to be set for the given key.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrCapacityExceeded if the dictionary is full and
+//     the policy refuses to evict or names a key that is not present, nil otherwise.
+func (d *BoundedDictionary[K, V]) SetValue(key K, value V) error {
+	if _, ok := d.entries[key]; ok {
+		d.entries[key] = value
+		d.policy.Accessed(key)
+		return nil
+	}
+	if len(d.entries) >= d.capacity {
+		victim, ok := d.policy.Victim(d.entries.Where(func(K, V) bool { return true }))
+		old, present := d.entries[victim]
+		if !ok || !present {
+			return errs.NewKeyError("set", key, errs.ErrCapacityExceeded)
+		}
+		delete(d.entries, victim)
+		d.policy.Removed(victim)
+		if d.onEvict != nil {
+			d.onEvict(victim, old)
+		}
+	}
+	d.entries[key] = value
+	d.policy.Inserted(key)
+	return nil
+}
+
+// DeleteValue removes the specified key. If the key does not exist, nothing changes.
+func (d *BoundedDictionary[K, V]) DeleteValue(key K) {
+	if _, ok := d.entries[key]; ok {
+		delete(d.entries, key)
+		d.policy.Removed(key)
+	}
+}
+
+// GetKeys returns the keys in unspecified order.
+func (d *BoundedDictionary[K, V]) GetKeys() []K {
+	return d.entries.GetKeys()
+}
+
+// GetLength returns the number of entries.
+func (d *BoundedDictionary[K, V]) GetLength() int {
+	return len(d.entries)
+}
+
+// Capacity returns the maximum number of entries.
+func (d *BoundedDictionary[K, V]) Capacity() int {
+	return d.capacity
+}
+
+// ToDictionary returns a copy of the entries as a plain Dictionary.
+func (d *BoundedDictionary[K, V]) ToDictionary() Dictionary[K, V] {
+	return d.entries.CopyDictionary()
+}
@@ -0,0 +1,164 @@
+package dictionary
+
+import (
+	"reflect"
+
+	"github.com/bhanurp/gotypes/profiling"
+)
+
+// Cloner is implemented by values that know how to produce a deep copy of themselves.
+// DeepCopy prefers Clone over reflection for any value implementing it.
+type Cloner[V any] interface {
+	Clone() V
+}
+
+// DeepCopy returns a copy of the current Dictionary whose values share no memory with the original.
+// Values implementing Cloner[V] are copied with their Clone method. All other values are
+// copied by reflection: pointers, slices, maps, arrays, structs and interfaces are followed
+// recursively (nested values with a Clone method returning their own type are cloned with it),
+// and shared or cyclic pointers are preserved in the copy. Channels, functions and unexported
+// struct fields cannot be copied through reflection and are shared with the original.
+// Keys are copied as-is.
+//
+// Returns:
+//   - Dictionary[K, V]: A deep copy of the current Dictionary.
+//
+// Example:
+//
+//	dict := Dictionary[string, []int]{"primes": {2, 3, 5}}
+//	copy := dict.DeepCopy()
+//	copy["primes"][0] = 7
+//	// dict["primes"] is still []int{2, 3, 5}
+func (d Dictionary[K, V]) DeepCopy() Dictionary[K, V] {
+	if d == nil {
+		return nil
+	}
+	copy := make(Dictionary[K, V], len(d))
+	profiling.Do("dictionary.DeepCopy", func() int {
+		c := &copier{seen: make(map[visit]reflect.Value)}
+		for k, v := range d {
+			copy[k] = deepCopyValue(c, v)
+		}
+		return len(d)
+	})
+	return copy
+}
+
+// deepCopyValue copies a single value, preferring its Cloner implementation.
+func deepCopyValue[V any](c *copier, v V) V {
+	if cl, ok := any(v).(Cloner[V]); ok {
+		return cl.Clone()
+	}
+	src := reflect.ValueOf(&v).Elem()
+	// A nil interface value comes back as a nil any, which only the comma-ok form accepts.
+	copied, _ := c.copy(src).Interface().(V)
+	return copied
+}
+
+// visit identifies an already copied pointer or map so shared references stay shared.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// copier holds the state of a single reflection-based deep copy.
+type copier struct {
+	seen map[visit]reflect.Value
+}
+
+// copy returns a deep copy of src with the same type.
+func (c *copier) copy(src reflect.Value) reflect.Value {
+	if cloned, ok := cloneMethod(src); ok {
+		return cloned
+	}
+
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return src
+		}
+		key := visit{src.Pointer(), src.Type()}
+		if dst, ok := c.seen[key]; ok {
+			return dst
+		}
+		dst := reflect.New(src.Type().Elem())
+		c.seen[key] = dst
+		dst.Elem().Set(c.copy(src.Elem()))
+		return dst
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return src
+		}
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(c.copy(src.Elem()))
+		return dst
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return src
+		}
+		dst := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(c.copy(src.Index(i)))
+		}
+		return dst
+
+	case reflect.Array:
+		dst := reflect.New(src.Type()).Elem()
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(c.copy(src.Index(i)))
+		}
+		return dst
+
+	case reflect.Map:
+		if src.IsNil() {
+			return src
+		}
+		key := visit{src.Pointer(), src.Type()}
+		if dst, ok := c.seen[key]; ok {
+			return dst
+		}
+		dst := reflect.MakeMapWithSize(src.Type(), src.Len())
+		c.seen[key] = dst
+		iter := src.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(iter.Key(), c.copy(iter.Value()))
+		}
+		return dst
+
+	case reflect.Struct:
+		// Copying the whole struct first carries over unexported fields, which
+		// reflection cannot set individually.
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				dst.Field(i).Set(c.copy(src.Field(i)))
+			}
+		}
+		return dst
+
+	default:
+		return src
+	}
+}
+
+// cloneMethod calls a Clone method on v if its type has one returning the same type.
+func cloneMethod(v reflect.Value) (reflect.Value, bool) {
+	if !v.IsValid() || v.Type().NumMethod() == 0 {
+		return reflect.Value{}, false
+	}
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return reflect.Value{}, false
+	}
+	m := v.MethodByName("Clone")
+	if !m.IsValid() {
+		return reflect.Value{}, false
+	}
+	t := m.Type()
+	if t.NumIn() != 0 || t.NumOut() != 1 || t.Out(0) != v.Type() {
+		return reflect.Value{}, false
+	}
+	return m.Call(nil)[0], true
+}
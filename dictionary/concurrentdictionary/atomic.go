@@ -0,0 +1,188 @@
+package concurrentdictionary
+
+import (
+	"sync"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/equality"
+)
+
+// AtomicDictionary is a typed adapter over sync.Map. It suits read-mostly workloads, and
+// keys that are written once and then only read, where sync.Map outperforms a mutex.
+// The zero value is an empty AtomicDictionary ready to use; it must not be copied after
+// first use.
+//
+// Values are stored boxed, so CompareAndSwap and CompareAndDelete can compare any V with
+// equality.Equal, as ConcurrentDictionary does, and swap the box atomically.
+type AtomicDictionary[K comparable, V any] struct {
+	m sync.Map // of K to *box[V]
+}
+
+// box holds a stored value. A new box is stored on every write, so boxes compare by identity.
+type box[V any] struct {
+	value V
+}
+
+var _ collection.WritableMap[string, int] = (*AtomicDictionary[string, int])(nil)
+
+// NewAtomic creates an empty AtomicDictionary.
+//
+// Returns:
+//   - A new empty AtomicDictionary.
+//
+// Example:
+//
+//	routes := NewAtomic[string, http.Handler]()
+//	routes.SetValue("/health", healthHandler)
+func NewAtomic[K comparable, V any]() *AtomicDictionary[K, V] {
+	return new(AtomicDictionary[K, V])
+}
+
+// loaded unboxes a value loaded from the sync.Map.
+func loaded[V any](v any, ok bool) (V, bool) {
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return v.(*box[V]).value, true
+}
+
+// GetValue retrieves the value associated with the specified key, or the zero value if it is absent.
+func (a *AtomicDictionary[K, V]) GetValue(key K) V {
+	v, _ := a.Lookup(key)
+	return v
+}
+
+// Lookup retrieves the value associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (a *AtomicDictionary[K, V]) Lookup(key K) (V, bool) {
+	return loaded[V](a.m.Load(key))
+}
+
+// ContainsKey checks if the specified key is present.
+func (a *AtomicDictionary[K, V]) ContainsKey(key K) bool {
+	_, ok := a.m.Load(key)
+	return ok
+}
+
+// SetValue sets the value for a given key.
+func (a *AtomicDictionary[K, V]) SetValue(key K, value V) {
+	a.m.Store(key, &box[V]{value})
+}
+
+// DeleteValue removes the specified key. If the key does not exist, nothing changes.
+func (a *AtomicDictionary[K, V]) DeleteValue(key K) {
+	a.m.Delete(key)
+}
+
+// GetOrSet returns the value of key if it is present, and otherwise stores value.
+//
+// Returns:
+//   - V: The existing value, or value if it was stored.
+//   - bool: True if the value was already present, false if value was stored.
+func (a *AtomicDictionary[K, V]) GetOrSet(key K, value V) (V, bool) {
+	v, ok := a.m.LoadOrStore(key, &box[V]{value})
+	return v.(*box[V]).value, ok
+}
+
+// Pop removes the specified key and returns its value.
+//
+// Returns:
+//   - V: The removed value, or the zero value if the key was absent.
+//   - bool: True if the key was present, false otherwise.
+func (a *AtomicDictionary[K, V]) Pop(key K) (V, bool) {
+	return loaded[V](a.m.LoadAndDelete(key))
+}
+
+// Swap stores value under key and returns the previous value.
+//
+// Returns:
+//   - V: The previous value, or the zero value if the key was absent.
+//   - bool: True if the key was present, false otherwise.
+func (a *AtomicDictionary[K, V]) Swap(key K, value V) (V, bool) {
+	return loaded[V](a.m.Swap(key, &box[V]{value}))
+}
+
+// CompareAndSwap stores new under key only if the key is present with a value equal to
+// old, compared as dictionary.Dictionary.IsEqual compares values.
+//
+// Returns:
+//   - bool: True if the value was swapped, false otherwise.
+func (a *AtomicDictionary[K, V]) CompareAndSwap(key K, old, new V) bool {
+	for {
+		cur, ok := a.m.Load(key)
+		if !ok || !equality.Equal(cur.(*box[V]).value, old) {
+			return false
+		}
+		if a.m.CompareAndSwap(key, cur, &box[V]{new}) {
+			return true
+		}
+		// Another write replaced the box in between; compare against its value.
+	}
+}
+
+// CompareAndDelete removes key only if it is present with a value equal to old, compared
+// as dictionary.Dictionary.IsEqual compares values.
+//
+// Returns:
+//   - bool: True if the key was removed, false otherwise.
+func (a *AtomicDictionary[K, V]) CompareAndDelete(key K, old V) bool {
+	for {
+		cur, ok := a.m.Load(key)
+		if !ok || !equality.Equal(cur.(*box[V]).value, old) {
+			return false
+		}
+		if a.m.CompareAndDelete(key, cur) {
+			return true
+		}
+	}
+}
+
+// Range calls fn for each entry, in unspecified order, until fn returns false. As with
+// sync.Map, it does not see a consistent snapshot, and fn may modify the dictionary.
+func (a *AtomicDictionary[K, V]) Range(fn func(key K, value V) bool) {
+	a.m.Range(func(k, v any) bool {
+		key, _ := k.(K) // comma-ok: a nil stored under an interface type K is not a K
+		return fn(key, v.(*box[V]).value)
+	})
+}
+
+// GetKeys returns the keys in unspecified order.
+func (a *AtomicDictionary[K, V]) GetKeys() []K {
+	var keys []K
+	a.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+// GetLength returns the number of entries. sync.Map keeps no count, so it takes linear time.
+func (a *AtomicDictionary[K, V]) GetLength() int {
+	n := 0
+	a.m.Range(func(any, any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// ClearDictionary removes every entry.
+func (a *AtomicDictionary[K, V]) ClearDictionary() {
+	a.m.Clear()
+}
+
+// CopyDictionary returns the entries as a plain Dictionary. Like Range, it does not see a
+// consistent snapshot under concurrent writes.
+func (a *AtomicDictionary[K, V]) CopyDictionary() dictionary.Dictionary[K, V] {
+	out := dictionary.DefaultDictionary[K, V]()
+	a.Range(func(k K, v V) bool {
+		out[k] = v
+		return true
+	})
+	return out
+}
@@ -0,0 +1,235 @@
+// Package concurrentdictionary provides a Dictionary that is safe for concurrent use, with
+// compound operations such as GetOrSet and CompareAndSwap that run atomically.
+package concurrentdictionary
+
+import (
+	"sync"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/equality"
+)
+
+// ConcurrentDictionary guards a Dictionary with a sync.RWMutex. Reads run in parallel;
+// writes and compound operations are exclusive. The zero value is not usable; create one
+// with New or FromDictionary.
+type ConcurrentDictionary[K comparable, V any] struct {
+	mu      sync.RWMutex
+	entries dictionary.Dictionary[K, V]
+}
+
+var _ collection.WritableMap[string, int] = (*ConcurrentDictionary[string, int])(nil)
+
+// New creates an empty ConcurrentDictionary.
+//
+// Returns:
+//   - A new empty ConcurrentDictionary.
+//
+// Example:
+//
+//	sessions := New[string, *Session]()
+//	s, loaded := sessions.GetOrSet(id, newSession(id))
+func New[K comparable, V any]() *ConcurrentDictionary[K, V] {
+	return &ConcurrentDictionary[K, V]{entries: dictionary.DefaultDictionary[K, V]()}
+}
+
+// FromDictionary creates a ConcurrentDictionary holding a copy of the entries of d.
+func FromDictionary[K comparable, V any](d dictionary.Dictionary[K, V]) *ConcurrentDictionary[K, V] {
+	return &ConcurrentDictionary[K, V]{entries: d.CopyDictionary()}
+}
+
+// GetValue retrieves the value associated with the specified key, or the zero value if it is absent.
+func (c *ConcurrentDictionary[K, V]) GetValue(key K) V {
+	v, _ := c.Lookup(key)
+	return v
+}
+
+// Lookup retrieves the value associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (c *ConcurrentDictionary[K, V]) Lookup(key K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	v, ok := c.entries[key]
+	return v, ok
+}
+
+// ContainsKey checks if the specified key is present.
+func (c *ConcurrentDictionary[K, V]) ContainsKey(key K) bool {
+	_, ok := c.Lookup(key)
+	return ok
+}
+
+// SetValue sets the value for a given key.
+func (c *ConcurrentDictionary[K, V]) SetValue(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = value
+}
+
+// DeleteValue removes the specified key. If the key does not exist, nothing changes.
+func (c *ConcurrentDictionary[K, V]) DeleteValue(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// GetOrSet returns the value of key if it is present, and otherwise stores value.
+//
+// Parameters:
+//   - key: The key to look up.
+//   - value: The value to store if the key is absent.
+//
+// Returns:
+//   - V: The existing value, or value if it was stored.
+//   - bool: True if the value was already present, false if value was stored.
+//
+// Example:
+//
+//	counter, loaded := counters.GetOrSet("hits", new(atomic.Int64))
+func (c *ConcurrentDictionary[K, V]) GetOrSet(key K, value V) (V, bool) {
+	if v, ok := c.Lookup(key); ok {
+		return v, true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.entries[key]; ok {
+		return v, true
+	}
+	c.entries[key] = value
+	return value, false
+}
+
+// GetOrCompute is like GetOrSet but only calls fn, under the write lock, when the key is
+// absent, so an expensive value is built at most once. fn must not use the dictionary.
+//
+// Returns:
+//   - V: The existing or computed value.
+//   - bool: True if the value was already present, false if it was computed.
+func (c *ConcurrentDictionary[K, V]) GetOrCompute(key K, fn func() V) (V, bool) {
+	if v, ok := c.Lookup(key); ok {
+		return v, true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.entries[key]; ok {
+		return v, true
+	}
+	v := fn()
+	c.entries[key] = v
+	return v, false
+}
+
+// CompareAndSwap stores new under key only if the key is present with a value equal to
+// old, compared as dictionary.Dictionary.IsEqual compares values.
+//
+// Returns:
+//   - bool: True if the value was swapped, false otherwise.
+//
+// Example:
+//
+//	for {
+//		old := balances.GetValue("alice")
+//		if balances.CompareAndSwap("alice", old, old+10) {
+//			break
+//		}
+//	}
+func (c *ConcurrentDictionary[K, V]) CompareAndSwap(key K, old, new V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.entries[key]
+	if !ok || !equality.Equal(v, old) {
+		return false
+	}
+	c.entries[key] = new
+	return true
+}
+
+// CompareAndDelete removes key only if it is present with a value equal to old.
+//
+// Returns:
+//   - bool: True if the key was removed, false otherwise.
+func (c *ConcurrentDictionary[K, V]) CompareAndDelete(key K, old V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.entries[key]
+	if !ok || !equality.Equal(v, old) {
+		return false
+	}
+	delete(c.entries, key)
+	return true
+}
+
+// Update replaces the value for a given key with the result of fn, atomically. fn receives
+// the current value (or the zero value) and whether the key was present, and runs under
+// the write lock, so it must not use the dictionary.
+//
+// Returns:
+//   - V: The new value.
+//
+// Example:
+//
+//	hits.Update("/", func(n int, _ bool) int { return n + 1 })
+func (c *ConcurrentDictionary[K, V]) Update(key K, fn func(old V, exists bool) V) V {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	old, ok := c.entries[key]
+	v := fn(old, ok)
+	c.entries[key] = v
+	return v
+}
+
+// Pop removes the specified key and returns its value.
+//
+// Returns:
+//   - V: The removed value, or the zero value if the key was absent.
+//   - bool: True if the key was present, false otherwise.
+func (c *ConcurrentDictionary[K, V]) Pop(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.entries[key]
+	delete(c.entries, key)
+	return v, ok
+}
+
+// Range calls fn for each entry, in unspecified order, until fn returns false. It holds
+// the read lock throughout, so fn must not modify the dictionary.
+func (c *ConcurrentDictionary[K, V]) Range(fn func(key K, value V) bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for k, v := range c.entries {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
+
+// GetKeys returns the keys in unspecified order.
+func (c *ConcurrentDictionary[K, V]) GetKeys() []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.entries.GetKeys()
+}
+
+// GetLength returns the number of entries.
+func (c *ConcurrentDictionary[K, V]) GetLength() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// ClearDictionary removes every entry.
+func (c *ConcurrentDictionary[K, V]) ClearDictionary() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries.ClearDictionary()
+}
+
+// CopyDictionary returns a consistent snapshot of the entries as a plain Dictionary.
+func (c *ConcurrentDictionary[K, V]) CopyDictionary() dictionary.Dictionary[K, V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.entries.CopyDictionary()
+}
@@ -0,0 +1,239 @@
+package concurrentdictionary
+
+import (
+	"hash/maphash"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/equality"
+)
+
+// shard is one lock-striped partition of a ShardedDictionary.
+type shard[K comparable, V any] struct {
+	mu      sync.RWMutex
+	entries dictionary.Dictionary[K, V]
+}
+
+// ShardedDictionary partitions its keys across several independently locked shards, so
+// concurrent writers to different keys rarely wait for each other. Operations on a single
+// key are atomic, as in ConcurrentDictionary; operations spanning keys, such as Range, see
+// each shard at a different moment unless stated otherwise. The zero value is not usable;
+// create one with NewSharded.
+type ShardedDictionary[K comparable, V any] struct {
+	shards []shard[K, V]
+	mask   uint64
+	hash   func(K) uint64
+	length atomic.Int64
+}
+
+var _ collection.WritableMap[string, int] = (*ShardedDictionary[string, int])(nil)
+
+// NewSharded creates an empty ShardedDictionary.
+//
+// Parameters:
+//   - shards: The number of shards, rounded up to a power of two; zero or less means 32.
+//   - hash: The function hashing keys to pick their shard, or nil to use hash/maphash with
+//     a random seed. It must return equal hashes for equal keys.
+//
+// Returns:
+//   - A new empty ShardedDictionary.
+//
+// Example:
+//
+//	counts := NewSharded[string, int](64, nil)
+//	counts.Update(path, func(n int, _ bool) int { return n + 1 })
+func NewSharded[K comparable, V any](shards int, hash func(K) uint64) *ShardedDictionary[K, V] {
+	if shards <= 0 {
+		shards = 32
+	}
+	n := 1 << bits.Len(uint(shards-1))
+	if hash == nil {
+		seed := maphash.MakeSeed()
+		hash = func(k K) uint64 { return maphash.Comparable(seed, k) }
+	}
+	s := &ShardedDictionary[K, V]{shards: make([]shard[K, V], n), mask: uint64(n - 1), hash: hash}
+	for i := range s.shards {
+		s.shards[i].entries = dictionary.DefaultDictionary[K, V]()
+	}
+	return s
+}
+
+// shardOf returns the shard holding key.
+func (s *ShardedDictionary[K, V]) shardOf(key K) *shard[K, V] {
+	return &s.shards[s.hash(key)&s.mask]
+}
+
+// ShardCount returns the number of shards.
+func (s *ShardedDictionary[K, V]) ShardCount() int {
+	return len(s.shards)
+}
+
+// GetValue retrieves the value associated with the specified key, or the zero value if it is absent.
+func (s *ShardedDictionary[K, V]) GetValue(key K) V {
+	v, _ := s.Lookup(key)
+	return v
+}
+
+// Lookup retrieves the value associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (s *ShardedDictionary[K, V]) Lookup(key K) (V, bool) {
+	sh := s.shardOf(key)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	v, ok := sh.entries[key]
+	return v, ok
+}
+
+// ContainsKey checks if the specified key is present.
+func (s *ShardedDictionary[K, V]) ContainsKey(key K) bool {
+	_, ok := s.Lookup(key)
+	return ok
+}
+
+// SetValue sets the value for a given key.
+func (s *ShardedDictionary[K, V]) SetValue(key K, value V) {
+	sh := s.shardOf(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	s.store(sh, key, value)
+}
+
+// DeleteValue removes the specified key. If the key does not exist, nothing changes.
+func (s *ShardedDictionary[K, V]) DeleteValue(key K) {
+	s.Pop(key)
+}
+
+// GetOrSet returns the value of key if it is present, and otherwise stores value.
+//
+// Returns:
+//   - V: The existing value, or value if it was stored.
+//   - bool: True if the value was already present, false if value was stored.
+func (s *ShardedDictionary[K, V]) GetOrSet(key K, value V) (V, bool) {
+	sh := s.shardOf(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if v, ok := sh.entries[key]; ok {
+		return v, true
+	}
+	s.store(sh, key, value)
+	return value, false
+}
+
+// CompareAndSwap stores new under key only if the key is present with a value equal to
+// old, compared as dictionary.Dictionary.IsEqual compares values.
+//
+// Returns:
+//   - bool: True if the value was swapped, false otherwise.
+func (s *ShardedDictionary[K, V]) CompareAndSwap(key K, old, new V) bool {
+	sh := s.shardOf(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	v, ok := sh.entries[key]
+	if !ok || !equality.Equal(v, old) {
+		return false
+	}
+	sh.entries[key] = new
+	return true
+}
+
+// Update replaces the value for a given key with the result of fn, atomically. fn runs
+// under the lock of the key's shard, so it must not use the dictionary.
+//
+// Returns:
+//   - V: The new value.
+func (s *ShardedDictionary[K, V]) Update(key K, fn func(old V, exists bool) V) V {
+	sh := s.shardOf(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	old, ok := sh.entries[key]
+	v := fn(old, ok)
+	s.store(sh, key, v)
+	return v
+}
+
+// Pop removes the specified key and returns its value.
+//
+// Returns:
+//   - V: The removed value, or the zero value if the key was absent.
+//   - bool: True if the key was present, false otherwise.
+func (s *ShardedDictionary[K, V]) Pop(key K) (V, bool) {
+	sh := s.shardOf(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	v, ok := sh.entries[key]
+	if ok {
+		delete(sh.entries, key)
+		s.length.Add(-1)
+	}
+	return v, ok
+}
+
+// store sets key in sh, counting new keys. The caller must hold sh.mu.
+func (s *ShardedDictionary[K, V]) store(sh *shard[K, V], key K, value V) {
+	if _, ok := sh.entries[key]; !ok {
+		s.length.Add(1)
+	}
+	sh.entries[key] = value
+}
+
+// Range calls fn for each entry, one shard at a time, until fn returns false. Each shard's
+// read lock is held while its entries are visited, so fn must not modify the dictionary.
+func (s *ShardedDictionary[K, V]) Range(fn func(key K, value V) bool) {
+	for i := range s.shards {
+		if !s.rangeShard(&s.shards[i], fn) {
+			return
+		}
+	}
+}
+
+// rangeShard calls fn for the entries of sh and reports whether to continue.
+func (s *ShardedDictionary[K, V]) rangeShard(sh *shard[K, V], fn func(key K, value V) bool) bool {
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	for k, v := range sh.entries {
+		if !fn(k, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetKeys returns the keys in unspecified order.
+func (s *ShardedDictionary[K, V]) GetKeys() []K {
+	keys := make([]K, 0, max(s.GetLength(), 0))
+	s.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+// GetLength returns the number of entries without taking any lock. While writers are
+// active the result is approximate: it reflects completed operations only.
+func (s *ShardedDictionary[K, V]) GetLength() int {
+	return int(s.length.Load())
+}
+
+// CopyDictionary returns a consistent snapshot of the entries as a plain Dictionary. It
+// read-locks every shard for the duration of the copy.
+func (s *ShardedDictionary[K, V]) CopyDictionary() dictionary.Dictionary[K, V] {
+	for i := range s.shards {
+		s.shards[i].mu.RLock()
+	}
+	defer func() {
+		for i := range s.shards {
+			s.shards[i].mu.RUnlock()
+		}
+	}()
+	out := dictionary.NewDictionaryWithCapacity[K, V](int(s.length.Load()))
+	for i := range s.shards {
+		out.MergeDictionaries(s.shards[i].entries)
+	}
+	return out
+}
@@ -0,0 +1,133 @@
+package dictionary
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/bhanurp/gotypes/collection"
+)
+
+// COWDictionary is a copy-on-write Dictionary for data that is read far more often than it
+// is written, such as configuration. Readers load the current immutable snapshot with a
+// single atomic operation and never block; each write copies the entries, applies the
+// change and publishes the copy as the new snapshot. Writes therefore cost O(n), so batch
+// them with Apply. A COWDictionary is safe for concurrent use. The zero value is an empty
+// COWDictionary ready to use.
+type COWDictionary[K comparable, V any] struct {
+	mu      sync.Mutex // serializes writers
+	current atomic.Pointer[ReadOnlyDictionary[K, V]]
+}
+
+var _ collection.WritableMap[string, int] = (*COWDictionary[string, int])(nil)
+
+// NewCOWDictionary creates a COWDictionary holding a copy of the entries of d.
+//
+// Parameters:
+//   - d: The initial entries, or nil for none.
+//
+// Returns:
+//   - A new COWDictionary.
+//
+// Example:
+//
+//	flags := NewCOWDictionary(Dictionary[string, bool]{"beta": false})
+//	go reloadLoop(flags) // flags.Apply(...) on each reload
+//	if flags.GetValue("beta") { ... } // lock-free on the hot path
+func NewCOWDictionary[K comparable, V any](d Dictionary[K, V]) *COWDictionary[K, V] {
+	c := &COWDictionary[K, V]{}
+	snapshot := d.Freeze()
+	c.current.Store(&snapshot)
+	return c
+}
+
+// Snapshot returns the current entries. The snapshot never changes, so a reader that needs
+// several consistent reads should take one snapshot and read from it.
+//
+// Example:
+//
+//	s := cfg.Snapshot()
+//	host, port := s.GetValue("host"), s.GetValue("port") // from the same version
+func (c *COWDictionary[K, V]) Snapshot() ReadOnlyDictionary[K, V] {
+	if s := c.current.Load(); s != nil {
+		return *s
+	}
+	return ReadOnlyDictionary[K, V]{}
+}
+
+// GetValue retrieves the value associated with the specified key, or the zero value if it is absent.
+func (c *COWDictionary[K, V]) GetValue(key K) V {
+	return c.Snapshot().GetValue(key)
+}
+
+// Lookup retrieves the value associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (c *COWDictionary[K, V]) Lookup(key K) (V, bool) {
+	return c.Snapshot().Lookup(key)
+}
+
+// ContainsKey checks if the specified key is present.
+func (c *COWDictionary[K, V]) ContainsKey(key K) bool {
+	return c.Snapshot().ContainsKey(key)
+}
+
+// GetKeys returns the keys of the current snapshot in unspecified order.
+func (c *COWDictionary[K, V]) GetKeys() []K {
+	return c.Snapshot().GetKeys()
+}
+
+// GetLength returns the number of entries of the current snapshot.
+func (c *COWDictionary[K, V]) GetLength() int {
+	return c.Snapshot().GetLength()
+}
+
+// SetValue publishes a new snapshot in which key holds value.
+func (c *COWDictionary[K, V]) SetValue(key K, value V) {
+	c.Apply(func(d Dictionary[K, V]) { d[key] = value })
+}
+
+// DeleteValue publishes a new snapshot without key. If the key does not exist, nothing changes.
+func (c *COWDictionary[K, V]) DeleteValue(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.Snapshot().ContainsKey(key) {
+		return
+	}
+	c.publish(func(d Dictionary[K, V]) { delete(d, key) })
+}
+
+// Apply copies the entries once, lets fn modify the copy, and publishes it as the new
+// snapshot, so several changes cost a single copy and become visible together. fn must
+// not retain d or use the COWDictionary.
+//
+// Parameters:
+//   - fn: The function modifying the entries.
+//
+// Example:
+//
+//	cfg.Apply(func(d Dictionary[string, string]) {
+//		d["host"] = "db-2"
+//		d["port"] = "5433"
+//	})
+func (c *COWDictionary[K, V]) Apply(fn func(d Dictionary[K, V])) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.publish(fn)
+}
+
+// Replace publishes a copy of d as the new snapshot.
+func (c *COWDictionary[K, V]) Replace(d Dictionary[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	snapshot := d.Freeze()
+	c.current.Store(&snapshot)
+}
+
+// publish copies the current entries, applies fn and stores the result. The caller must hold c.mu.
+func (c *COWDictionary[K, V]) publish(fn func(d Dictionary[K, V])) {
+	next := c.Snapshot().ToDictionary()
+	fn(next)
+	c.current.Store(&ReadOnlyDictionary[K, V]{entries: next})
+}
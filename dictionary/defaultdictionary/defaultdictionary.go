@@ -0,0 +1,145 @@
+// Package defaultdictionary provides a Dictionary that creates missing values on first
+// access, in the manner of Python's collections.defaultdict. It lives in its own package
+// because dictionary.DefaultDictionary already names the empty-Dictionary constructor.
+package defaultdictionary
+
+import (
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/dictionary"
+)
+
+// DefaultDictionary is a Dictionary whose GetValue and Update create the value of a missing
+// key with a factory and store it, so grouping and counting need no "check, create, insert"
+// step. Lookup and ContainsKey never create values. A DefaultDictionary is not safe for
+// concurrent use.
+type DefaultDictionary[K comparable, V any] struct {
+	entries dictionary.Dictionary[K, V]
+	factory func(K) V
+}
+
+var _ collection.WritableMap[string, int] = (*DefaultDictionary[string, int])(nil)
+
+// New creates an empty DefaultDictionary that creates missing values with factory.
+//
+// Parameters:
+//   - factory: The function creating the value of a missing key; nil creates the zero value.
+//
+// Returns:
+//   - A new empty DefaultDictionary.
+//
+// Example:
+//
+//	seen := New(func(host string) *Stats { return &Stats{Host: host} })
+//	seen.GetValue("db-1").Requests++
+func New[K comparable, V any](factory func(K) V) *DefaultDictionary[K, V] {
+	if factory == nil {
+		factory = func(K) V {
+			var zero V
+			return zero
+		}
+	}
+	return &DefaultDictionary[K, V]{entries: dictionary.DefaultDictionary[K, V](), factory: factory}
+}
+
+// FromDictionary creates a DefaultDictionary holding a copy of the entries of d.
+//
+// Parameters:
+//   - d: The initial entries.
+//   - factory: The function creating the value of a missing key; nil creates the zero value.
+//
+// Returns:
+//   - A new DefaultDictionary with the same entries as d.
+func FromDictionary[K comparable, V any](d dictionary.Dictionary[K, V], factory func(K) V) *DefaultDictionary[K, V] {
+	dd := New(factory)
+	dd.entries.MergeDictionaries(d)
+	return dd
+}
+
+// GetValue retrieves the value associated with the specified key, first creating and
+// storing it with the factory if the key is absent.
+//
+// Parameters:
+//   - key: The key whose value is to be retrieved.
+//
+// Returns:
+//   - V: The stored or newly created value.
+//
+// Example:
+//
+//	sizes := New(func(string) int { return -1 })
+//	sizes.GetValue("a") // -1, and "a" is now present
+func (d *DefaultDictionary[K, V]) GetValue(key K) V {
+	v, ok := d.entries[key]
+	if !ok {
+		v = d.factory(key)
+		d.entries[key] = v
+	}
+	return v
+}
+
+// Lookup retrieves the value associated with the specified key without creating it.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (d *DefaultDictionary[K, V]) Lookup(key K) (V, bool) {
+	v, ok := d.entries[key]
+	return v, ok
+}
+
+// ContainsKey checks if the specified key is present, without creating it.
+func (d *DefaultDictionary[K, V]) ContainsKey(key K) bool {
+	return d.entries.ContainsKey(key)
+}
+
+// SetValue sets the value for a given key.
+func (d *DefaultDictionary[K, V]) SetValue(key K, value V) {
+	d.entries[key] = value
+}
+
+// Update replaces the value for a given key with the result of fn, which receives the
+// current value, or one created by the factory if the key is absent.
+//
+// Parameters:
+//   - key: The key whose value is to be updated.
+//   - fn: The function computing the new value from the old one.
+//
+// Example:
+//
+//	counts := New[string, int](nil)
+//	for _, w := range words {
+//		counts.Update(w, func(n int) int { return n + 1 })
+//	}
+//
+//	groups := New[int, []string](nil)
+//	for _, w := range words {
+//		groups.Update(len(w), func(g []string) []string { return append(g, w) })
+//	}
+func (d *DefaultDictionary[K, V]) Update(key K, fn func(old V) V) {
+	d.entries[key] = fn(d.GetValue(key))
+}
+
+// DeleteValue removes the specified key. If the key does not exist, nothing changes.
+func (d *DefaultDictionary[K, V]) DeleteValue(key K) {
+	d.entries.DeleteValue(key)
+}
+
+// GetKeys returns the keys in unspecified order.
+func (d *DefaultDictionary[K, V]) GetKeys() []K {
+	return d.entries.GetKeys()
+}
+
+// GetLength returns the number of entries.
+func (d *DefaultDictionary[K, V]) GetLength() int {
+	return d.entries.GetLength()
+}
+
+// ClearDictionary removes every entry.
+func (d *DefaultDictionary[K, V]) ClearDictionary() {
+	d.entries.ClearDictionary()
+}
+
+// ToDictionary returns a copy of the entries as a plain Dictionary.
+func (d *DefaultDictionary[K, V]) ToDictionary() dictionary.Dictionary[K, V] {
+	return d.entries.CopyDictionary()
+}
@@ -1,12 +1,17 @@
 package dictionary
 
 import (
-	"reflect"
+	"fmt"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/errs"
 )
 
 // Dictionary is a type alias for a generic map.
 type Dictionary[K comparable, V any] map[K]V
 
+var _ collection.WritableMap[string, any] = Dictionary[string, any](nil)
+
 // CreateDictionary creates a Dictionary with a single key-value pair.
 // It takes a key of any comparable type and a value of any type,
 // and returns a Dictionary containing the provided key and value.
@@ -37,6 +42,129 @@ func DefaultDictionary[K comparable, V any]() Dictionary[K, V] {
 	return Dictionary[K, V]{}
 }
 
+// NewDictionaryWithCapacity creates an empty Dictionary with room for about n entries,
+// so filling it does not repeatedly grow the underlying map.
+//
+// Parameters:
+//   - n: The expected number of entries. Zero or negative values allocate a small map.
+//
+// Returns:
+//   - A new empty Dictionary.
+//
+// Example:
+//
+//	dict := NewDictionaryWithCapacity[string, int](10000)
+func NewDictionaryWithCapacity[K comparable, V any](n int) Dictionary[K, V] {
+	return make(Dictionary[K, V], max(n, 0))
+}
+
+// FromMap creates a Dictionary holding a copy of the entries of a plain map.
+// Later changes to m are not reflected in the Dictionary, and vice versa.
+//
+// Parameters:
+//   - m: The map to be copied.
+//
+// Returns:
+//   - A new Dictionary with the entries of m, or nil if m is nil.
+//
+// Example:
+//
+//	headers := map[string]string{"Accept": "application/json"}
+//	dict := FromMap(headers)
+func FromMap[K comparable, V any](m map[K]V) Dictionary[K, V] {
+	if m == nil {
+		return nil
+	}
+	d := make(Dictionary[K, V], len(m))
+	for k, v := range m {
+		d[k] = v
+	}
+	return d
+}
+
+// capacityHint returns the capacity to allocate for n entries, raised to the optional
+// caller-supplied hint.
+func capacityHint(n int, hint []int) int {
+	if len(hint) > 0 {
+		return max(n, hint[0])
+	}
+	return n
+}
+
+// GroupBy builds a Dictionary indexing the given items by the key returned from keyFn.
+// Items sharing a key are collected into a slice in their original order.
+//
+// Parameters:
+//   - items: The items to be grouped.
+//   - keyFn: The function computing the key of each item.
+//
+// Returns:
+//   - A Dictionary mapping each key to the items that produced it.
+//
+// Example:
+//
+//	words := []string{"apple", "avocado", "banana"}
+//	dict := GroupBy(words, func(w string) byte { return w[0] })
+//	// dict is Dictionary[byte, []string]{'a': {"apple", "avocado"}, 'b': {"banana"}}
+func GroupBy[T any, K comparable](items []T, keyFn func(T) K) Dictionary[K, []T] {
+	groups := make(Dictionary[K, []T])
+	for _, item := range items {
+		k := keyFn(item)
+		groups[k] = append(groups[k], item)
+	}
+	return groups
+}
+
+// CountBy builds a Dictionary counting how many of the given items share each key returned by keyFn.
+//
+// Parameters:
+//   - items: The items to be counted.
+//   - keyFn: The function computing the key of each item.
+//
+// Returns:
+//   - A Dictionary mapping each key to the number of items that produced it.
+//
+// Example:
+//
+//	words := []string{"go", "is", "go"}
+//	counts := CountBy(words, func(w string) string { return w })
+//	// counts is Dictionary[string, int]{"go": 2, "is": 1}
+func CountBy[T any, K comparable](items []T, keyFn func(T) K) Dictionary[K, int] {
+	counts := make(Dictionary[K, int])
+	for _, item := range items {
+		counts[keyFn(item)]++
+	}
+	return counts
+}
+
+// FromZip creates a Dictionary by pairing each key with the value at the same index.
+// If a key appears more than once, the value paired with its last occurrence wins.
+//
+// Parameters:
+//   - keys: The keys of the Dictionary.
+//   - values: The values, in the same order as keys.
+//   - capacity: An optional capacity hint for Dictionaries that will keep growing; at least
+//     len(keys) is always reserved.
+//
+// Returns:
+//   - Dictionary[K, V]: A Dictionary containing the paired entries, or nil on error.
+//   - error: An error wrapping errs.ErrLengthMismatch if the slices differ in length, nil otherwise.
+//
+// Example:
+//
+//	dict, err := FromZip([]string{"one", "two"}, []int{1, 2})
+//	// dict is Dictionary[string, int]{"one": 1, "two": 2}, err is nil
+func FromZip[K comparable, V any](keys []K, values []V, capacity ...int) (Dictionary[K, V], error) {
+	if len(keys) != len(values) {
+		return nil, fmt.Errorf("zip %d keys with %d values: %w", len(keys), len(values), errs.ErrLengthMismatch)
+	}
+	d := make(Dictionary[K, V], capacityHint(len(keys), capacity))
+	for i, k := range keys {
+		d[k] = values[i]
+	}
+	return d, nil
+}
+
 // GetValue retrieves the value associated with the specified key from the Dictionary.
 //
 // Parameters:
@@ -53,8 +181,74 @@ func (d Dictionary[K, V]) GetValue(key K) V {
 	return d[key]
 }
 
+// Get retrieves the value associated with the specified key from the Dictionary.
+// Unlike GetValue, it distinguishes a missing key from a stored zero value.
+//
+// Parameters:
+//   - key: The key whose associated value is to be returned.
+//
+// Returns:
+//   - V: The value associated with the specified key, or the zero value if it is absent.
+//   - error: A *errs.KeyError wrapping errs.ErrKeyNotFound if the key is absent, nil otherwise.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1}
+//	value, err := dict.Get("one") // value will be 1, err will be nil
+//	_, err = dict.Get("two")      // errors.Is(err, errs.ErrKeyNotFound) will be true
+func (d Dictionary[K, V]) Get(key K) (V, error) {
+	v, ok := d[key]
+	if !ok {
+		return v, errs.NewKeyError("get", key, errs.ErrKeyNotFound)
+	}
+	return v, nil
+}
+
+// Lookup retrieves the value associated with the specified key, reporting whether it is present.
+//
+// Parameters:
+//   - key: The key whose associated value is to be returned.
+//
+// Returns:
+//   - V: The value associated with the specified key, or the zero value if it is absent.
+//   - bool: True if the key is present, false otherwise.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1}
+//	value, ok := dict.Lookup("one") // value will be 1, ok will be true
+func (d Dictionary[K, V]) Lookup(key K) (V, bool) {
+	v, ok := d[key]
+	return v, ok
+}
+
+// MustGet retrieves the value associated with the specified key from the Dictionary.
+// It panics if the key is absent, and is intended for tests and setup code where a
+// missing key is a programming error.
+//
+// Parameters:
+//   - key: The key whose associated value is to be returned.
+//
+// Returns:
+//   - The value associated with the specified key.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1}
+//	value := dict.MustGet("one") // value will be 1
+//	dict.MustGet("two")          // panics
+func (d Dictionary[K, V]) MustGet(key K) V {
+	v, err := d.Get(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
 // SetValue sets the value for a given key in the Dictionary.
 // If the key already exists, its value will be updated.
+// A nil Dictionary cannot hold entries, so calling SetValue on it does nothing rather
+// than panic; use SetMany to be told about the lost write.
 //
 // Parameters:
 //   - key: The key for which the value needs to be set.
@@ -70,9 +264,37 @@ func (d Dictionary[K, V]) GetValue(key K) V {
 //	keys := dict.GetKeys() // keys will be ["two"]
 //	values := dict.GetValues() // values will be [2]
 func (d Dictionary[K, V]) SetValue(key K, value V) {
+	if d == nil {
+		return
+	}
 	d[key] = value
 }
 
+// Update replaces the value for a given key with the result of fn in a single call.
+// fn receives the current value (or the zero value) and whether the key was present.
+//
+// Parameters:
+//   - key: The key whose value is to be updated.
+//   - fn: The function computing the new value from the old one.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrNilCollection if the Dictionary is nil, nil otherwise.
+//
+// Example:
+//
+//	counts := Dictionary[string, int]{}
+//	counts.Update("go", func(old int, exists bool) int { return old + 1 })
+//	counts.Update("go", func(old int, exists bool) int { return old + 1 })
+//	// counts is Dictionary[string, int]{"go": 2}
+func (d Dictionary[K, V]) Update(key K, fn func(old V, exists bool) V) error {
+	if d == nil {
+		return errs.NewKeyError("update", key, errs.ErrNilCollection)
+	}
+	old, ok := d[key]
+	d[key] = fn(old, ok)
+	return nil
+}
+
 // DeleteValue removes the value associated with the specified key from the Dictionary.
 // If the key does not exist, the Dictionary remains unchanged.
 //
@@ -88,6 +310,153 @@ func (d Dictionary[K, V]) DeleteValue(key K) {
 	delete(d, key)
 }
 
+// Pop removes the specified key from the Dictionary and returns the value it held.
+//
+// Parameters:
+//   - key: The key to be removed.
+//
+// Returns:
+//   - V: The value that was associated with the key, or the zero value if it was absent.
+//   - error: A *errs.KeyError wrapping errs.ErrKeyNotFound if the key was absent, nil otherwise.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "two": 2}
+//	value, err := dict.Pop("one") // value will be 1, err will be nil
+//	// dict is now Dictionary[string, int]{"two": 2}
+func (d Dictionary[K, V]) Pop(key K) (V, error) {
+	v, ok := d[key]
+	if !ok {
+		return v, errs.NewKeyError("pop", key, errs.ErrKeyNotFound)
+	}
+	delete(d, key)
+	return v, nil
+}
+
+// MustPop removes the specified key from the Dictionary and returns the value it held.
+// It panics if the key is absent, and is intended for tests and setup code.
+//
+// Parameters:
+//   - key: The key to be removed.
+//
+// Returns:
+//   - The value that was associated with the key.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1}
+//	value := dict.MustPop("one") // value will be 1
+//	dict.MustPop("one")          // panics
+func (d Dictionary[K, V]) MustPop(key K) V {
+	v, err := d.Pop(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// RenameKey moves the value stored under oldKey to newKey.
+// Renaming a key to itself is a no-op.
+//
+// Parameters:
+//   - oldKey: The key whose value is to be moved.
+//   - newKey: The key the value is to be stored under.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrKeyNotFound if oldKey is absent, or
+//     errs.ErrDuplicateKey if newKey is already present, nil otherwise.
+//
+// Example:
+//
+//	config := Dictionary[string, string]{"db_host": "localhost"}
+//	err := config.RenameKey("db_host", "database.host")
+//	// config is Dictionary[string, string]{"database.host": "localhost"}
+func (d Dictionary[K, V]) RenameKey(oldKey, newKey K) error {
+	if oldKey == newKey {
+		if _, ok := d[oldKey]; !ok {
+			return errs.NewKeyError("rename", oldKey, errs.ErrKeyNotFound)
+		}
+		return nil
+	}
+	if _, ok := d[newKey]; ok {
+		return errs.NewKeyError("rename", newKey, errs.ErrDuplicateKey)
+	}
+	return d.ForceRenameKey(oldKey, newKey)
+}
+
+// ForceRenameKey moves the value stored under oldKey to newKey, overwriting any value
+// newKey already holds.
+//
+// Parameters:
+//   - oldKey: The key whose value is to be moved.
+//   - newKey: The key the value is to be stored under.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrKeyNotFound if oldKey is absent, nil otherwise.
+//
+// Example:
+//
+//	config := Dictionary[string, int]{"timeout": 30, "timeout_s": 10}
+//	err := config.ForceRenameKey("timeout", "timeout_s")
+//	// config is Dictionary[string, int]{"timeout_s": 30}
+func (d Dictionary[K, V]) ForceRenameKey(oldKey, newKey K) error {
+	v, ok := d[oldKey]
+	if !ok {
+		return errs.NewKeyError("rename", oldKey, errs.ErrKeyNotFound)
+	}
+	delete(d, oldKey)
+	d[newKey] = v
+	return nil
+}
+
+// RemapKeys renames several keys at once, as if every rename happened simultaneously, so
+// keys can be swapped or shifted. Keys in mapping that are absent from the Dictionary are
+// ignored. Nothing is changed if a collision is found.
+//
+// Parameters:
+//   - mapping: The new key for each key to be renamed.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrDuplicateKey naming the first target key that
+//     two keys would be renamed to, or that is held by a key that is not renamed, nil otherwise.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"a": 1, "b": 2}
+//	err := dict.RemapKeys(Dictionary[string, string]{"a": "b", "b": "a"})
+//	// dict is Dictionary[string, int]{"a": 2, "b": 1}
+func (d Dictionary[K, V]) RemapKeys(mapping Dictionary[K, K]) error {
+	moved := make([]Entry[K, V], 0, min(len(mapping), len(d)))
+	targets := make(Dictionary[K, struct{}], len(moved))
+	for from, to := range mapping {
+		v, ok := d[from]
+		if !ok {
+			continue
+		}
+		if _, dup := targets[to]; dup {
+			return errs.NewKeyError("remap", to, errs.ErrDuplicateKey)
+		}
+		targets[to] = struct{}{}
+		moved = append(moved, Entry[K, V]{Key: to, Value: v})
+	}
+	for to := range targets {
+		if _, held := d[to]; !held {
+			continue
+		}
+		// An occupied target is only free if its current key is renamed as well.
+		if _, renamed := mapping[to]; !renamed {
+			return errs.NewKeyError("remap", to, errs.ErrDuplicateKey)
+		}
+	}
+	for from := range mapping {
+		delete(d, from)
+	}
+	for _, e := range moved {
+		d[e.Key] = e.Value
+	}
+	return nil
+}
+
 // GetKeys returns a slice containing all the keys present in the Dictionary.
 // It iterates over the Dictionary and collects each key into a slice, which is then returned.
 //
@@ -141,7 +510,8 @@ func (d Dictionary[K, V]) GetLength() int {
 // If there are duplicate keys, the values from the other Dictionary will overwrite the current values.
 //
 // Parameters:
-//   - d2: The Dictionary to be merged into the current Dictionary.
+//   - d2: The Dictionary to be merged into the current Dictionary. Merging into a nil
+//     Dictionary does nothing, as with SetValue.
 //
 // Example:
 //
@@ -150,9 +520,92 @@ func (d Dictionary[K, V]) GetLength() int {
 //	dict1.MergeDictionaries(dict2)
 //	// dict1 is now Dictionary[string, int]{"one": 1, "two": 2, "three": 3, "four": 4}
 func (d Dictionary[K, V]) MergeDictionaries(d2 Dictionary[K, V]) {
+	if d == nil {
+		return
+	}
+	for k, v := range d2 {
+		d[k] = v
+	}
+}
+
+// MergeDictionariesWith merges another Dictionary into the current Dictionary,
+// calling resolve to decide the value of every key present in both.
+//
+// Parameters:
+//   - d2: The Dictionary to be merged into the current Dictionary.
+//   - resolve: The function returning the value to keep for a colliding key,
+//     given the current value (old) and the incoming value (new).
+//
+// Returns:
+//   - error: errs.ErrNilCollection if the current Dictionary is nil and d2 is not empty, nil otherwise.
+//
+// Example:
+//
+//	dict1 := Dictionary[string, int]{"one": 1, "two": 2}
+//	dict2 := Dictionary[string, int]{"two": 20, "three": 3}
+//	dict1.MergeDictionariesWith(dict2, func(k string, old, new int) int { return old + new })
+//	// dict1 is now Dictionary[string, int]{"one": 1, "two": 22, "three": 3}
+func (d Dictionary[K, V]) MergeDictionariesWith(d2 Dictionary[K, V], resolve func(k K, old, new V) V) error {
+	if d == nil && len(d2) > 0 {
+		return errs.ErrNilCollection
+	}
 	for k, v := range d2 {
+		if old, ok := d[k]; ok {
+			v = resolve(k, old, v)
+		}
 		d[k] = v
 	}
+	return nil
+}
+
+// Merged returns a new Dictionary holding the union of the current Dictionary and d2.
+// If there are duplicate keys, the values from d2 win. Neither input is modified.
+//
+// Parameters:
+//   - d2: The Dictionary to be merged with the current Dictionary.
+//
+// Returns:
+//   - Dictionary[K, V]: A new Dictionary containing the entries of both Dictionaries.
+//
+// Example:
+//
+//	dict1 := Dictionary[string, int]{"one": 1, "two": 2}
+//	dict2 := Dictionary[string, int]{"two": 20, "three": 3}
+//	merged := dict1.Merged(dict2)
+//	// merged is Dictionary[string, int]{"one": 1, "two": 20, "three": 3}
+//	// dict1 and dict2 are unchanged
+func (d Dictionary[K, V]) Merged(d2 Dictionary[K, V]) Dictionary[K, V] {
+	merged := make(Dictionary[K, V], max(len(d), len(d2)))
+	for k, v := range d {
+		merged[k] = v
+	}
+	for k, v := range d2 {
+		merged[k] = v
+	}
+	return merged
+}
+
+// MergedWith returns a new Dictionary holding the union of the current Dictionary and d2,
+// calling resolve to decide the value of every key present in both. Neither input is modified.
+//
+// Parameters:
+//   - d2: The Dictionary to be merged with the current Dictionary.
+//   - resolve: The function returning the value to keep for a colliding key,
+//     given the value from the current Dictionary (old) and the value from d2 (new).
+//
+// Returns:
+//   - Dictionary[K, V]: A new Dictionary containing the entries of both Dictionaries.
+//
+// Example:
+//
+//	dict1 := Dictionary[string, int]{"one": 1, "two": 2}
+//	dict2 := Dictionary[string, int]{"two": 20, "three": 3}
+//	merged := dict1.MergedWith(dict2, func(k string, old, new int) int { return old + new })
+//	// merged is Dictionary[string, int]{"one": 1, "two": 22, "three": 3}
+func (d Dictionary[K, V]) MergedWith(d2 Dictionary[K, V], resolve func(k K, old, new V) V) Dictionary[K, V] {
+	merged := d.CopyDictionary()
+	merged.MergeDictionariesWith(d2, resolve)
+	return merged
 }
 
 // CopyDictionary returns a copy of the current Dictionary.
@@ -173,6 +626,37 @@ func (d Dictionary[K, V]) CopyDictionary() Dictionary[K, V] {
 	return copy
 }
 
+// ToMap returns a copy of the Dictionary as a plain map, for APIs that require one.
+// Later changes to the copy are not reflected in the Dictionary, and vice versa.
+//
+// Returns:
+//   - map[K]V: A new map with the entries of the Dictionary, or nil if it is nil.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1}
+//	m := dict.ToMap()
+//	m["two"] = 2 // dict still has one entry
+func (d Dictionary[K, V]) ToMap() map[K]V {
+	return map[K]V(FromMap(map[K]V(d)))
+}
+
+// AsMap returns the Dictionary as a plain map without copying.
+// The map and the Dictionary share their storage, so changes through either are visible
+// through both. Use ToMap when the receiver of the map may modify it.
+//
+// Returns:
+//   - map[K]V: The Dictionary viewed as a map.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1}
+//	m := dict.AsMap()
+//	m["two"] = 2 // dict now has two entries
+func (d Dictionary[K, V]) AsMap() map[K]V {
+	return map[K]V(d)
+}
+
 // ContainsKey checks if the Dictionary contains the specified key.
 //
 // Parameters:
@@ -191,6 +675,7 @@ func (d Dictionary[K, V]) ContainsKey(key K) bool {
 }
 
 // ContainsValue checks if the Dictionary contains the specified value.
+// Values are compared deeply with equality.Equal.
 //
 // Parameters:
 //   - value: The value to be checked.
@@ -203,14 +688,39 @@ func (d Dictionary[K, V]) ContainsKey(key K) bool {
 //	dict := Dictionary[string, int]{"one": 1, "two": 2}
 //	contains := dict.ContainsValue(1) // contains will be true
 func (d Dictionary[K, V]) ContainsValue(value V) bool {
+	eq := valuesEqual[V]()
 	for _, v := range d {
-		if reflect.DeepEqual(v, value) {
+		if eq(v, value) {
 			return true
 		}
 	}
 	return false
 }
 
+// KeysOf returns a slice containing every key whose value equals the specified value.
+// Values are compared with the same semantics as ContainsValue.
+//
+// Parameters:
+//   - value: The value to look up.
+//
+// Returns:
+//   - []K: A slice of keys mapping to the value, empty if there are none.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "uno": 1, "two": 2}
+//	keys := dict.KeysOf(1) // keys will be ["one", "uno"]
+func (d Dictionary[K, V]) KeysOf(value V) []K {
+	keys := make([]K, 0)
+	eq := valuesEqual[V]()
+	for k, v := range d {
+		if eq(v, value) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
 // ClearDictionary removes all key-value pairs from the Dictionary.
 //
 // Example:
@@ -238,7 +748,8 @@ func (d Dictionary[K, V]) IsEmpty() bool {
 }
 
 // IsEqual checks if the current Dictionary is equal to another Dictionary.
-// Two Dictionaries are considered equal if they have the same key-value pairs.
+// Two Dictionaries are considered equal if they have the same key-value pairs,
+// with values compared deeply by equality.Equal.
 //
 // Parameters:
 //   - d2: The Dictionary to be compared with.
@@ -255,8 +766,9 @@ func (d Dictionary[K, V]) IsEqual(d2 Dictionary[K, V]) bool {
 	if len(d) != len(d2) {
 		return false
 	}
+	eq := valuesEqual[V]()
 	for k, v := range d {
-		if !reflect.DeepEqual(v, d2[k]) {
+		if v2, ok := d2[k]; !ok || !eq(v, v2) {
 			return false
 		}
 	}
@@ -281,8 +793,9 @@ func (d Dictionary[K, V]) IsSubset(d2 Dictionary[K, V]) bool {
 	if len(d) > len(d2) {
 		return false
 	}
+	eq := valuesEqual[V]()
 	for k, v := range d {
-		if !reflect.DeepEqual(v, d2[k]) {
+		if v2, ok := d2[k]; !ok || !eq(v, v2) {
 			return false
 		}
 	}
@@ -307,8 +820,9 @@ func (d Dictionary[K, V]) IsSuperset(d2 Dictionary[K, V]) bool {
 	if len(d) < len(d2) {
 		return false
 	}
+	eq := valuesEqual[V]()
 	for k, v := range d2 {
-		if !reflect.DeepEqual(v, d[k]) {
+		if v1, ok := d[k]; !ok || !eq(v1, v) {
 			return false
 		}
 	}
@@ -337,3 +851,207 @@ func (d Dictionary[K, V]) IsDisjoint(d2 Dictionary[K, V]) bool {
 	}
 	return true
 }
+
+// Partition splits the Dictionary into two new Dictionaries in a single pass.
+// Entries for which pred returns true go to the first Dictionary, all others to the second.
+// The current Dictionary is left unchanged.
+//
+// Parameters:
+//   - pred: The predicate deciding which half an entry belongs to.
+//
+// Returns:
+//   - Dictionary[K, V]: The entries matching the predicate.
+//   - Dictionary[K, V]: The entries not matching the predicate.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "two": 2, "three": 3}
+//	odd, even := dict.Partition(func(k string, v int) bool { return v%2 == 1 })
+//	// odd is Dictionary[string, int]{"one": 1, "three": 3}
+//	// even is Dictionary[string, int]{"two": 2}
+func (d Dictionary[K, V]) Partition(pred func(K, V) bool) (Dictionary[K, V], Dictionary[K, V]) {
+	matched := make(Dictionary[K, V])
+	rest := make(Dictionary[K, V])
+	for k, v := range d {
+		if pred(k, v) {
+			matched[k] = v
+		} else {
+			rest[k] = v
+		}
+	}
+	return matched, rest
+}
+
+// Chunk splits the Dictionary into new Dictionaries of at most size entries each.
+// Every chunk except possibly the last holds exactly size entries. Which entries land in
+// which chunk follows map iteration order and is therefore unspecified.
+//
+// Parameters:
+//   - size: The maximum number of entries per chunk.
+//
+// Returns:
+//   - []Dictionary[K, V]: The chunks, or nil if the Dictionary is empty or size is not positive.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "two": 2, "three": 3}
+//	chunks := dict.Chunk(2) // chunks will hold two Dictionaries, of 2 and 1 entries
+func (d Dictionary[K, V]) Chunk(size int) []Dictionary[K, V] {
+	if size <= 0 || len(d) == 0 {
+		return nil
+	}
+	chunks := make([]Dictionary[K, V], 0, (len(d)+size-1)/size)
+	var current Dictionary[K, V]
+	remaining := len(d)
+	for k, v := range d {
+		if current == nil {
+			current = make(Dictionary[K, V], min(size, remaining))
+		}
+		current[k] = v
+		remaining--
+		if len(current) == size {
+			chunks = append(chunks, current)
+			current = nil
+		}
+	}
+	if current != nil {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// Intersect returns a new Dictionary containing the entries of the current Dictionary
+// whose keys are also present in d2. Values are taken from the current Dictionary.
+//
+// Parameters:
+//   - d2: The Dictionary whose keys are intersected with.
+//
+// Returns:
+//   - Dictionary[K, V]: A new Dictionary with the keys common to both Dictionaries.
+//
+// Example:
+//
+//	dict1 := Dictionary[string, int]{"one": 1, "two": 2}
+//	dict2 := Dictionary[string, int]{"two": 20, "three": 3}
+//	common := dict1.Intersect(dict2)
+//	// common is Dictionary[string, int]{"two": 2}
+func (d Dictionary[K, V]) Intersect(d2 Dictionary[K, V]) Dictionary[K, V] {
+	result := make(Dictionary[K, V])
+	for k, v := range d {
+		if _, ok := d2[k]; ok {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// Difference returns a new Dictionary containing the entries of the current Dictionary
+// whose keys are not present in d2.
+//
+// Parameters:
+//   - d2: The Dictionary whose keys are excluded.
+//
+// Returns:
+//   - Dictionary[K, V]: A new Dictionary with the keys only found in the current Dictionary.
+//
+// Example:
+//
+//	dict1 := Dictionary[string, int]{"one": 1, "two": 2}
+//	dict2 := Dictionary[string, int]{"two": 20, "three": 3}
+//	diff := dict1.Difference(dict2)
+//	// diff is Dictionary[string, int]{"one": 1}
+func (d Dictionary[K, V]) Difference(d2 Dictionary[K, V]) Dictionary[K, V] {
+	result := make(Dictionary[K, V])
+	for k, v := range d {
+		if _, ok := d2[k]; !ok {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// SymmetricDifference returns a new Dictionary containing the entries whose keys are
+// present in exactly one of the current Dictionary and d2.
+//
+// Parameters:
+//   - d2: The Dictionary to be compared with.
+//
+// Returns:
+//   - Dictionary[K, V]: A new Dictionary with the keys found in only one of the Dictionaries.
+//
+// Example:
+//
+//	dict1 := Dictionary[string, int]{"one": 1, "two": 2}
+//	dict2 := Dictionary[string, int]{"two": 20, "three": 3}
+//	diff := dict1.SymmetricDifference(dict2)
+//	// diff is Dictionary[string, int]{"one": 1, "three": 3}
+func (d Dictionary[K, V]) SymmetricDifference(d2 Dictionary[K, V]) Dictionary[K, V] {
+	result := d.Difference(d2)
+	for k, v := range d2 {
+		if _, ok := d[k]; !ok {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// Any checks if at least one entry of the Dictionary satisfies the predicate.
+// It stops at the first matching entry.
+//
+// Parameters:
+//   - pred: The predicate applied to each entry.
+//
+// Returns:
+//   - bool: True if any entry satisfies pred, false otherwise (including when the Dictionary is empty).
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "two": 2}
+//	any := dict.Any(func(k string, v int) bool { return v > 1 }) // any will be true
+func (d Dictionary[K, V]) Any(pred func(K, V) bool) bool {
+	for k, v := range d {
+		if pred(k, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// All checks if every entry of the Dictionary satisfies the predicate.
+// It stops at the first entry that does not.
+//
+// Parameters:
+//   - pred: The predicate applied to each entry.
+//
+// Returns:
+//   - bool: True if every entry satisfies pred (including when the Dictionary is empty), false otherwise.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "two": 2}
+//	all := dict.All(func(k string, v int) bool { return v > 0 }) // all will be true
+func (d Dictionary[K, V]) All(pred func(K, V) bool) bool {
+	for k, v := range d {
+		if !pred(k, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// None checks if no entry of the Dictionary satisfies the predicate.
+// It stops at the first matching entry.
+//
+// Parameters:
+//   - pred: The predicate applied to each entry.
+//
+// Returns:
+//   - bool: True if no entry satisfies pred (including when the Dictionary is empty), false otherwise.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "two": 2}
+//	none := dict.None(func(k string, v int) bool { return v < 0 }) // none will be true
+func (d Dictionary[K, V]) None(pred func(K, V) bool) bool {
+	return !d.Any(pred)
+}
@@ -0,0 +1,221 @@
+package dictionary
+
+import (
+	"fmt"
+
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// Change records the old and new value of a key whose value was modified.
+type Change[V any] struct {
+	Old V `json:"old"`
+	New V `json:"new"`
+}
+
+// Changeset describes how one Dictionary differs from another: the entries that were
+// added, the entries that were removed, and the keys whose value was modified. Its fields
+// are exported so a Changeset can be encoded and shipped like any other value. Modified is
+// a plain map because a Dictionary method cannot instantiate Dictionary with Change[V].
+type Changeset[K comparable, V any] struct {
+	Added    Dictionary[K, V] `json:"added"`
+	Removed  Dictionary[K, V] `json:"removed"`
+	Modified map[K]Change[V]  `json:"modified"`
+}
+
+// IsEmpty checks if the Changeset records no changes.
+func (cs Changeset[K, V]) IsEmpty() bool {
+	return cs.GetLength() == 0
+}
+
+// GetLength returns the number of changed keys.
+func (cs Changeset[K, V]) GetLength() int {
+	return len(cs.Added) + len(cs.Removed) + len(cs.Modified)
+}
+
+// Inverse returns the Changeset that undoes cs: additions become removals, removals
+// become additions, and every modification is swapped.
+//
+// Example:
+//
+//	cs := before.Diff(after)
+//	undo := cs.Inverse() // after.Diff(before) has the same changes
+func (cs Changeset[K, V]) Inverse() Changeset[K, V] {
+	inv := Changeset[K, V]{
+		Added:    cs.Removed.CopyDictionary(),
+		Removed:  cs.Added.CopyDictionary(),
+		Modified: make(map[K]Change[V], len(cs.Modified)),
+	}
+	for k, c := range cs.Modified {
+		inv.Modified[k] = Change[V]{Old: c.New, New: c.Old}
+	}
+	return inv
+}
+
+// Diff compares the current Dictionary with d2 and reports the changes that turn the
+// current Dictionary into d2. Values are compared as IsEqual compares them.
+//
+// Parameters:
+//   - d2: The Dictionary to be compared with.
+//
+// Returns:
+//   - Changeset[K, V]: The entries only in d2 as Added, the entries only in the current
+//     Dictionary as Removed, and the keys in both with different values as Modified. All
+//     three Dictionaries are non-nil.
+//
+// Example:
+//
+//	before := Dictionary[string, int]{"one": 1, "two": 2}
+//	after := Dictionary[string, int]{"two": 20, "three": 3}
+//	cs := before.Diff(after)
+//	// cs.Added is {"three": 3}
+//	// cs.Removed is {"one": 1}
+//	// cs.Modified is {"two": {Old: 2, New: 20}}
+func (d Dictionary[K, V]) Diff(d2 Dictionary[K, V]) Changeset[K, V] {
+	return d.DiffFunc(d2, valuesEqual[V]())
+}
+
+// DiffFunc is like Diff but compares values with eq.
+//
+// Parameters:
+//   - d2: The Dictionary to be compared with.
+//   - eq: The function reporting whether two values are equal.
+//
+// Returns:
+//   - Changeset[K, V]: The changes that turn the current Dictionary into d2.
+//
+// Example:
+//
+//	cs := before.DiffFunc(after, func(a, b float64) bool {
+//		return math.Abs(a-b) < 1e-9
+//	})
+func (d Dictionary[K, V]) DiffFunc(d2 Dictionary[K, V], eq func(a, b V) bool) Changeset[K, V] {
+	cs := Changeset[K, V]{
+		Added:    make(Dictionary[K, V]),
+		Removed:  make(Dictionary[K, V]),
+		Modified: make(map[K]Change[V]),
+	}
+	for k, v := range d {
+		v2, ok := d2[k]
+		switch {
+		case !ok:
+			cs.Removed[k] = v
+		case !eq(v, v2):
+			cs.Modified[k] = Change[V]{Old: v, New: v2}
+		}
+	}
+	for k, v := range d2 {
+		if _, ok := d[k]; !ok {
+			cs.Added[k] = v
+		}
+	}
+	return cs
+}
+
+// ApplyPatch applies cs to the current Dictionary, turning the Dictionary a Changeset was
+// computed from into the one it was compared with. Before anything is changed, ApplyPatch
+// checks that the Dictionary is in the state cs expects: added keys are absent, and removed
+// and modified keys hold the recorded old values, compared as IsEqual compares them. If any
+// check fails the Dictionary is left unchanged, so a replica that has drifted is detected
+// instead of silently corrupted.
+//
+// Parameters:
+//   - cs: The Changeset to be applied.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrDuplicateKey for an added key that is already
+//     present, errs.ErrKeyNotFound for a removed or modified key that is absent,
+//     errs.ErrVersionConflict for a key whose value differs from the recorded old value, or
+//     errs.ErrNilCollection if the Dictionary is nil and cs adds entries; nil otherwise.
+//
+// Example:
+//
+//	replica := Dictionary[string, int]{"one": 1, "two": 2}
+//	cs := Dictionary[string, int]{"one": 1, "two": 2}.Diff(Dictionary[string, int]{"two": 20})
+//	err := replica.ApplyPatch(cs)
+//	// replica is Dictionary[string, int]{"two": 20}
+func (d Dictionary[K, V]) ApplyPatch(cs Changeset[K, V]) error {
+	eq := valuesEqual[V]()
+	for k := range cs.Added {
+		if _, ok := d[k]; ok {
+			return errs.NewKeyError("patch", k, errs.ErrDuplicateKey)
+		}
+	}
+	for k, old := range cs.Removed {
+		if err := d.expect(k, old, eq); err != nil {
+			return err
+		}
+	}
+	for k, c := range cs.Modified {
+		if err := d.expect(k, c.Old, eq); err != nil {
+			return err
+		}
+	}
+	return d.ForceApplyPatch(cs)
+}
+
+// ForceApplyPatch applies cs to the current Dictionary without checking its state: removed
+// keys are deleted, and added and modified keys are set to their new values whatever they
+// held before.
+//
+// Parameters:
+//   - cs: The Changeset to be applied.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrNilCollection if the Dictionary is nil and cs
+//     sets any value, nil otherwise.
+//
+// Example:
+//
+//	replica := Dictionary[string, int]{"two": 5}
+//	err := replica.ForceApplyPatch(cs) // replica is Dictionary[string, int]{"two": 20}
+func (d Dictionary[K, V]) ForceApplyPatch(cs Changeset[K, V]) error {
+	if d == nil {
+		for k := range cs.Added {
+			return errs.NewKeyError("patch", k, errs.ErrNilCollection)
+		}
+		for k := range cs.Modified {
+			return errs.NewKeyError("patch", k, errs.ErrNilCollection)
+		}
+		return nil
+	}
+	for k := range cs.Removed {
+		delete(d, k)
+	}
+	for k, v := range cs.Added {
+		d[k] = v
+	}
+	for k, c := range cs.Modified {
+		d[k] = c.New
+	}
+	return nil
+}
+
+// RevertPatch undoes cs on a Dictionary it was applied to. It is ApplyPatch of cs.Inverse(),
+// with the same checks.
+//
+// Parameters:
+//   - cs: The Changeset to be reverted.
+//
+// Returns:
+//   - error: An error as described for ApplyPatch if the Dictionary is not in the state
+//     cs leaves it in, nil otherwise.
+//
+// Example:
+//
+//	err := replica.ApplyPatch(cs)
+//	err = replica.RevertPatch(cs) // replica is back to its previous state
+func (d Dictionary[K, V]) RevertPatch(cs Changeset[K, V]) error {
+	return d.ApplyPatch(cs.Inverse())
+}
+
+// expect checks that key holds want.
+func (d Dictionary[K, V]) expect(key K, want V, eq func(a, b V) bool) error {
+	v, ok := d[key]
+	if !ok {
+		return errs.NewKeyError("patch", key, errs.ErrKeyNotFound)
+	}
+	if !eq(v, want) {
+		return errs.NewKeyError("patch", key, fmt.Errorf("value differs from the changeset: %w", errs.ErrVersionConflict))
+	}
+	return nil
+}
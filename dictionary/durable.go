@@ -0,0 +1,276 @@
+package dictionary
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// PersistentDictionary is a Dictionary whose mutations are written through to a Backend,
+// so its contents survive restarts. Reads are served from an in-memory copy loaded when
+// the dictionary is opened; a write changes that copy only once the backend has accepted
+// it. Keys are encoded as by MarshalJSON and values as JSON.
+//
+// It is unrelated to the persistent package, whose dictionaries are immutable rather than
+// durable. A PersistentDictionary is safe for concurrent use.
+type PersistentDictionary[K comparable, V any] struct {
+	mu      sync.RWMutex
+	entries Dictionary[K, V]
+	backend Backend
+	closed  bool
+}
+
+var _ collection.Map[string, int] = (*PersistentDictionary[string, int])(nil)
+
+// OpenPersistentDictionary loads every entry of backend into a new PersistentDictionary.
+//
+// Parameters:
+//   - backend: The storage to load from and write through to.
+//
+// Returns:
+//   - *PersistentDictionary[K, V]: The dictionary holding the backend's entries.
+//   - error: An error if the backend cannot be scanned or holds an entry that does not
+//     decode into K and V.
+//
+// Example:
+//
+//	backend, _ := NewDirBackend("/var/lib/app/flags")
+//	flags, err := OpenPersistentDictionary[string, bool](backend)
+//	if err != nil {
+//		return err
+//	}
+//	defer flags.Close()
+//	err = flags.SetValue("dark-mode", true) // survives a restart
+func OpenPersistentDictionary[K comparable, V any](backend Backend) (*PersistentDictionary[K, V], error) {
+	d := &PersistentDictionary[K, V]{backend: backend}
+	if err := d.Reload(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Reload replaces the in-memory copy with the current contents of the backend, picking
+// up changes made to it by others. On error the dictionary is left unchanged.
+//
+// Returns:
+//   - error: errs.ErrClosed after Close, or an error if the backend cannot be scanned or
+//     holds an entry that does not decode.
+func (d *PersistentDictionary[K, V]) Reload() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return fmt.Errorf("dictionary: reload: %w", errs.ErrClosed)
+	}
+	entries := DefaultDictionary[K, V]()
+	err := d.backend.Scan(func(name string, data []byte) error {
+		key, value, err := decodeEntry[K, V](name, data)
+		if err != nil {
+			return err
+		}
+		entries[key] = value
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("dictionary: reload: %w", err)
+	}
+	d.entries = entries
+	return nil
+}
+
+// Refresh re-reads key from the backend, picking up a change made to it by others.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrClosed after Close, or the error of the
+//     backend or of decoding; the entry is left unchanged on error.
+func (d *PersistentDictionary[K, V]) Refresh(key K) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return errs.NewKeyError("refresh", key, errs.ErrClosed)
+	}
+	name, err := encodeKey(key)
+	if err != nil {
+		return errs.NewKeyError("refresh", key, err)
+	}
+	data, ok, err := d.backend.Get(name)
+	if err != nil {
+		return errs.NewKeyError("refresh", key, err)
+	}
+	if !ok {
+		delete(d.entries, key)
+		return nil
+	}
+	_, value, err := decodeEntry[K, V](name, data)
+	if err != nil {
+		return errs.NewKeyError("refresh", key, err)
+	}
+	d.entries[key] = value
+	return nil
+}
+
+// GetValue retrieves the value associated with the specified key, or the zero value if it
+// is absent.
+func (d *PersistentDictionary[K, V]) GetValue(key K) V {
+	v, _ := d.Lookup(key)
+	return v
+}
+
+// Lookup retrieves the value associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (d *PersistentDictionary[K, V]) Lookup(key K) (V, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	v, ok := d.entries[key]
+	return v, ok
+}
+
+// ContainsKey checks if the specified key is present.
+func (d *PersistentDictionary[K, V]) ContainsKey(key K) bool {
+	_, ok := d.Lookup(key)
+	return ok
+}
+
+// SetValue stores value under key in the backend, then in memory.
+//
+// Parameters:
+//   - key: The key for which the value needs to be set.
+//   - value: The value to be set for the given key.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrClosed after Close, or the error of encoding
+//     or of the backend; the dictionary is left unchanged on error.
+func (d *PersistentDictionary[K, V]) SetValue(key K, value V) error {
+	name, err := encodeKey(key)
+	if err != nil {
+		return errs.NewKeyError("set", key, err)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return errs.NewKeyError("set", key, fmt.Errorf("dictionary: %w", err))
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return errs.NewKeyError("set", key, errs.ErrClosed)
+	}
+	if err := d.backend.Put(name, data); err != nil {
+		return errs.NewKeyError("set", key, err)
+	}
+	d.entries[key] = value
+	return nil
+}
+
+// DeleteValue removes key from the backend, then from memory. Deleting an absent key is
+// not an error.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrClosed after Close, or the error of the
+//     backend; the dictionary is left unchanged on error.
+func (d *PersistentDictionary[K, V]) DeleteValue(key K) error {
+	name, err := encodeKey(key)
+	if err != nil {
+		return errs.NewKeyError("delete", key, err)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return errs.NewKeyError("delete", key, errs.ErrClosed)
+	}
+	if err := d.backend.Delete(name); err != nil {
+		return errs.NewKeyError("delete", key, err)
+	}
+	delete(d.entries, key)
+	return nil
+}
+
+// ClearDictionary removes every key from the backend and from memory. Every key is
+// encoded before any is deleted, so an encoding error leaves the dictionary unchanged.
+// The clear is not atomic, however: if the backend fails part way, the keys deleted so
+// far stay deleted, both in the backend and in memory, and the others remain.
+//
+// Returns:
+//   - error: errs.ErrClosed after Close, or a *errs.KeyError with the error of encoding
+//     a key or with the error of the backend for the first key it failed to delete.
+func (d *PersistentDictionary[K, V]) ClearDictionary() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return fmt.Errorf("dictionary: clear: %w", errs.ErrClosed)
+	}
+	names := NewDictionaryWithCapacity[K, string](len(d.entries))
+	for key := range d.entries {
+		name, err := encodeKey(key)
+		if err != nil {
+			return errs.NewKeyError("clear", key, err)
+		}
+		names[key] = name
+	}
+	for key, name := range names {
+		if err := d.backend.Delete(name); err != nil {
+			return errs.NewKeyError("clear", key, err)
+		}
+		delete(d.entries, key)
+	}
+	return nil
+}
+
+// GetKeys returns the keys in unspecified order.
+func (d *PersistentDictionary[K, V]) GetKeys() []K {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.entries.GetKeys()
+}
+
+// GetLength returns the number of keys.
+func (d *PersistentDictionary[K, V]) GetLength() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.entries)
+}
+
+// ToDictionary returns a copy of the entries as a plain Dictionary.
+func (d *PersistentDictionary[K, V]) ToDictionary() Dictionary[K, V] {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.entries.CopyDictionary()
+}
+
+// Close stops writes to the backend and closes it if it implements io.Closer. Reads keep
+// being served from memory. Calling Close more than once is harmless.
+//
+// Returns:
+//   - error: The error of closing the backend, if any.
+func (d *PersistentDictionary[K, V]) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	if c, ok := d.backend.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// decodeEntry decodes an entry stored in a Backend.
+func decodeEntry[K comparable, V any](name string, data []byte) (K, V, error) {
+	var (
+		key   K
+		value V
+	)
+	if err := decodeKey(name, &key); err != nil {
+		return key, value, err
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return key, value, fmt.Errorf("dictionary: unmarshal value for key %q: %w", name, err)
+	}
+	return key, value, nil
+}
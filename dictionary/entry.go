@@ -0,0 +1,48 @@
+package dictionary
+
+// Entry is a single key-value pair of a Dictionary.
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// FromPairs creates a Dictionary from a slice of entries.
+// If the same key appears more than once, the last entry wins.
+//
+// Parameters:
+//   - pairs: The entries to be inserted into the Dictionary.
+//   - capacity: An optional capacity hint for Dictionaries that will keep growing; at least
+//     len(pairs) is always reserved.
+//
+// Returns:
+//   - A Dictionary containing the provided entries.
+//
+// Example:
+//
+//	dict := FromPairs([]Entry[string, int]{{"one", 1}, {"two", 2}})
+//	// dict is Dictionary[string, int]{"one": 1, "two": 2}
+func FromPairs[K comparable, V any](pairs []Entry[K, V], capacity ...int) Dictionary[K, V] {
+	d := make(Dictionary[K, V], capacityHint(len(pairs), capacity))
+	for _, p := range pairs {
+		d[p.Key] = p.Value
+	}
+	return d
+}
+
+// ToPairs returns a slice containing all the entries present in the Dictionary.
+// The order of the entries is unspecified.
+//
+// Returns:
+//   - []Entry[K, V]: A slice of the Dictionary's entries.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "two": 2}
+//	pairs := dict.ToPairs() // pairs will be [{one 1} {two 2}]
+func (d Dictionary[K, V]) ToPairs() []Entry[K, V] {
+	pairs := make([]Entry[K, V], 0, len(d))
+	for k, v := range d {
+		pairs = append(pairs, Entry[K, V]{Key: k, Value: v})
+	}
+	return pairs
+}
@@ -0,0 +1,110 @@
+package dictionary
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// FromEnviron creates a Dictionary from the environment variables of the process whose
+// names start with prefix, with the prefix removed from the keys. An empty prefix loads
+// every variable.
+//
+// Parameters:
+//   - prefix: The prefix selecting the variables.
+//
+// Returns:
+//   - Dictionary[string, string]: The selected variables keyed by name without the prefix.
+//
+// Example:
+//
+//	// With APP_PORT=8080 and APP_HOST=0.0.0.0 in the environment:
+//	cfg := FromEnviron("APP_")
+//	// cfg is Dictionary[string, string]{"PORT": "8080", "HOST": "0.0.0.0"}
+func FromEnviron(prefix string) Dictionary[string, string] {
+	return ParseEnviron(os.Environ(), prefix)
+}
+
+// ParseEnviron is like FromEnviron but reads the "name=value" strings of environ, in the
+// format of os.Environ and exec.Cmd.Env. When a name appears more than once, the last
+// value wins, as in the environment of a process.
+//
+// Parameters:
+//   - environ: The "name=value" strings.
+//   - prefix: The prefix selecting the variables.
+//
+// Returns:
+//   - Dictionary[string, string]: The selected variables keyed by name without the prefix.
+//
+// Example:
+//
+//	cfg := ParseEnviron(cmd.Env, "APP_")
+func ParseEnviron(environ []string, prefix string) Dictionary[string, string] {
+	d := make(Dictionary[string, string])
+	for _, kv := range environ {
+		// Skip the first byte so Windows' per-drive variables such as "=C:=C:\dir" keep their name.
+		i := strings.IndexByte(kv[min(1, len(kv)):], '=') + min(1, len(kv))
+		if i < 1 {
+			continue
+		}
+		if name, ok := strings.CutPrefix(kv[:i], prefix); ok {
+			d[name] = kv[i+1:]
+		}
+	}
+	return d
+}
+
+// flagValue adapts a Dictionary[string, string] to flag.Value.
+type flagValue struct {
+	d *Dictionary[string, string]
+}
+
+// FlagValue returns a flag.Value that adds "key=value" arguments to *d, so a repeatable
+// flag such as -D can fill a Dictionary. A nil *d is allocated on first use, and a key
+// given twice keeps its last value.
+//
+// Parameters:
+//   - d: The Dictionary to be filled.
+//
+// Returns:
+//   - flag.Value: The flag value.
+//
+// Example:
+//
+//	var defines Dictionary[string, string]
+//	flag.Var(FlagValue(&defines), "D", "define `key=value` (repeatable)")
+//	flag.Parse()
+//	// with -D env=prod -D region=eu, defines is {"env": "prod", "region": "eu"}
+func FlagValue(d *Dictionary[string, string]) flag.Value {
+	return flagValue{d: d}
+}
+
+// String returns the entries as comma-separated "key=value" pairs in key order.
+func (f flagValue) String() string {
+	if f.d == nil {
+		return ""
+	}
+	pairs := make([]string, 0, len(*f.d))
+	for k, v := range *f.d {
+		pairs = append(pairs, k+"="+v)
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Set adds one "key=value" argument.
+func (f flagValue) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("dictionary: %q is not of the form key=value: %w", s, errs.ErrMalformed)
+	}
+	if *f.d == nil {
+		*f.d = make(Dictionary[string, string])
+	}
+	(*f.d)[key] = value
+	return nil
+}
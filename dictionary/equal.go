@@ -0,0 +1,69 @@
+package dictionary
+
+import "github.com/bhanurp/gotypes/equality"
+
+// EqualComparable reports whether two Dictionaries hold the same key-value pairs,
+// comparing values with == instead of the deep comparison used by IsEqual. It is much faster for
+// primitive values. Note that == compares pointers, channels and interfaces holding
+// them by identity rather than by the data they refer to.
+//
+// Parameters:
+//   - a: The first Dictionary.
+//   - b: The second Dictionary.
+//
+// Returns:
+//   - bool: True if the Dictionaries are equal, false otherwise.
+//
+// Example:
+//
+//	dict1 := Dictionary[string, int]{"one": 1, "two": 2}
+//	dict2 := Dictionary[string, int]{"one": 1, "two": 2}
+//	equal := EqualComparable(dict1, dict2) // equal will be true
+func EqualComparable[K comparable, V comparable](a, b Dictionary[K, V]) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if v2, ok := b[k]; !ok || v != v2 {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualFunc reports whether the current Dictionary and d2 hold the same keys with values
+// that eq considers equal. It lets callers choose the value semantics, for example a
+// tolerance for floats or ignoring timestamps, instead of those of IsEqual.
+//
+// Parameters:
+//   - d2: The Dictionary to be compared with.
+//   - eq: The function reporting whether two values are equal.
+//
+// Returns:
+//   - bool: True if both Dictionaries have the same keys and eq holds for every pair of values.
+//
+// Example:
+//
+//	dict1 := Dictionary[string, float64]{"pi": 3.14159}
+//	dict2 := Dictionary[string, float64]{"pi": 3.14160}
+//	equal := dict1.EqualFunc(dict2, func(a, b float64) bool {
+//		return math.Abs(a-b) < 1e-3
+//	}) // equal will be true
+func (d Dictionary[K, V]) EqualFunc(d2 Dictionary[K, V], eq func(a, b V) bool) bool {
+	if len(d) != len(d2) {
+		return false
+	}
+	for k, v := range d {
+		if v2, ok := d2[k]; !ok || !eq(v, v2) {
+			return false
+		}
+	}
+	return true
+}
+
+// valuesEqual returns the function used to compare values of type V by ContainsValue,
+// KeysOf, IsEqual, IsSubset, IsSuperset and Diff. It follows the rules of package
+// equality, so values with an Equal method such as time.Time are compared with it.
+func valuesEqual[V any]() func(a, b V) bool {
+	return equality.Func[V]()
+}
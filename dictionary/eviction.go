@@ -0,0 +1,133 @@
+package dictionary
+
+import (
+	"container/list"
+	"math/rand/v2"
+)
+
+// EvictionPolicy chooses which entry a BoundedDictionary evicts when it is full. The
+// dictionary reports every insertion, access and removal of a key so the policy can keep
+// whatever bookkeeping it needs, and asks it for a victim when a new key does not fit.
+type EvictionPolicy[K comparable, V any] interface {
+	// Inserted reports that key was added.
+	Inserted(key K)
+	// Accessed reports that the value of key was read or overwritten.
+	Accessed(key K)
+	// Removed reports that key was deleted or evicted.
+	Removed(key K)
+	// Victim returns the key to evict from entries, or false to refuse eviction.
+	Victim(entries View[K, V]) (K, bool)
+}
+
+// fifoEviction evicts the oldest inserted key.
+type fifoEviction[K comparable, V any] struct {
+	order *list.List
+	elems Dictionary[K, *list.Element]
+}
+
+// FIFOEviction returns a policy evicting the key that was inserted first. Overwriting or
+// reading a key does not change its position. All operations take constant time.
+//
+// Example:
+//
+//	recent := NewBoundedDictionary[string, int](100, FIFOEviction[string, int](), nil)
+func FIFOEviction[K comparable, V any]() EvictionPolicy[K, V] {
+	return &fifoEviction[K, V]{order: list.New(), elems: DefaultDictionary[K, *list.Element]()}
+}
+
+func (p *fifoEviction[K, V]) Inserted(key K) { p.elems[key] = p.order.PushBack(key) }
+
+func (p *fifoEviction[K, V]) Accessed(K) {}
+
+func (p *fifoEviction[K, V]) Removed(key K) {
+	if e, ok := p.elems[key]; ok {
+		p.order.Remove(e)
+		delete(p.elems, key)
+	}
+}
+
+func (p *fifoEviction[K, V]) Victim(View[K, V]) (K, bool) {
+	front := p.order.Front()
+	if front == nil {
+		var zero K
+		return zero, false
+	}
+	return front.Value.(K), true
+}
+
+// randomEviction evicts a uniformly random key.
+type randomEviction[K comparable, V any] struct {
+	r     *rand.Rand
+	keys  []K
+	index Dictionary[K, int]
+}
+
+// RandomEviction returns a policy evicting a key chosen uniformly at random. All operations
+// take constant time.
+//
+// Parameters:
+//   - r: The source of randomness, or nil to use the global generator of math/rand/v2.
+//
+// Example:
+//
+//	sample := NewBoundedDictionary[string, int](100, RandomEviction[string, int](nil), nil)
+func RandomEviction[K comparable, V any](r *rand.Rand) EvictionPolicy[K, V] {
+	return &randomEviction[K, V]{r: r, index: DefaultDictionary[K, int]()}
+}
+
+func (p *randomEviction[K, V]) Inserted(key K) {
+	p.index[key] = len(p.keys)
+	p.keys = append(p.keys, key)
+}
+
+func (p *randomEviction[K, V]) Accessed(K) {}
+
+func (p *randomEviction[K, V]) Removed(key K) {
+	i, ok := p.index[key]
+	if !ok {
+		return
+	}
+	last := len(p.keys) - 1
+	p.keys[i] = p.keys[last]
+	p.index[p.keys[i]] = i
+	var zero K
+	p.keys[last] = zero
+	p.keys = p.keys[:last]
+	delete(p.index, key)
+}
+
+func (p *randomEviction[K, V]) Victim(View[K, V]) (K, bool) {
+	if len(p.keys) == 0 {
+		var zero K
+		return zero, false
+	}
+	return p.keys[intN(p.r, len(p.keys))], true
+}
+
+// evictFunc lets a callback choose the victim.
+type evictFunc[K comparable, V any] func(entries View[K, V]) (K, bool)
+
+// EvictFunc returns a policy that asks choose for the victim, passing a view of every
+// entry. choose typically scans the entries, so eviction takes linear time.
+//
+// Parameters:
+//   - choose: The function returning the key to evict, or false to refuse eviction.
+//
+// Example:
+//
+//	// Evict the smallest score.
+//	policy := EvictFunc(func(entries View[string, int]) (string, bool) {
+//		best, ok := entries.ToDictionary().MinBy(func(a, b int) bool { return a < b })
+//		return best.Key, ok
+//	})
+func EvictFunc[K comparable, V any](choose func(entries View[K, V]) (K, bool)) EvictionPolicy[K, V] {
+	return evictFunc[K, V](choose)
+}
+
+func (f evictFunc[K, V]) Inserted(K) {}
+
+func (f evictFunc[K, V]) Accessed(K) {}
+
+func (f evictFunc[K, V]) Removed(K) {}
+
+func (f evictFunc[K, V]) Victim(entries View[K, V]) (K, bool) { return f(entries) }
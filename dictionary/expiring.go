@@ -0,0 +1,301 @@
+package dictionary
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/profiling"
+)
+
+// expiringEntry is a value with its deadline. A zero deadline never expires.
+type expiringEntry[V any] struct {
+	value    V
+	deadline time.Time
+	seq      uint64
+}
+
+// expired reports whether the entry has expired at now.
+func (e expiringEntry[V]) expired(now time.Time) bool {
+	return !e.deadline.IsZero() && !now.Before(e.deadline)
+}
+
+// deadlineItem schedules the expiry of the write seq of key.
+type deadlineItem[K comparable] struct {
+	key      K
+	deadline time.Time
+	seq      uint64
+}
+
+// deadlineHeap is a min-heap of deadlineItems by deadline.
+type deadlineHeap[K comparable] []deadlineItem[K]
+
+func (h deadlineHeap[K]) Len() int           { return len(h) }
+func (h deadlineHeap[K]) Less(i, j int) bool { return h[i].deadline.Before(h[j].deadline) }
+func (h deadlineHeap[K]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *deadlineHeap[K]) Push(x any)        { *h = append(*h, x.(deadlineItem[K])) }
+
+func (h *deadlineHeap[K]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// ExpiringDictionary is a Dictionary whose entries can be given a time to live. An entry
+// becomes invisible as soon as its TTL elapses and is removed later by a background reaper
+// or by Reap, at which point the expiration callback, if any, is called with it. Every
+// expired entry is reported exactly once, including one that is overwritten or deleted
+// after expiring but before being reaped.
+//
+// An ExpiringDictionary is safe for concurrent use. Call Stop to end the reaper.
+type ExpiringDictionary[K comparable, V any] struct {
+	mu        sync.Mutex
+	entries   Dictionary[K, expiringEntry[V]]
+	deadlines deadlineHeap[K]
+	seq       uint64
+	onExpire  func(K, V)
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+var _ collection.WritableMap[string, int] = (*ExpiringDictionary[string, int])(nil)
+
+// NewExpiringDictionary creates an empty ExpiringDictionary.
+//
+// Parameters:
+//   - reapInterval: How often the background reaper removes expired entries; zero or less
+//     starts no reaper, leaving removal to Reap.
+//   - onExpire: The function called with each expired entry after it is removed, or nil. It
+//     runs without the dictionary's lock held, so it may use the dictionary.
+//
+// Returns:
+//   - A new empty ExpiringDictionary.
+//
+// Example:
+//
+//	sessions := NewExpiringDictionary(time.Second, func(id string, s Session) {
+//		log.Printf("session %s expired", id)
+//	})
+//	defer sessions.Stop()
+//	sessions.SetWithTTL("abc", session, 30*time.Minute)
+func NewExpiringDictionary[K comparable, V any](reapInterval time.Duration, onExpire func(K, V)) *ExpiringDictionary[K, V] {
+	d := &ExpiringDictionary[K, V]{
+		entries:  DefaultDictionary[K, expiringEntry[V]](),
+		onExpire: onExpire,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if reapInterval > 0 {
+		go d.reap(reapInterval)
+	} else {
+		close(d.done)
+	}
+	return d
+}
+
+// SetValue sets the value for a given key with no expiry.
+func (d *ExpiringDictionary[K, V]) SetValue(key K, value V) {
+	d.SetWithTTL(key, value, 0)
+}
+
+// SetWithTTL sets the value for a given key, to expire after ttl.
+//
+// Parameters:
+//   - key: The key for which the value needs to be set.
+//   - value: The value to be set for the given key.
+//   - ttl: How long the entry stays visible; zero or less means it never expires.
+func (d *ExpiringDictionary[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	now := time.Now()
+	d.mu.Lock()
+	old, expired := d.takeExpired(key, now)
+	d.seq++
+	e := expiringEntry[V]{value: value, seq: d.seq}
+	if ttl > 0 {
+		e.deadline = now.Add(ttl)
+		heap.Push(&d.deadlines, deadlineItem[K]{key: key, deadline: e.deadline, seq: e.seq})
+	}
+	d.entries[key] = e
+	d.compact()
+	d.mu.Unlock()
+	if expired {
+		d.expire(key, old)
+	}
+}
+
+// GetValue retrieves the value associated with the specified key, or the zero value if it
+// is absent or expired.
+func (d *ExpiringDictionary[K, V]) GetValue(key K) V {
+	v, _ := d.Lookup(key)
+	return v
+}
+
+// Lookup retrieves the value associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent or expired.
+//   - bool: True if the key is present and not expired, false otherwise.
+func (d *ExpiringDictionary[K, V]) Lookup(key K) (V, bool) {
+	now := time.Now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	e, ok := d.entries[key]
+	if !ok || e.expired(now) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// ContainsKey checks if the specified key is present and not expired.
+func (d *ExpiringDictionary[K, V]) ContainsKey(key K) bool {
+	_, ok := d.Lookup(key)
+	return ok
+}
+
+// TTL returns how long the specified key has left to live.
+//
+// Returns:
+//   - time.Duration: The remaining time to live, or 0 if the entry never expires.
+//   - bool: True if the key is present and not expired, false otherwise.
+func (d *ExpiringDictionary[K, V]) TTL(key K) (time.Duration, bool) {
+	now := time.Now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	e, ok := d.entries[key]
+	if !ok || e.expired(now) {
+		return 0, false
+	}
+	if e.deadline.IsZero() {
+		return 0, true
+	}
+	return e.deadline.Sub(now), true
+}
+
+// DeleteValue removes the specified key. If the key does not exist, nothing changes.
+func (d *ExpiringDictionary[K, V]) DeleteValue(key K) {
+	d.mu.Lock()
+	old, expired := d.takeExpired(key, time.Now())
+	delete(d.entries, key)
+	d.compact()
+	d.mu.Unlock()
+	if expired {
+		d.expire(key, old)
+	}
+}
+
+// GetKeys returns the keys that have not expired, in unspecified order.
+func (d *ExpiringDictionary[K, V]) GetKeys() []K {
+	now := time.Now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	keys := make([]K, 0, len(d.entries))
+	for k, e := range d.entries {
+		if !e.expired(now) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// GetLength returns the number of entries that have not expired. It takes linear time.
+func (d *ExpiringDictionary[K, V]) GetLength() int {
+	now := time.Now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := 0
+	for _, e := range d.entries {
+		if !e.expired(now) {
+			n++
+		}
+	}
+	return n
+}
+
+// Reap removes every expired entry now and calls the expiration callback for each.
+//
+// Returns:
+//   - int: The number of entries removed.
+func (d *ExpiringDictionary[K, V]) Reap() int {
+	now := time.Now()
+	var expired []Entry[K, V]
+	d.mu.Lock()
+	for len(d.deadlines) > 0 && !now.Before(d.deadlines[0].deadline) {
+		item := heap.Pop(&d.deadlines).(deadlineItem[K])
+		if e, ok := d.entries[item.key]; ok && e.seq == item.seq {
+			delete(d.entries, item.key)
+			expired = append(expired, Entry[K, V]{Key: item.key, Value: e.value})
+		}
+	}
+	d.compact()
+	d.mu.Unlock()
+	for _, e := range expired {
+		d.expire(e.Key, e.Value)
+	}
+	return len(expired)
+}
+
+// Stop ends the background reaper and waits for it to exit. Entries stay readable, and Reap
+// can still be called. Calling Stop more than once is harmless.
+func (d *ExpiringDictionary[K, V]) Stop() {
+	d.stopOnce.Do(func() { close(d.stop) })
+	<-d.done
+}
+
+// reap runs the background reaper until Stop is called.
+func (d *ExpiringDictionary[K, V]) reap(interval time.Duration) {
+	defer close(d.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			d.Reap()
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+// takeExpired reports the current entry of key if it has expired. The caller must hold d.mu.
+func (d *ExpiringDictionary[K, V]) takeExpired(key K, now time.Time) (V, bool) {
+	e, ok := d.entries[key]
+	if !ok || !e.expired(now) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// compact drops the deadlines of overwritten and deleted entries once they make up most of
+// the heap. Every write calls it, so the heap stays bounded even without a reaper; the check
+// is constant time, and the linear rebuild runs only after the heap has doubled past the
+// entries. The caller must hold d.mu.
+func (d *ExpiringDictionary[K, V]) compact() {
+	if len(d.deadlines) < 64 || len(d.deadlines) < 2*len(d.entries) {
+		return
+	}
+	profiling.Do("dictionary.ExpiringDictionary.compact", func() int {
+		n := len(d.deadlines)
+		live := d.deadlines[:0]
+		for _, item := range d.deadlines {
+			if e, ok := d.entries[item.key]; ok && e.seq == item.seq {
+				live = append(live, item)
+			}
+		}
+		clear(d.deadlines[len(live):])
+		d.deadlines = live
+		heap.Init(&d.deadlines)
+		return n
+	})
+}
+
+// expire reports an expired entry to the callback. The caller must not hold d.mu.
+func (d *ExpiringDictionary[K, V]) expire(key K, value V) {
+	if d.onExpire != nil {
+		d.onExpire(key, value)
+	}
+}
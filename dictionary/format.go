@@ -0,0 +1,236 @@
+package dictionary
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// FormatOptions controls how Format renders a Dictionary.
+type FormatOptions struct {
+	// Indent, when non-empty, puts every entry on its own line and indents nested
+	// dictionaries by one more copy of Indent per level. When empty, the output is
+	// a single line.
+	Indent string
+	// MaxWidth, when positive, truncates every rendered key and scalar value to at
+	// most MaxWidth characters, marking the cut with "...".
+	MaxWidth int
+	// Table renders the entries as an ASCII table with Key and Value columns.
+	// Nested dictionaries are rendered on a single line inside their cell.
+	Table bool
+}
+
+// String returns the Dictionary formatted like a Go map, map[k1:v1 k2:v2], with the
+// entries sorted by key so the output is stable across runs. Keys of string, integer,
+// unsigned integer or float kind are compared by value; any other keys are ordered by
+// their type and formatted text. Keys of different kinds, as when K is an interface
+// type, are grouped by kind first.
+//
+// Returns:
+//   - string: The formatted Dictionary.
+//
+// Example:
+//
+//	dict := Dictionary[int, string]{10: "ten", 9: "nine"}
+//	s := dict.String() // s will be "map[9:nine 10:ten]"
+func (d Dictionary[K, V]) String() string {
+	var b strings.Builder
+	b.WriteString("map[")
+	for i, e := range d.stableEntries() {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%v:%v", e.Key, e.Value)
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// stableEntries returns the entries of the Dictionary in the deterministic order used by String.
+func (d Dictionary[K, V]) stableEntries() []Entry[K, V] {
+	entries := d.ToPairs()
+	slices.SortFunc(entries, func(a, b Entry[K, V]) int {
+		return compareKeys(a.Key, b.Key)
+	})
+	return entries
+}
+
+// compareKeys orders two keys by kind, then by value when their kind is ordered, and by
+// their type and formatted text otherwise.
+func compareKeys[K comparable](a, b K) int {
+	return compareValues(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+// compareValues implements compareKeys for reflected values.
+func compareValues(va, vb reflect.Value) int {
+	// Map keys of an interface type arrive wrapped; order their dynamic values.
+	if va.Kind() == reflect.Interface {
+		va = va.Elem()
+	}
+	if vb.Kind() == reflect.Interface {
+		vb = vb.Elem()
+	}
+	// Comparing different kinds by text would not be transitive: 9 < 10 by value,
+	// 10 < "10a" and "10a" < 9 by text.
+	if c := cmp.Compare(va.Kind(), vb.Kind()); c != 0 || !va.IsValid() {
+		return c
+	}
+	switch va.Kind() {
+	case reflect.String:
+		return cmp.Compare(va.String(), vb.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(va.Int(), vb.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(va.Uint(), vb.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(va.Float(), vb.Float())
+	}
+	if c := cmp.Compare(va.Type().String(), vb.Type().String()); c != 0 {
+		return c
+	}
+	return cmp.Compare(formatValue(va), formatValue(vb))
+}
+
+// formatValue formats a reflected value with %v, treating the invalid value as nil.
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// Format renders the Dictionary for display, for example by CLI tools. Entries are sorted
+// as in String, and values that are themselves maps, such as nested Dictionaries, are
+// rendered recursively.
+//
+// Parameters:
+//   - opts: The layout options.
+//
+// Returns:
+//   - string: The rendered Dictionary.
+//
+// Example:
+//
+//	dict := Dictionary[string, any]{"name": "api", "limits": Dictionary[string, int]{"rps": 100}}
+//	fmt.Println(dict.Format(FormatOptions{Indent: "  "}))
+//	// {
+//	//   limits: {
+//	//     rps: 100
+//	//   }
+//	//   name: api
+//	// }
+//
+//	fmt.Println(dict.Format(FormatOptions{Table: true}))
+//	// +--------+------------+
+//	// | Key    | Value      |
+//	// +--------+------------+
+//	// | limits | {rps: 100} |
+//	// | name   | api        |
+//	// +--------+------------+
+func (d Dictionary[K, V]) Format(opts FormatOptions) string {
+	if opts.Table {
+		return formatTable(reflect.ValueOf(d), opts)
+	}
+	var b strings.Builder
+	formatMap(&b, reflect.ValueOf(d), opts, 0)
+	return b.String()
+}
+
+// formatMap writes a map value in brace notation.
+func formatMap(b *strings.Builder, m reflect.Value, opts FormatOptions, depth int) {
+	keys := sortedMapKeys(m)
+	if len(keys) == 0 {
+		b.WriteString("{}")
+		return
+	}
+	b.WriteByte('{')
+	for i, k := range keys {
+		if opts.Indent != "" {
+			b.WriteByte('\n')
+			b.WriteString(strings.Repeat(opts.Indent, depth+1))
+		} else if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(truncate(formatValue(k), opts.MaxWidth))
+		b.WriteString(": ")
+		formatElem(b, m.MapIndex(k), opts, depth+1)
+	}
+	if opts.Indent != "" {
+		b.WriteByte('\n')
+		b.WriteString(strings.Repeat(opts.Indent, depth))
+	}
+	b.WriteByte('}')
+}
+
+// formatElem writes a map element, recursing into nested maps.
+func formatElem(b *strings.Builder, v reflect.Value, opts FormatOptions, depth int) {
+	for v.IsValid() && (v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer) && !v.IsNil() && v.Elem().Kind() == reflect.Map {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Map && !v.IsNil() {
+		formatMap(b, v, opts, depth)
+		return
+	}
+	b.WriteString(truncate(formatValue(v), opts.MaxWidth))
+}
+
+// formatTable renders a map value as an ASCII table.
+func formatTable(m reflect.Value, opts FormatOptions) string {
+	rows := [][2]string{{"Key", "Value"}}
+	inline := opts
+	inline.Indent = ""
+	for _, k := range sortedMapKeys(m) {
+		var cell strings.Builder
+		formatElem(&cell, m.MapIndex(k), inline, 0)
+		rows = append(rows, [2]string{truncate(formatValue(k), opts.MaxWidth), cell.String()})
+	}
+
+	var widths [2]int
+	for _, row := range rows {
+		for c, cell := range row {
+			widths[c] = max(widths[c], utf8.RuneCountInString(cell))
+		}
+	}
+	border := "+" + strings.Repeat("-", widths[0]+2) + "+" + strings.Repeat("-", widths[1]+2) + "+\n"
+
+	var b strings.Builder
+	b.WriteString(border)
+	for i, row := range rows {
+		b.WriteByte('|')
+		for c, cell := range row {
+			b.WriteByte(' ')
+			b.WriteString(cell)
+			b.WriteString(strings.Repeat(" ", widths[c]-utf8.RuneCountInString(cell)+1))
+			b.WriteByte('|')
+		}
+		b.WriteByte('\n')
+		if i == 0 {
+			b.WriteString(border)
+		}
+	}
+	b.WriteString(border)
+	return b.String()
+}
+
+// sortedMapKeys returns the keys of a map value in the order used by String.
+func sortedMapKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	slices.SortFunc(keys, compareValues)
+	return keys
+}
+
+// truncate shortens s to at most width characters, ending with "..." when cut.
+// A width of zero or less disables truncation.
+func truncate(s string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	r := []rune(s)
+	if width <= 3 {
+		return string(r[:width])
+	}
+	return string(r[:width-3]) + "..."
+}
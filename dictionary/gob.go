@@ -0,0 +1,64 @@
+package dictionary
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// GobEncode implements the gob.GobEncoder interface.
+// The Dictionary is encoded as a plain map, so K and V must themselves be encodable by gob.
+//
+// Returns:
+//   - []byte: The gob encoding of the Dictionary.
+//   - error: An error if a key or value cannot be encoded.
+//
+// Example:
+//
+//	var buf bytes.Buffer
+//	err := gob.NewEncoder(&buf).Encode(Dictionary[string, int]{"one": 1})
+func (d Dictionary[K, V]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(map[K]V(d)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+// It replaces the contents of the Dictionary with the decoded entries.
+//
+// Parameters:
+//   - data: The gob encoding produced by GobEncode.
+//
+// Returns:
+//   - error: An error if data cannot be decoded.
+//
+// Example:
+//
+//	var dict Dictionary[string, int]
+//	err := gob.NewDecoder(&buf).Decode(&dict)
+func (d *Dictionary[K, V]) GobDecode(data []byte) error {
+	var m map[K]V
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&m); err != nil {
+		return err
+	}
+	if m == nil {
+		m = make(map[K]V)
+	}
+	*d = Dictionary[K, V](m)
+	return nil
+}
+
+// RegisterGob records the Dictionary[K, V] type with encoding/gob.
+// Registration is only needed when Dictionaries are sent as interface values, for example
+// as a field of type any or as an argument of a net/rpc method declared with interface types;
+// it is safe to call more than once.
+//
+// Example:
+//
+//	func init() {
+//		RegisterGob[string, int]()
+//	}
+func RegisterGob[K comparable, V any]() {
+	gob.Register(Dictionary[K, V]{})
+}
@@ -0,0 +1,178 @@
+package dictionary
+
+import "github.com/bhanurp/gotypes/collection"
+
+// InternedDictionary is a string-valued dictionary that stores each distinct value once.
+// Values are replaced by small integer codes pointing into a shared code table, and are
+// decoded transparently on read, which cuts memory sharply for label-style maps where a
+// few values repeat across many keys.
+//
+// Codes of values that are no longer referenced are recycled, so the table never grows
+// beyond the number of distinct values in use. An InternedDictionary is not safe for
+// concurrent use.
+type InternedDictionary[K comparable] struct {
+	codes  Dictionary[K, uint32]
+	table  []string
+	refs   []int
+	lookup Dictionary[string, uint32]
+	free   []uint32
+}
+
+var _ collection.WritableMap[string, string] = (*InternedDictionary[string])(nil)
+
+// NewInternedDictionary creates an empty InternedDictionary.
+//
+// Returns:
+//   - A new empty InternedDictionary.
+//
+// Example:
+//
+//	regions := NewInternedDictionary[string]()
+//	regions.SetValue("host-1", "eu-west-1")
+//	regions.SetValue("host-2", "eu-west-1") // stored once
+//	regions.DistinctValues()                // 1
+func NewInternedDictionary[K comparable]() *InternedDictionary[K] {
+	return &InternedDictionary[K]{
+		codes:  DefaultDictionary[K, uint32](),
+		lookup: DefaultDictionary[string, uint32](),
+	}
+}
+
+// Intern creates an InternedDictionary holding the entries of d.
+//
+// Parameters:
+//   - d: The Dictionary to be interned.
+//
+// Returns:
+//   - A new InternedDictionary with the same entries as d.
+//
+// Example:
+//
+//	labels := Intern(Dictionary[string, string]{"pod-1": "prod", "pod-2": "prod"})
+//	labels.DistinctValues() // 1
+func Intern[K comparable](d Dictionary[K, string]) *InternedDictionary[K] {
+	id := NewInternedDictionary[K]()
+	for k, v := range d {
+		id.SetValue(k, v)
+	}
+	return id
+}
+
+// GetValue retrieves the value associated with the specified key, or "" if it is absent.
+func (d *InternedDictionary[K]) GetValue(key K) string {
+	v, _ := d.Lookup(key)
+	return v
+}
+
+// Lookup retrieves the value associated with the specified key.
+//
+// Returns:
+//   - string: The value, or "" if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (d *InternedDictionary[K]) Lookup(key K) (string, bool) {
+	code, ok := d.codes[key]
+	if !ok {
+		return "", false
+	}
+	return d.table[code], true
+}
+
+// SetValue sets the value for a given key, reusing the stored copy of value if it is
+// already present in the code table.
+//
+// Parameters:
+//   - key: The key for which the value needs to be set.
+//   - value: The value to be set for the given key.
+func (d *InternedDictionary[K]) SetValue(key K, value string) {
+	if old, ok := d.codes[key]; ok {
+		if d.table[old] == value {
+			return
+		}
+		d.release(old)
+	}
+	d.codes[key] = d.intern(value)
+}
+
+// DeleteValue removes the specified key. If the key does not exist, nothing changes.
+func (d *InternedDictionary[K]) DeleteValue(key K) {
+	if code, ok := d.codes[key]; ok {
+		delete(d.codes, key)
+		d.release(code)
+	}
+}
+
+// ContainsKey checks if the specified key is present.
+func (d *InternedDictionary[K]) ContainsKey(key K) bool {
+	return d.codes.ContainsKey(key)
+}
+
+// ContainsValue checks if any key maps to the specified value. It takes constant time.
+func (d *InternedDictionary[K]) ContainsValue(value string) bool {
+	return d.lookup.ContainsKey(value)
+}
+
+// GetKeys returns the keys in unspecified order.
+func (d *InternedDictionary[K]) GetKeys() []K {
+	return d.codes.GetKeys()
+}
+
+// GetLength returns the number of keys.
+func (d *InternedDictionary[K]) GetLength() int {
+	return len(d.codes)
+}
+
+// DistinctValues returns the number of distinct values currently stored.
+func (d *InternedDictionary[K]) DistinctValues() int {
+	return len(d.lookup)
+}
+
+// ClearDictionary removes every key and empties the code table.
+func (d *InternedDictionary[K]) ClearDictionary() {
+	d.codes.ClearDictionary()
+	d.lookup.ClearDictionary()
+	d.table, d.refs, d.free = nil, nil, nil
+}
+
+// ToDictionary decodes every entry into a plain Dictionary.
+//
+// Returns:
+//   - Dictionary[K, string]: A new Dictionary with the same entries.
+func (d *InternedDictionary[K]) ToDictionary() Dictionary[K, string] {
+	out := make(Dictionary[K, string], len(d.codes))
+	for k, code := range d.codes {
+		out[k] = d.table[code]
+	}
+	return out
+}
+
+// intern returns the code for value, adding it to the table if needed.
+func (d *InternedDictionary[K]) intern(value string) uint32 {
+	if code, ok := d.lookup[value]; ok {
+		d.refs[code]++
+		return code
+	}
+	var code uint32
+	if n := len(d.free); n > 0 {
+		code = d.free[n-1]
+		d.free = d.free[:n-1]
+		d.table[code] = value
+		d.refs[code] = 1
+	} else {
+		code = uint32(len(d.table))
+		d.table = append(d.table, value)
+		d.refs = append(d.refs, 1)
+	}
+	d.lookup[value] = code
+	return code
+}
+
+// release drops one reference to code, recycling it when it is no longer used.
+func (d *InternedDictionary[K]) release(code uint32) {
+	d.refs[code]--
+	if d.refs[code] > 0 {
+		return
+	}
+	delete(d.lookup, d.table[code])
+	d.table[code] = ""
+	d.free = append(d.free, code)
+}
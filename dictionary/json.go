@@ -0,0 +1,198 @@
+package dictionary
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// JSONOptions controls how a Dictionary is encoded by MarshalJSONWithOptions.
+type JSONOptions struct {
+	// SortKeys writes object members in ascending order of their encoded keys,
+	// making the output deterministic across runs.
+	SortKeys bool
+}
+
+// MarshalJSON implements json.Marshaler.
+// The Dictionary is encoded as a JSON object with members sorted by key. Unlike Go's default
+// map encoding, keys may be of any string, integer, unsigned integer, float or bool type, or
+// implement encoding.TextMarshaler, which is preferred when present.
+//
+// Returns:
+//   - []byte: The JSON encoding of the Dictionary, or null if it is nil.
+//   - error: An error wrapping errs.ErrUnsupportedKey if a key type is unsupported, or an
+//     error if a value cannot be encoded.
+//
+// Example:
+//
+//	dict := Dictionary[bool, string]{true: "yes", false: "no"}
+//	data, _ := json.Marshal(dict)
+//	// data is {"false":"no","true":"yes"}
+func (d Dictionary[K, V]) MarshalJSON() ([]byte, error) {
+	return d.MarshalJSONWithOptions(JSONOptions{SortKeys: true})
+}
+
+// MarshalJSONWithOptions encodes the Dictionary as a JSON object using the given options.
+// See MarshalJSON for the supported key types.
+//
+// Parameters:
+//   - opts: The encoding options.
+//
+// Returns:
+//   - []byte: The JSON encoding of the Dictionary, or null if it is nil.
+//   - error: An error wrapping errs.ErrUnsupportedKey if a key type is unsupported, or an
+//     error if a value cannot be encoded.
+func (d Dictionary[K, V]) MarshalJSONWithOptions(opts JSONOptions) ([]byte, error) {
+	if d == nil {
+		return []byte("null"), nil
+	}
+
+	type member struct {
+		key   string
+		value []byte
+	}
+	members := make([]member, 0, len(d))
+	for k, v := range d {
+		key, err := encodeKey(k)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("dictionary: marshal value for key %q: %w", key, err)
+		}
+		members = append(members, member{key, value})
+	}
+	if opts.SortKeys {
+		sort.Slice(members, func(i, j int) bool { return members[i].key < members[j].key })
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(m.key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(m.value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It decodes a JSON object into the Dictionary, converting member names back to K with the
+// same rules as MarshalJSON. Entries are added to any already present, and a nil Dictionary
+// is allocated first. JSON null leaves the Dictionary unchanged.
+//
+// Parameters:
+//   - data: The JSON encoding of the Dictionary.
+//
+// Returns:
+//   - error: An error if data is not a JSON object, a key cannot be converted, or a value cannot
+//     be decoded; one wrapping errs.ErrUnsupportedKey if K is not a supported key type.
+//
+// Example:
+//
+//	var dict Dictionary[int, string]
+//	err := json.Unmarshal([]byte(`{"1":"one","2":"two"}`), &dict)
+//	// dict is Dictionary[int, string]{1: "one", 2: "two"}
+func (d *Dictionary[K, V]) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("dictionary: %w", err)
+	}
+	if raw == nil {
+		return nil
+	}
+	if *d == nil {
+		*d = make(Dictionary[K, V], len(raw))
+	}
+	for name, rawValue := range raw {
+		var key K
+		if err := decodeKey(name, &key); err != nil {
+			return err
+		}
+		var value V
+		if err := json.Unmarshal(rawValue, &value); err != nil {
+			return fmt.Errorf("dictionary: unmarshal value for key %q: %w", name, err)
+		}
+		(*d)[key] = value
+	}
+	return nil
+}
+
+// encodeKey converts a key into the string used as its JSON member name.
+func encodeKey(key any) (string, error) {
+	if tm, ok := key.(encoding.TextMarshaler); ok {
+		text, err := tm.MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("dictionary: marshal key %v: %w", key, err)
+		}
+		return string(text), nil
+	}
+	v := reflect.ValueOf(key)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	}
+	return "", fmt.Errorf("dictionary: key type %T: %w", key, errs.ErrUnsupportedKey)
+}
+
+// decodeKey parses a JSON member name into the key pointed to by dst.
+func decodeKey[K comparable](name string, dst *K) error {
+	if tu, ok := any(dst).(encoding.TextUnmarshaler); ok {
+		if err := tu.UnmarshalText([]byte(name)); err != nil {
+			return fmt.Errorf("dictionary: unmarshal key %q: %w", name, err)
+		}
+		return nil
+	}
+	v := reflect.ValueOf(dst).Elem()
+	var err error
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(name)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(name, 10, v.Type().Bits()); err == nil {
+			v.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		if n, err = strconv.ParseUint(name, 10, v.Type().Bits()); err == nil {
+			v.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(name, v.Type().Bits()); err == nil {
+			v.SetFloat(f)
+		}
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(name); err == nil {
+			v.SetBool(b)
+		}
+	default:
+		return fmt.Errorf("dictionary: key type %s: %w", v.Type(), errs.ErrUnsupportedKey)
+	}
+	if err != nil {
+		return fmt.Errorf("dictionary: unmarshal key %q: %w", name, err)
+	}
+	return nil
+}
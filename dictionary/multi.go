@@ -0,0 +1,165 @@
+package dictionary
+
+import (
+	"iter"
+	"slices"
+)
+
+// MultiDictionary maps each key to a list of values, kept in the order they were added.
+// A key is present as long as it has at least one value. A MultiDictionary is not safe for
+// concurrent use.
+type MultiDictionary[K comparable, V any] struct {
+	entries Dictionary[K, []V]
+	size    int
+}
+
+// NewMultiDictionary creates an empty MultiDictionary.
+//
+// Returns:
+//   - A new empty MultiDictionary.
+//
+// Example:
+//
+//	owners := NewMultiDictionary[string, string]()
+//	owners.Add("api", "alice", "bob")
+//	owners.Add("db", "carol")
+//	owners.GetAll("api") // ["alice", "bob"]
+func NewMultiDictionary[K comparable, V any]() *MultiDictionary[K, V] {
+	return &MultiDictionary[K, V]{entries: DefaultDictionary[K, []V]()}
+}
+
+// Add appends values to the list of the specified key.
+//
+// Parameters:
+//   - key: The key the values belong to.
+//   - values: The values to be added.
+func (m *MultiDictionary[K, V]) Add(key K, values ...V) {
+	if len(values) == 0 {
+		return
+	}
+	m.entries[key] = append(m.entries[key], values...)
+	m.size += len(values)
+}
+
+// GetAll returns a copy of the values of the specified key, or nil if it is absent.
+func (m *MultiDictionary[K, V]) GetAll(key K) []V {
+	return slices.Clone(m.entries[key])
+}
+
+// GetFirst returns the first value added under the specified key.
+//
+// Returns:
+//   - V: The first value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (m *MultiDictionary[K, V]) GetFirst(key K) (V, bool) {
+	values := m.entries[key]
+	if len(values) == 0 {
+		var zero V
+		return zero, false
+	}
+	return values[0], true
+}
+
+// ContainsKey checks if the specified key has at least one value.
+func (m *MultiDictionary[K, V]) ContainsKey(key K) bool {
+	return m.entries.ContainsKey(key)
+}
+
+// ContainsEntry checks if value is among the values of key, compared as
+// Dictionary.ContainsValue compares values.
+func (m *MultiDictionary[K, V]) ContainsEntry(key K, value V) bool {
+	eq := valuesEqual[V]()
+	return slices.ContainsFunc(m.entries[key], func(v V) bool { return eq(v, value) })
+}
+
+// CountOf returns the number of values of the specified key.
+func (m *MultiDictionary[K, V]) CountOf(key K) int {
+	return len(m.entries[key])
+}
+
+// RemoveValue removes the first occurrence of value from the values of key, compared as
+// Dictionary.ContainsValue compares values. The key is removed with its last value.
+//
+// Returns:
+//   - bool: True if a value was removed, false otherwise.
+//
+// Example:
+//
+//	owners.RemoveValue("api", "alice") // true; owners.GetAll("api") is ["bob"]
+func (m *MultiDictionary[K, V]) RemoveValue(key K, value V) bool {
+	eq := valuesEqual[V]()
+	values := m.entries[key]
+	i := slices.IndexFunc(values, func(v V) bool { return eq(v, value) })
+	if i < 0 {
+		return false
+	}
+	values = slices.Delete(values, i, i+1)
+	if len(values) == 0 {
+		delete(m.entries, key)
+	} else {
+		m.entries[key] = values
+	}
+	m.size--
+	return true
+}
+
+// RemoveAll removes the specified key with all its values.
+//
+// Returns:
+//   - int: The number of values removed.
+func (m *MultiDictionary[K, V]) RemoveAll(key K) int {
+	n := len(m.entries[key])
+	delete(m.entries, key)
+	m.size -= n
+	return n
+}
+
+// GetKeys returns the keys in unspecified order.
+func (m *MultiDictionary[K, V]) GetKeys() []K {
+	return m.entries.GetKeys()
+}
+
+// KeyCount returns the number of keys.
+func (m *MultiDictionary[K, V]) KeyCount() int {
+	return len(m.entries)
+}
+
+// GetLength returns the total number of values across all keys.
+func (m *MultiDictionary[K, V]) GetLength() int {
+	return m.size
+}
+
+// All returns an iterator over every key-value pair, yielding a key once per value. Keys
+// come in unspecified order and the values of a key in the order they were added.
+//
+// Example:
+//
+//	for service, owner := range owners.All() {
+//		notify(owner, service)
+//	}
+func (m *MultiDictionary[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, values := range m.entries {
+			for _, v := range values {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ClearDictionary removes every key.
+func (m *MultiDictionary[K, V]) ClearDictionary() {
+	m.entries.ClearDictionary()
+	m.size = 0
+}
+
+// ToDictionary returns a copy of the entries as a Dictionary of slices.
+func (m *MultiDictionary[K, V]) ToDictionary() Dictionary[K, []V] {
+	out := make(Dictionary[K, []V], len(m.entries))
+	for k, values := range m.entries {
+		out[k] = slices.Clone(values)
+	}
+	return out
+}
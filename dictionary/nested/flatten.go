@@ -0,0 +1,83 @@
+package nested
+
+import (
+	"slices"
+
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// Flatten turns a tree of dictionaries into a single Dictionary whose keys are the paths of
+// the leaves joined with sep, for diffing, storing in flat key-value stores or exporting as
+// environment variables. Empty inner dictionaries are kept as leaves so Unflatten restores
+// them; slices and all other values are leaves.
+//
+// Parameters:
+//   - d: The root of the tree.
+//   - sep: The separator placed between path segments.
+//
+// Returns:
+//   - dictionary.Dictionary[string, any]: A new flat Dictionary.
+//
+// Example:
+//
+//	tree := dictionary.Dictionary[string, any]{
+//		"server": map[string]any{"port": 8080, "tls": map[string]any{"enabled": true}},
+//	}
+//	flat := Flatten(tree, ".")
+//	// flat is {"server.port": 8080, "server.tls.enabled": true}
+func Flatten(d dictionary.Dictionary[string, any], sep string) dictionary.Dictionary[string, any] {
+	flat := dictionary.DefaultDictionary[string, any]()
+	flatten(flat, d, "", sep)
+	return flat
+}
+
+func flatten(flat, node dictionary.Dictionary[string, any], prefix, sep string) {
+	for k, v := range node {
+		path := k
+		if prefix != "" {
+			path = prefix + sep + k
+		}
+		if child, ok := asDictionary(v); ok && len(child) > 0 {
+			flatten(flat, child, path, sep)
+			continue
+		}
+		flat[path] = v
+	}
+}
+
+// Unflatten is the inverse of Flatten: it splits every key on sep and builds the tree of
+// Dictionary[string, any] they describe.
+//
+// Parameters:
+//   - flat: The flat Dictionary.
+//   - sep: The separator between path segments.
+//
+// Returns:
+//   - dictionary.Dictionary[string, any]: A new tree, or nil on error.
+//   - error: A *errs.KeyError wrapping errs.ErrTypeMismatch if a key is both a leaf and a
+//     prefix of another key, such as "a" and "a.b", or an error wrapping errs.ErrInvalidPath
+//     for a key with an empty segment.
+//
+// Example:
+//
+//	tree, err := Unflatten(dictionary.Dictionary[string, any]{"db.host": "localhost", "db.port": 5432}, ".")
+//	// tree is {"db": {"host": "localhost", "port": 5432}}
+func Unflatten(flat dictionary.Dictionary[string, any], sep string) (dictionary.Dictionary[string, any], error) {
+	tree := dictionary.NewDictionaryWithCapacity[string, any](len(flat))
+	// Sorting puts every prefix before the keys it is a prefix of, so conflicts are
+	// reported the same way whatever the iteration order of flat.
+	keys := flat.GetKeys()
+	slices.Sort(keys)
+	for _, k := range keys {
+		node, last, err := parent(tree, k, sep, true)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := node[last]; exists {
+			return nil, errs.NewKeyError("unflatten", k, errs.ErrTypeMismatch)
+		}
+		node[last] = flat[k]
+	}
+	return tree, nil
+}
@@ -0,0 +1,151 @@
+// Package nested reads and writes trees of Dictionary[string, any] by dotted path,
+// such as "server.tls.cert", as found in configuration files and decoded JSON.
+//
+// Inner nodes may be Dictionary[string, any] or map[string]any, so trees decoded by
+// encoding/json can be used directly. Nodes created by this package are
+// Dictionary[string, any].
+package nested
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// Separator separates the segments of a path.
+const Separator = "."
+
+// asDictionary returns v as a Dictionary[string, any] sharing its storage, if it is one.
+func asDictionary(v any) (dictionary.Dictionary[string, any], bool) {
+	switch m := v.(type) {
+	case dictionary.Dictionary[string, any]:
+		return m, true
+	case map[string]any:
+		return dictionary.Dictionary[string, any](m), true
+	}
+	return nil, false
+}
+
+// split splits a path into its segments.
+func split(path, sep string) ([]string, error) {
+	segments := strings.Split(path, sep)
+	for _, s := range segments {
+		if s == "" {
+			return nil, fmt.Errorf("nested: %q: %w", path, errs.ErrInvalidPath)
+		}
+	}
+	return segments, nil
+}
+
+// parent walks to the dictionary holding the last segment of path.
+// If create is true, missing intermediate dictionaries are added.
+func parent(d dictionary.Dictionary[string, any], path, sep string, create bool) (dictionary.Dictionary[string, any], string, error) {
+	segments, err := split(path, sep)
+	if err != nil {
+		return nil, "", err
+	}
+	node := d
+	for i, seg := range segments[:len(segments)-1] {
+		next, ok := node[seg]
+		if !ok && create {
+			child := dictionary.DefaultDictionary[string, any]()
+			node[seg] = child
+			node = child
+			continue
+		}
+		prefix := strings.Join(segments[:i+1], sep)
+		if !ok {
+			return nil, "", errs.NewKeyError("walk", prefix, errs.ErrKeyNotFound)
+		}
+		if node, ok = asDictionary(next); !ok {
+			return nil, "", errs.NewKeyError("walk", prefix, errs.ErrTypeMismatch)
+		}
+	}
+	return node, segments[len(segments)-1], nil
+}
+
+// GetPath returns the value at path.
+//
+// Parameters:
+//   - d: The root of the tree.
+//   - path: The dotted path of the value.
+//
+// Returns:
+//   - any: The value, or nil on error.
+//   - error: A *errs.KeyError wrapping errs.ErrKeyNotFound or errs.ErrTypeMismatch naming the
+//     path prefix that could not be followed, or an error wrapping errs.ErrInvalidPath.
+//
+// Example:
+//
+//	config := dictionary.Dictionary[string, any]{
+//		"server": map[string]any{"port": 8080},
+//	}
+//	port, err := GetPath(config, "server.port") // port will be 8080
+func GetPath(d dictionary.Dictionary[string, any], path string) (any, error) {
+	node, last, err := parent(d, path, Separator, false)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := node[last]
+	if !ok {
+		return nil, errs.NewKeyError("get", path, errs.ErrKeyNotFound)
+	}
+	return v, nil
+}
+
+// SetPath stores value at path, creating missing intermediate dictionaries.
+//
+// Parameters:
+//   - d: The root of the tree; it must not be nil.
+//   - path: The dotted path of the value.
+//   - value: The value to be stored.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrTypeMismatch if the path runs through a value that
+//     is not a dictionary, or errs.ErrNilCollection if d is nil, or an error wrapping
+//     errs.ErrInvalidPath.
+//
+// Example:
+//
+//	config := dictionary.Dictionary[string, any]{}
+//	err := SetPath(config, "server.tls.enabled", true)
+//	// config is {"server": {"tls": {"enabled": true}}}
+func SetPath(d dictionary.Dictionary[string, any], path string, value any) error {
+	if d == nil {
+		return errs.NewKeyError("set", path, errs.ErrNilCollection)
+	}
+	node, last, err := parent(d, path, Separator, true)
+	if err != nil {
+		return err
+	}
+	node[last] = value
+	return nil
+}
+
+// DeletePath removes the value at path. Intermediate dictionaries are kept even if they
+// become empty.
+//
+// Parameters:
+//   - d: The root of the tree.
+//   - path: The dotted path of the value.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrKeyNotFound or errs.ErrTypeMismatch if the path
+//     does not lead to a value, or an error wrapping errs.ErrInvalidPath.
+//
+// Example:
+//
+//	err := DeletePath(config, "server.tls")
+func DeletePath(d dictionary.Dictionary[string, any], path string) error {
+	node, last, err := parent(d, path, Separator, false)
+	if err != nil {
+		return err
+	}
+	if _, ok := node[last]; !ok {
+		return errs.NewKeyError("delete", path, errs.ErrKeyNotFound)
+	}
+	delete(node, last)
+	return nil
+}
@@ -0,0 +1,247 @@
+package dictionary
+
+import (
+	"sync"
+
+	"github.com/bhanurp/gotypes/collection"
+)
+
+// EventKind identifies the change an Event reports.
+type EventKind int
+
+const (
+	// EventSet reports that a key was set, whether it was added or overwritten.
+	EventSet EventKind = iota
+	// EventDelete reports that a key was removed.
+	EventDelete
+	// EventClear reports that every entry was removed.
+	EventClear
+)
+
+// String returns the name of the event kind.
+func (k EventKind) String() string {
+	switch k {
+	case EventSet:
+		return "set"
+	case EventDelete:
+		return "delete"
+	case EventClear:
+		return "clear"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes one change to an ObservableDictionary.
+type Event[K comparable, V any] struct {
+	Kind EventKind
+	// Key is the changed key; it is the zero value for EventClear.
+	Key K
+	// OldValue is the value before the change, valid when HadOld is true.
+	OldValue V
+	// HadOld reports whether Key was present before the change.
+	HadOld bool
+	// NewValue is the value after an EventSet.
+	NewValue V
+}
+
+// listener is a registered callback.
+type listener[K comparable, V any] struct {
+	id uint64
+	fn func(Event[K, V])
+}
+
+// ObservableDictionary wraps a Dictionary and notifies subscribers of every change made
+// through it. Listeners run synchronously on the goroutine that made the change, after the
+// change is applied, in the order they subscribed; events are delivered in the order the
+// changes happened. Listeners may read the dictionary but must not modify it.
+// An ObservableDictionary is safe for concurrent use.
+type ObservableDictionary[K comparable, V any] struct {
+	mu        sync.RWMutex
+	entries   Dictionary[K, V]
+	listeners []listener[K, V]
+	nextID    uint64
+	// delivered is closed once the listeners of the latest change have returned; the next
+	// change waits for it, which keeps events in order.
+	delivered chan struct{}
+}
+
+var _ collection.WritableMap[string, int] = (*ObservableDictionary[string, int])(nil)
+
+// NewObservableDictionary creates an empty ObservableDictionary.
+//
+// Returns:
+//   - A new empty ObservableDictionary.
+//
+// Example:
+//
+//	config := NewObservableDictionary[string, string]()
+//	cancel := config.Subscribe(func(e Event[string, string]) {
+//		log.Printf("%s %s: %q -> %q", e.Kind, e.Key, e.OldValue, e.NewValue)
+//	})
+//	defer cancel()
+//	config.SetValue("mode", "fast") // logs: set mode: "" -> "fast"
+func NewObservableDictionary[K comparable, V any]() *ObservableDictionary[K, V] {
+	return &ObservableDictionary[K, V]{entries: DefaultDictionary[K, V]()}
+}
+
+// Subscribe registers fn to be called on every change.
+//
+// Parameters:
+//   - fn: The listener.
+//
+// Returns:
+//   - func(): A function that unregisters fn; calling it more than once is harmless.
+func (o *ObservableDictionary[K, V]) Subscribe(fn func(Event[K, V])) (cancel func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.nextID++
+	id := o.nextID
+	o.listeners = append(o.listeners, listener[K, V]{id: id, fn: fn})
+	return func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		for i, l := range o.listeners {
+			if l.id == id {
+				// Copy instead of deleting in place: a notification in progress may hold the old slice.
+				o.listeners = append(o.listeners[:i:i], o.listeners[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Watch returns a channel receiving every change. Each change blocks until the event is
+// received or the subscription is cancelled, so the channel must be drained promptly or
+// given enough buffer. The channel is never closed.
+//
+// Parameters:
+//   - buffer: The capacity of the channel.
+//
+// Returns:
+//   - <-chan Event[K, V]: The channel of events.
+//   - func(): A function that ends the subscription and releases any blocked change.
+//
+// Example:
+//
+//	events, cancel := config.Watch(64)
+//	defer cancel()
+//	go func() {
+//		for e := range events {
+//			cache.Invalidate(e.Key)
+//		}
+//	}()
+func (o *ObservableDictionary[K, V]) Watch(buffer int) (<-chan Event[K, V], func()) {
+	ch := make(chan Event[K, V], max(buffer, 0))
+	done := make(chan struct{})
+	unsubscribe := o.Subscribe(func(e Event[K, V]) {
+		select {
+		case ch <- e:
+		case <-done:
+		}
+	})
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			close(done)
+			unsubscribe()
+		})
+	}
+}
+
+// GetValue retrieves the value associated with the specified key, or the zero value if it is absent.
+func (o *ObservableDictionary[K, V]) GetValue(key K) V {
+	v, _ := o.Lookup(key)
+	return v
+}
+
+// Lookup retrieves the value associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (o *ObservableDictionary[K, V]) Lookup(key K) (V, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	v, ok := o.entries[key]
+	return v, ok
+}
+
+// ContainsKey checks if the specified key is present.
+func (o *ObservableDictionary[K, V]) ContainsKey(key K) bool {
+	_, ok := o.Lookup(key)
+	return ok
+}
+
+// GetKeys returns the keys in unspecified order.
+func (o *ObservableDictionary[K, V]) GetKeys() []K {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.entries.GetKeys()
+}
+
+// GetLength returns the number of entries.
+func (o *ObservableDictionary[K, V]) GetLength() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return len(o.entries)
+}
+
+// Snapshot returns a copy of the entries.
+func (o *ObservableDictionary[K, V]) Snapshot() Dictionary[K, V] {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.entries.CopyDictionary()
+}
+
+// SetValue sets the value for a given key and fires an EventSet.
+func (o *ObservableDictionary[K, V]) SetValue(key K, value V) {
+	o.mu.Lock()
+	old, had := o.entries[key]
+	o.entries[key] = value
+	o.notify(Event[K, V]{Kind: EventSet, Key: key, OldValue: old, HadOld: had, NewValue: value})
+}
+
+// MergeDictionaries sets every entry of d2, firing one EventSet per entry.
+func (o *ObservableDictionary[K, V]) MergeDictionaries(d2 Dictionary[K, V]) {
+	for k, v := range d2 {
+		o.SetValue(k, v)
+	}
+}
+
+// DeleteValue removes the specified key and fires an EventDelete. If the key does not
+// exist, nothing changes and no event fires.
+func (o *ObservableDictionary[K, V]) DeleteValue(key K) {
+	o.mu.Lock()
+	old, had := o.entries[key]
+	if !had {
+		o.mu.Unlock()
+		return
+	}
+	delete(o.entries, key)
+	o.notify(Event[K, V]{Kind: EventDelete, Key: key, OldValue: old, HadOld: true})
+}
+
+// ClearDictionary removes every entry and fires a single EventClear.
+func (o *ObservableDictionary[K, V]) ClearDictionary() {
+	o.mu.Lock()
+	clear(o.entries)
+	o.notify(Event[K, V]{Kind: EventClear})
+}
+
+// notify delivers e to the listeners. The caller must hold o.mu, which notify releases
+// before delivering, so listeners can read the dictionary and cancel their subscription.
+// Delivery waits until the listeners of the previous change have returned.
+func (o *ObservableDictionary[K, V]) notify(e Event[K, V]) {
+	listeners := o.listeners
+	prev, done := o.delivered, make(chan struct{})
+	o.delivered = done
+	o.mu.Unlock()
+	defer close(done)
+	if prev != nil {
+		<-prev
+	}
+	for _, l := range listeners {
+		l.fn(e)
+	}
+}
@@ -0,0 +1,299 @@
+// Package ordereddictionary provides a Dictionary that remembers the order in which keys
+// were inserted, for serialization, display and any other use that needs a stable order
+// where Go maps randomize it.
+package ordereddictionary
+
+import (
+	"container/list"
+	"fmt"
+	"iter"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// OrderedDictionary is a Dictionary that iterates in insertion order. Overwriting a key
+// keeps its position; MoveToFront and MoveToBack reposition it. Lookups, inserts, deletes
+// and moves take constant time; At takes time proportional to the distance from the
+// nearer end. An OrderedDictionary is not safe for concurrent use.
+type OrderedDictionary[K comparable, V any] struct {
+	order *list.List // of *dictionary.Entry[K, V]
+	index dictionary.Dictionary[K, *list.Element]
+}
+
+var (
+	_ collection.OrderedMap[string, int]  = (*OrderedDictionary[string, int])(nil)
+	_ collection.WritableMap[string, int] = (*OrderedDictionary[string, int])(nil)
+)
+
+// New creates an empty OrderedDictionary.
+//
+// Returns:
+//   - A new empty OrderedDictionary.
+//
+// Example:
+//
+//	d := New[string, int]()
+//	d.SetValue("b", 2)
+//	d.SetValue("a", 1)
+//	d.GetKeys() // ["b", "a"]
+func New[K comparable, V any]() *OrderedDictionary[K, V] {
+	return &OrderedDictionary[K, V]{order: list.New(), index: dictionary.DefaultDictionary[K, *list.Element]()}
+}
+
+// FromEntries creates an OrderedDictionary holding entries in the given order. A key given
+// more than once keeps its first position and its last value.
+//
+// Parameters:
+//   - entries: The initial entries.
+//
+// Returns:
+//   - A new OrderedDictionary.
+func FromEntries[K comparable, V any](entries []dictionary.Entry[K, V]) *OrderedDictionary[K, V] {
+	d := New[K, V]()
+	for _, e := range entries {
+		d.SetValue(e.Key, e.Value)
+	}
+	return d
+}
+
+// entry returns the entry stored in elem.
+func entry[K comparable, V any](elem *list.Element) *dictionary.Entry[K, V] {
+	return elem.Value.(*dictionary.Entry[K, V])
+}
+
+// GetValue retrieves the value associated with the specified key, or the zero value if it is absent.
+func (d *OrderedDictionary[K, V]) GetValue(key K) V {
+	v, _ := d.Lookup(key)
+	return v
+}
+
+// Lookup retrieves the value associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (d *OrderedDictionary[K, V]) Lookup(key K) (V, bool) {
+	elem, ok := d.index[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return entry[K, V](elem).Value, true
+}
+
+// ContainsKey checks if the specified key is present.
+func (d *OrderedDictionary[K, V]) ContainsKey(key K) bool {
+	return d.index.ContainsKey(key)
+}
+
+// SetValue sets the value for a given key. A new key is appended at the back; an existing
+// key keeps its position.
+func (d *OrderedDictionary[K, V]) SetValue(key K, value V) {
+	if elem, ok := d.index[key]; ok {
+		entry[K, V](elem).Value = value
+		return
+	}
+	d.index[key] = d.order.PushBack(&dictionary.Entry[K, V]{Key: key, Value: value})
+}
+
+// DeleteValue removes the specified key. If the key does not exist, nothing changes.
+func (d *OrderedDictionary[K, V]) DeleteValue(key K) {
+	if elem, ok := d.index[key]; ok {
+		d.order.Remove(elem)
+		delete(d.index, key)
+	}
+}
+
+// MoveToFront moves the specified key to the front of the order.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrKeyNotFound if the key is absent, nil otherwise.
+//
+// Example:
+//
+//	d.MoveToFront("a") // d.GetKeys() is ["a", "b"]
+func (d *OrderedDictionary[K, V]) MoveToFront(key K) error {
+	elem, ok := d.index[key]
+	if !ok {
+		return errs.NewKeyError("move", key, errs.ErrKeyNotFound)
+	}
+	d.order.MoveToFront(elem)
+	return nil
+}
+
+// MoveToBack moves the specified key to the back of the order.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrKeyNotFound if the key is absent, nil otherwise.
+func (d *OrderedDictionary[K, V]) MoveToBack(key K) error {
+	elem, ok := d.index[key]
+	if !ok {
+		return errs.NewKeyError("move", key, errs.ErrKeyNotFound)
+	}
+	d.order.MoveToBack(elem)
+	return nil
+}
+
+// At returns the entry at position i of the order.
+//
+// Parameters:
+//   - i: The position, from 0 to GetLength()-1.
+//
+// Returns:
+//   - K: The key at position i.
+//   - V: The value at position i.
+//   - error: An error wrapping errs.ErrOutOfRange if i is not a valid position, nil otherwise.
+//
+// Example:
+//
+//	k, v, err := d.At(0) // the first inserted entry
+func (d *OrderedDictionary[K, V]) At(i int) (K, V, error) {
+	n := d.order.Len()
+	if i < 0 || i >= n {
+		var (
+			zk K
+			zv V
+		)
+		return zk, zv, fmt.Errorf("ordereddictionary: index %d of %d: %w", i, n, errs.ErrOutOfRange)
+	}
+	var elem *list.Element
+	if i < n/2 {
+		elem = d.order.Front()
+		for range i {
+			elem = elem.Next()
+		}
+	} else {
+		elem = d.order.Back()
+		for range n - 1 - i {
+			elem = elem.Prev()
+		}
+	}
+	e := entry[K, V](elem)
+	return e.Key, e.Value, nil
+}
+
+// IndexOf returns the position of the specified key in the order. It takes linear time.
+//
+// Returns:
+//   - int: The position, or -1 if the key is absent.
+func (d *OrderedDictionary[K, V]) IndexOf(key K) int {
+	target, ok := d.index[key]
+	if !ok {
+		return -1
+	}
+	i := 0
+	for elem := d.order.Front(); elem != target; elem = elem.Next() {
+		i++
+	}
+	return i
+}
+
+// First returns the first entry in the order, or false if the dictionary is empty.
+func (d *OrderedDictionary[K, V]) First() (K, V, bool) {
+	return d.at(d.order.Front())
+}
+
+// Last returns the last entry in the order, or false if the dictionary is empty.
+func (d *OrderedDictionary[K, V]) Last() (K, V, bool) {
+	return d.at(d.order.Back())
+}
+
+// at returns the entry of elem, or false if elem is nil.
+func (d *OrderedDictionary[K, V]) at(elem *list.Element) (K, V, bool) {
+	if elem == nil {
+		var (
+			zk K
+			zv V
+		)
+		return zk, zv, false
+	}
+	e := entry[K, V](elem)
+	return e.Key, e.Value, true
+}
+
+// Ascend calls fn for each entry from first to last until fn returns false.
+func (d *OrderedDictionary[K, V]) Ascend(fn func(key K, value V) bool) {
+	for elem := d.order.Front(); elem != nil; elem = elem.Next() {
+		if e := entry[K, V](elem); !fn(e.Key, e.Value) {
+			return
+		}
+	}
+}
+
+// Descend calls fn for each entry from last to first until fn returns false.
+func (d *OrderedDictionary[K, V]) Descend(fn func(key K, value V) bool) {
+	for elem := d.order.Back(); elem != nil; elem = elem.Prev() {
+		if e := entry[K, V](elem); !fn(e.Key, e.Value) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over the entries from first to last. The dictionary must not be
+// modified during iteration, except to delete the current key.
+//
+// Example:
+//
+//	for k, v := range d.All() {
+//		fmt.Println(k, v)
+//	}
+func (d *OrderedDictionary[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for elem := d.order.Front(); elem != nil; {
+			next := elem.Next()
+			if e := entry[K, V](elem); !yield(e.Key, e.Value) {
+				return
+			}
+			elem = next
+		}
+	}
+}
+
+// GetKeys returns the keys in order.
+func (d *OrderedDictionary[K, V]) GetKeys() []K {
+	keys := make([]K, 0, d.order.Len())
+	for k := range d.All() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// GetValues returns the values in the order of their keys.
+func (d *OrderedDictionary[K, V]) GetValues() []V {
+	values := make([]V, 0, d.order.Len())
+	for _, v := range d.All() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// GetLength returns the number of entries.
+func (d *OrderedDictionary[K, V]) GetLength() int {
+	return d.order.Len()
+}
+
+// ClearDictionary removes every entry.
+func (d *OrderedDictionary[K, V]) ClearDictionary() {
+	d.order.Init()
+	d.index.ClearDictionary()
+}
+
+// ToEntries returns the entries in order.
+func (d *OrderedDictionary[K, V]) ToEntries() []dictionary.Entry[K, V] {
+	entries := make([]dictionary.Entry[K, V], 0, d.order.Len())
+	for k, v := range d.All() {
+		entries = append(entries, dictionary.Entry[K, V]{Key: k, Value: v})
+	}
+	return entries
+}
+
+// ToDictionary returns the entries as a plain, unordered Dictionary.
+func (d *OrderedDictionary[K, V]) ToDictionary() dictionary.Dictionary[K, V] {
+	out := make(dictionary.Dictionary[K, V], d.order.Len())
+	for k, v := range d.All() {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,140 @@
+package dictionary
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bhanurp/gotypes/profiling"
+)
+
+// chunksPerWorker is how many chunks each worker gets on average, so that a
+// worker stuck on slow entries does not leave the others idle at the end.
+const chunksPerWorker = 4
+
+// ParallelForEach calls fn for every entry of the Dictionary using several goroutines.
+// Entries are split into chunks sized from the Dictionary length and the number of
+// workers, and workers pick up chunks as they finish the previous one. Processing stops
+// early when ctx is done or fn returns an error. fn must be safe for concurrent use,
+// and the Dictionary must not be modified until ParallelForEach returns.
+//
+// Parameters:
+//   - ctx: The context bounding the whole operation.
+//   - workers: The number of goroutines to use; zero or less means runtime.GOMAXPROCS(0).
+//   - fn: The function applied to each entry.
+//
+// Returns:
+//   - error: The first error returned by fn, or ctx.Err() if ctx was done first; nil otherwise.
+//
+// Example:
+//
+//	var total atomic.Int64
+//	err := dict.ParallelForEach(ctx, 0, func(k string, v int) error {
+//		total.Add(int64(expensive(v)))
+//		return nil
+//	})
+func (d Dictionary[K, V]) ParallelForEach(ctx context.Context, workers int, fn func(K, V) error) error {
+	var err error
+	profiling.DoContext(ctx, "dictionary.ParallelForEach", func(ctx context.Context) int {
+		entries := d.ToPairs()
+		err = parallelChunks(ctx, len(entries), workers, func(i int) error {
+			return fn(entries[i].Key, entries[i].Value)
+		})
+		return len(entries)
+	})
+	return err
+}
+
+// ParallelMap returns a new Dictionary with the same keys as d, whose values are the
+// results of fn, computed with several goroutines as in ParallelForEach.
+//
+// Parameters:
+//   - ctx: The context bounding the whole operation.
+//   - d: The Dictionary to be transformed.
+//   - workers: The number of goroutines to use; zero or less means runtime.GOMAXPROCS(0).
+//   - fn: The function computing the new value of each entry.
+//
+// Returns:
+//   - Dictionary[K, R]: The transformed Dictionary, or nil on error.
+//   - error: The first error returned by fn, or ctx.Err() if ctx was done first; nil otherwise.
+//
+// Example:
+//
+//	sizes, err := ParallelMap(ctx, files, 8, func(name string, f File) (int64, error) {
+//		return f.Size()
+//	})
+func ParallelMap[K comparable, V any, R any](ctx context.Context, d Dictionary[K, V], workers int, fn func(K, V) (R, error)) (Dictionary[K, R], error) {
+	entries := d.ToPairs()
+	results := make([]R, len(entries))
+	var err error
+	profiling.DoContext(ctx, "dictionary.ParallelMap", func(ctx context.Context) int {
+		err = parallelChunks(ctx, len(entries), workers, func(i int) error {
+			r, err := fn(entries[i].Key, entries[i].Value)
+			results[i] = r
+			return err
+		})
+		return len(entries)
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make(Dictionary[K, R], len(entries))
+	for i, e := range entries {
+		out[e.Key] = results[i]
+	}
+	return out, nil
+}
+
+// parallelChunks calls fn for every index in [0, n) across workers goroutines, handing
+// out contiguous chunks of indices, and returns the first error encountered.
+func parallelChunks(ctx context.Context, n, workers int, fn func(i int) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = max(1, min(workers, n))
+	chunk := max(1, (n+workers*chunksPerWorker-1)/(workers*chunksPerWorker))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		next     atomic.Int64
+		once     sync.Once
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for {
+				start := int(next.Add(int64(chunk))) - chunk
+				if start >= n {
+					return
+				}
+				for i := start; i < min(start+chunk, n); i++ {
+					if ctx.Err() != nil {
+						fail(ctx.Err())
+						return
+					}
+					if err := fn(i); err != nil {
+						fail(err)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
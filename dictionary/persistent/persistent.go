@@ -0,0 +1,321 @@
+// Package persistent provides an immutable Dictionary backed by a hash array mapped trie
+// (HAMT). Every update returns a new version that shares all unchanged structure with the
+// old one, so versions are cheap snapshots that can be shared between goroutines without
+// locks.
+package persistent
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/profiling"
+)
+
+const (
+	// bitsPerLevel is the number of hash bits consumed at each level of the trie.
+	bitsPerLevel = 5
+	// maxDepth is the number of levels after which the 64-bit hash is exhausted.
+	maxDepth = (64 + bitsPerLevel - 1) / bitsPerLevel
+)
+
+// seed is shared by every Dictionary so that versions of one Dictionary hash alike.
+var seed = maphash.MakeSeed()
+
+// node is a trie node. An inner node has a bitmap of occupied slots and one child per set
+// bit; a slot is either an entry or a sub-node. Below maxDepth, collisions are kept in a
+// plain list of entries.
+type node[K comparable, V any] struct {
+	bitmap   uint32
+	slots    []slot[K, V]
+	collided []dictionary.Entry[K, V]
+}
+
+// slot is a child of an inner node: an entry if sub is nil, otherwise a sub-node.
+type slot[K comparable, V any] struct {
+	hash  uint64
+	entry dictionary.Entry[K, V]
+	sub   *node[K, V]
+}
+
+// Dictionary is an immutable hash map. The zero value is an empty Dictionary. Lookups and
+// updates take O(log32 n) time; updates copy only the path from the root to the changed
+// entry.
+type Dictionary[K comparable, V any] struct {
+	root *node[K, V]
+	size int
+}
+
+var _ collection.Map[string, int] = Dictionary[string, int]{}
+
+// New returns an empty Dictionary.
+//
+// Example:
+//
+//	v1 := New[string, int]().Set("a", 1)
+//	v2 := v1.Set("b", 2)
+//	v1.GetLength() // 1; v1 is unchanged
+//	v2.GetLength() // 2
+func New[K comparable, V any]() Dictionary[K, V] {
+	return Dictionary[K, V]{}
+}
+
+// FromDictionary returns a Dictionary holding the entries of d.
+func FromDictionary[K comparable, V any](d dictionary.Dictionary[K, V]) Dictionary[K, V] {
+	var p Dictionary[K, V]
+	profiling.Do("persistent.FromDictionary", func() int {
+		for k, v := range d {
+			p = p.Set(k, v)
+		}
+		return len(d)
+	})
+	return p
+}
+
+// hashOf hashes key.
+func hashOf[K comparable](key K) uint64 {
+	return maphash.Comparable(seed, key)
+}
+
+// index returns the slot index of hash at depth.
+func index(hash uint64, depth int) uint32 {
+	return uint32(hash>>(depth*bitsPerLevel)) & (1<<bitsPerLevel - 1)
+}
+
+// position returns where the slot for bit lives in slots.
+func position(bitmap, bit uint32) int {
+	return bits.OnesCount32(bitmap & (bit - 1))
+}
+
+// Lookup retrieves the value associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (d Dictionary[K, V]) Lookup(key K) (V, bool) {
+	h := hashOf(key)
+	n := d.root
+	for depth := 0; n != nil; depth++ {
+		if depth == maxDepth {
+			for _, e := range n.collided {
+				if e.Key == key {
+					return e.Value, true
+				}
+			}
+			break
+		}
+		bit := uint32(1) << index(h, depth)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		s := n.slots[position(n.bitmap, bit)]
+		if s.sub == nil {
+			if s.entry.Key == key {
+				return s.entry.Value, true
+			}
+			break
+		}
+		n = s.sub
+	}
+	var zero V
+	return zero, false
+}
+
+// GetValue retrieves the value associated with the specified key, or the zero value if it is absent.
+func (d Dictionary[K, V]) GetValue(key K) V {
+	v, _ := d.Lookup(key)
+	return v
+}
+
+// ContainsKey checks if the specified key is present.
+func (d Dictionary[K, V]) ContainsKey(key K) bool {
+	_, ok := d.Lookup(key)
+	return ok
+}
+
+// GetLength returns the number of entries.
+func (d Dictionary[K, V]) GetLength() int {
+	return d.size
+}
+
+// Set returns a new Dictionary in which key holds value. d is unchanged.
+//
+// Parameters:
+//   - key: The key for which the value needs to be set.
+//   - value: The value to be set for the given key.
+//
+// Returns:
+//   - Dictionary[K, V]: The new version.
+func (d Dictionary[K, V]) Set(key K, value V) Dictionary[K, V] {
+	root, added := set(d.root, 0, hashOf(key), dictionary.Entry[K, V]{Key: key, Value: value})
+	if added {
+		return Dictionary[K, V]{root: root, size: d.size + 1}
+	}
+	return Dictionary[K, V]{root: root, size: d.size}
+}
+
+// set returns a copy of n with e stored, and whether e's key is new.
+func set[K comparable, V any](n *node[K, V], depth int, h uint64, e dictionary.Entry[K, V]) (*node[K, V], bool) {
+	if n == nil {
+		n = &node[K, V]{}
+	}
+	if depth == maxDepth {
+		collided := make([]dictionary.Entry[K, V], len(n.collided), len(n.collided)+1)
+		copy(collided, n.collided)
+		for i := range collided {
+			if collided[i].Key == e.Key {
+				collided[i] = e
+				return &node[K, V]{collided: collided}, false
+			}
+		}
+		return &node[K, V]{collided: append(collided, e)}, true
+	}
+	bit := uint32(1) << index(h, depth)
+	pos := position(n.bitmap, bit)
+	if n.bitmap&bit == 0 {
+		slots := make([]slot[K, V], len(n.slots)+1)
+		copy(slots, n.slots[:pos])
+		slots[pos] = slot[K, V]{hash: h, entry: e}
+		copy(slots[pos+1:], n.slots[pos:])
+		return &node[K, V]{bitmap: n.bitmap | bit, slots: slots}, true
+	}
+	slots := make([]slot[K, V], len(n.slots))
+	copy(slots, n.slots)
+	s := slots[pos]
+	added := false
+	switch {
+	case s.sub != nil:
+		slots[pos].sub, added = set(s.sub, depth+1, h, e)
+	case s.entry.Key == e.Key:
+		slots[pos].entry = e
+	default:
+		// Push the existing entry one level down and add e beside it.
+		sub, _ := set(nil, depth+1, s.hash, s.entry)
+		sub, _ = set(sub, depth+1, h, e)
+		slots[pos] = slot[K, V]{sub: sub}
+		added = true
+	}
+	return &node[K, V]{bitmap: n.bitmap, slots: slots}, added
+}
+
+// Delete returns a new Dictionary without key. d is unchanged. If the key is absent, d
+// itself is returned.
+//
+// Returns:
+//   - Dictionary[K, V]: The new version.
+func (d Dictionary[K, V]) Delete(key K) Dictionary[K, V] {
+	root, removed := remove(d.root, 0, hashOf(key), key)
+	if !removed {
+		return d
+	}
+	return Dictionary[K, V]{root: root, size: d.size - 1}
+}
+
+// remove returns a copy of n without key, or nil if it becomes empty, and whether key was found.
+func remove[K comparable, V any](n *node[K, V], depth int, h uint64, key K) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	if depth == maxDepth {
+		for i, e := range n.collided {
+			if e.Key == key {
+				if len(n.collided) == 1 {
+					return nil, true
+				}
+				collided := make([]dictionary.Entry[K, V], 0, len(n.collided)-1)
+				collided = append(append(collided, n.collided[:i]...), n.collided[i+1:]...)
+				return &node[K, V]{collided: collided}, true
+			}
+		}
+		return n, false
+	}
+	bit := uint32(1) << index(h, depth)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	pos := position(n.bitmap, bit)
+	s := n.slots[pos]
+	var replacement *slot[K, V]
+	switch {
+	case s.sub != nil:
+		sub, removed := remove(s.sub, depth+1, h, key)
+		if !removed {
+			return n, false
+		}
+		if sub != nil {
+			replacement = &slot[K, V]{sub: sub}
+			// A sub-node left with a single entry collapses into that entry.
+			if len(sub.slots) == 1 && sub.slots[0].sub == nil {
+				replacement = &sub.slots[0]
+			}
+		}
+	case s.entry.Key != key:
+		return n, false
+	}
+	if replacement != nil {
+		slots := make([]slot[K, V], len(n.slots))
+		copy(slots, n.slots)
+		slots[pos] = *replacement
+		return &node[K, V]{bitmap: n.bitmap, slots: slots}, true
+	}
+	if len(n.slots) == 1 {
+		return nil, true
+	}
+	slots := make([]slot[K, V], 0, len(n.slots)-1)
+	slots = append(append(slots, n.slots[:pos]...), n.slots[pos+1:]...)
+	return &node[K, V]{bitmap: n.bitmap &^ bit, slots: slots}, true
+}
+
+// All returns an iterator over the entries, in an order that depends on the key hashes.
+//
+// Example:
+//
+//	for k, v := range snapshot.All() {
+//		fmt.Println(k, v)
+//	}
+func (d Dictionary[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var walk func(n *node[K, V]) bool
+		walk = func(n *node[K, V]) bool {
+			if n == nil {
+				return true
+			}
+			for _, e := range n.collided {
+				if !yield(e.Key, e.Value) {
+					return false
+				}
+			}
+			for _, s := range n.slots {
+				if s.sub != nil {
+					if !walk(s.sub) {
+						return false
+					}
+				} else if !yield(s.entry.Key, s.entry.Value) {
+					return false
+				}
+			}
+			return true
+		}
+		walk(d.root)
+	}
+}
+
+// GetKeys returns the keys in unspecified order.
+func (d Dictionary[K, V]) GetKeys() []K {
+	keys := make([]K, 0, d.size)
+	for k := range d.All() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// ToDictionary returns the entries as a plain, mutable Dictionary.
+func (d Dictionary[K, V]) ToDictionary() dictionary.Dictionary[K, V] {
+	out := dictionary.NewDictionaryWithCapacity[K, V](d.size)
+	for k, v := range d.All() {
+		out[k] = v
+	}
+	return out
+}
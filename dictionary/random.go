@@ -0,0 +1,187 @@
+package dictionary
+
+import (
+	"container/heap"
+	"math"
+	"math/rand/v2"
+)
+
+// RandomKey returns a key of the Dictionary chosen uniformly at random.
+// Go's map iteration order is not uniformly random, so the choice is made by drawing an
+// index from r and walking to it, which takes time linear in the size of the Dictionary.
+//
+// Parameters:
+//   - r: The source of randomness, or nil to use the global generator of math/rand/v2.
+//
+// Returns:
+//   - K: The chosen key, or the zero value if the Dictionary is empty.
+//   - bool: True if the Dictionary has at least one entry, false otherwise.
+//
+// Example:
+//
+//	backends := Dictionary[string, int]{"a": 1, "b": 2, "c": 3}
+//	key, ok := backends.RandomKey(rand.New(rand.NewPCG(1, 2)))
+func (d Dictionary[K, V]) RandomKey(r *rand.Rand) (K, bool) {
+	e, ok := d.RandomEntry(r)
+	return e.Key, ok
+}
+
+// RandomEntry returns an entry of the Dictionary chosen uniformly at random.
+// See RandomKey for how the choice is made.
+//
+// Parameters:
+//   - r: The source of randomness, or nil to use the global generator of math/rand/v2.
+//
+// Returns:
+//   - Entry[K, V]: The chosen entry, or the zero Entry if the Dictionary is empty.
+//   - bool: True if the Dictionary has at least one entry, false otherwise.
+//
+// Example:
+//
+//	backends := Dictionary[string, int]{"a": 1, "b": 2, "c": 3}
+//	entry, ok := backends.RandomEntry(nil)
+func (d Dictionary[K, V]) RandomEntry(r *rand.Rand) (Entry[K, V], bool) {
+	if len(d) == 0 {
+		return Entry[K, V]{}, false
+	}
+	i := intN(r, len(d))
+	for k, v := range d {
+		if i == 0 {
+			return Entry[K, V]{Key: k, Value: v}, true
+		}
+		i--
+	}
+	panic("unreachable")
+}
+
+// Sample returns a new Dictionary holding n entries of the current Dictionary chosen
+// uniformly at random without replacement, using reservoir sampling in a single pass.
+//
+// Parameters:
+//   - n: The number of entries to draw. If n is at least the length of the Dictionary, every
+//     entry is returned; if n is zero or negative, the result is empty.
+//   - r: The source of randomness, or nil to use the global generator of math/rand/v2.
+//
+// Returns:
+//   - Dictionary[K, V]: A new Dictionary with min(n, len) randomly chosen entries.
+//
+// Example:
+//
+//	users := Dictionary[string, int]{"a": 1, "b": 2, "c": 3, "d": 4}
+//	canary := users.Sample(2, nil) // canary will hold two of the four entries
+func (d Dictionary[K, V]) Sample(n int, r *rand.Rand) Dictionary[K, V] {
+	n = max(0, min(n, len(d)))
+	if n == len(d) {
+		return d.CopyDictionary()
+	}
+	reservoir := make([]Entry[K, V], 0, n)
+	seen := 0
+	for k, v := range d {
+		seen++
+		if len(reservoir) < n {
+			reservoir = append(reservoir, Entry[K, V]{Key: k, Value: v})
+			continue
+		}
+		if j := intN(r, seen); j < n {
+			reservoir[j] = Entry[K, V]{Key: k, Value: v}
+		}
+	}
+	return FromPairs(reservoir)
+}
+
+// WeightedSampler draws the keys of a Dictionary[K, float64] in random order without
+// replacement, where a key's chance of coming next is proportional to its weight among the
+// keys not yet drawn. It uses the Efraimidis–Spirakis method: every key gets the random
+// priority log(u)/w once, and keys are yielded by descending priority. Keys are produced
+// lazily, so stopping after the first few costs O(n + k log n) rather than a full sort.
+type WeightedSampler[K comparable] struct {
+	h sampleHeap[K]
+}
+
+// sampleItem is a key with its Efraimidis–Spirakis priority.
+type sampleItem[K comparable] struct {
+	key      K
+	priority float64
+}
+
+// sampleHeap is a max-heap of sampleItems by priority.
+type sampleHeap[K comparable] []sampleItem[K]
+
+func (h sampleHeap[K]) Len() int           { return len(h) }
+func (h sampleHeap[K]) Less(i, j int) bool { return h[i].priority > h[j].priority }
+func (h sampleHeap[K]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *sampleHeap[K]) Push(x any)        { *h = append(*h, x.(sampleItem[K])) }
+
+func (h *sampleHeap[K]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// WeightedSample returns a WeightedSampler over the keys of d, weighted by their values.
+// Keys whose weight is not a positive finite number are never drawn.
+//
+// Parameters:
+//   - d: The Dictionary mapping keys to weights.
+//   - r: The source of randomness, or nil to use the global generator of math/rand/v2.
+//
+// Returns:
+//   - *WeightedSampler[K]: A sampler positioned before the first key.
+//
+// Example:
+//
+//	backends := Dictionary[string, float64]{"a": 5, "b": 3, "c": 1}
+//	s := WeightedSample(backends, nil)
+//	for key, ok := s.Next(); ok; key, ok = s.Next() {
+//		if tryBackend(key) {
+//			break
+//		}
+//	}
+func WeightedSample[K comparable](d Dictionary[K, float64], r *rand.Rand) *WeightedSampler[K] {
+	h := make(sampleHeap[K], 0, len(d))
+	for k, w := range d {
+		if !(w > 0) || math.IsInf(w, 1) {
+			continue
+		}
+		// 1 - Float64 lies in (0, 1], keeping the logarithm finite.
+		u := 1 - float64Of(r)
+		h = append(h, sampleItem[K]{key: k, priority: math.Log(u) / w})
+	}
+	heap.Init(&h)
+	return &WeightedSampler[K]{h: h}
+}
+
+// Next returns the next sampled key.
+//
+// Returns:
+//   - K: The next key, or the zero value once every eligible key has been drawn.
+//   - bool: True if a key was returned, false once the sampler is exhausted.
+func (s *WeightedSampler[K]) Next() (K, bool) {
+	if len(s.h) == 0 {
+		var zero K
+		return zero, false
+	}
+	return heap.Pop(&s.h).(sampleItem[K]).key, true
+}
+
+// Remaining returns the number of keys not drawn yet.
+func (s *WeightedSampler[K]) Remaining() int {
+	return len(s.h)
+}
+
+// intN returns a random integer in [0, n) from r, or from the global generator if r is nil.
+func intN(r *rand.Rand, n int) int {
+	if r == nil {
+		return rand.IntN(n)
+	}
+	return r.IntN(n)
+}
+
+// float64Of returns a random float64 in [0, 1) from r, or from the global generator if r is nil.
+func float64Of(r *rand.Rand) float64 {
+	if r == nil {
+		return rand.Float64()
+	}
+	return r.Float64()
+}
@@ -0,0 +1,110 @@
+package dictionary
+
+import (
+	"iter"
+
+	"github.com/bhanurp/gotypes/collection"
+)
+
+// ReadOnlyDictionary is an immutable snapshot of a Dictionary. It has no mutating methods,
+// so the compiler rejects attempts to change it, and because its entries are never written
+// after Freeze returns it can be shared between goroutines without locks. The zero value is
+// an empty ReadOnlyDictionary.
+type ReadOnlyDictionary[K comparable, V any] struct {
+	entries Dictionary[K, V]
+}
+
+var _ collection.Map[string, int] = ReadOnlyDictionary[string, int]{}
+
+// Freeze returns a ReadOnlyDictionary holding a copy of the entries of the Dictionary.
+// Values are copied shallowly, so values that are pointers, slices or maps still share
+// their contents with the Dictionary; use DeepCopy first if that matters.
+//
+// Returns:
+//   - ReadOnlyDictionary[K, V]: An immutable snapshot of the current entries.
+//
+// Example:
+//
+//	var Defaults = Dictionary[string, int]{"timeout": 30, "retries": 3}.Freeze()
+//
+//	retries := Defaults.GetValue("retries") // 3
+//	// Defaults.SetValue("retries", 5) does not compile
+func (d Dictionary[K, V]) Freeze() ReadOnlyDictionary[K, V] {
+	return ReadOnlyDictionary[K, V]{entries: d.CopyDictionary()}
+}
+
+// GetValue retrieves the value associated with the specified key, or the zero value if it is absent.
+func (r ReadOnlyDictionary[K, V]) GetValue(key K) V {
+	return r.entries[key]
+}
+
+// Get retrieves the value associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - error: A *errs.KeyError wrapping errs.ErrKeyNotFound if the key is absent, nil otherwise.
+func (r ReadOnlyDictionary[K, V]) Get(key K) (V, error) {
+	return r.entries.Get(key)
+}
+
+// Lookup retrieves the value associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (r ReadOnlyDictionary[K, V]) Lookup(key K) (V, bool) {
+	v, ok := r.entries[key]
+	return v, ok
+}
+
+// ContainsKey checks if the specified key is present.
+func (r ReadOnlyDictionary[K, V]) ContainsKey(key K) bool {
+	return r.entries.ContainsKey(key)
+}
+
+// ContainsValue checks if any key maps to the specified value, compared as
+// Dictionary.ContainsValue compares values.
+func (r ReadOnlyDictionary[K, V]) ContainsValue(value V) bool {
+	return r.entries.ContainsValue(value)
+}
+
+// GetKeys returns the keys in unspecified order.
+func (r ReadOnlyDictionary[K, V]) GetKeys() []K {
+	return r.entries.GetKeys()
+}
+
+// GetValues returns the values in unspecified order.
+func (r ReadOnlyDictionary[K, V]) GetValues() []V {
+	return r.entries.GetValues()
+}
+
+// GetLength returns the number of entries.
+func (r ReadOnlyDictionary[K, V]) GetLength() int {
+	return len(r.entries)
+}
+
+// IsEmpty checks if the ReadOnlyDictionary has no entries.
+func (r ReadOnlyDictionary[K, V]) IsEmpty() bool {
+	return len(r.entries) == 0
+}
+
+// Entries returns an iterator over the entries, in unspecified order.
+func (r ReadOnlyDictionary[K, V]) Entries() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range r.entries {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Where returns a View of the entries for which pred returns true.
+func (r ReadOnlyDictionary[K, V]) Where(pred func(K, V) bool) View[K, V] {
+	return r.entries.Where(pred)
+}
+
+// ToDictionary returns a mutable copy of the entries.
+func (r ReadOnlyDictionary[K, V]) ToDictionary() Dictionary[K, V] {
+	return r.entries.CopyDictionary()
+}
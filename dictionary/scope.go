@@ -0,0 +1,89 @@
+package dictionary
+
+import (
+	"sync"
+)
+
+// Go's experimental arenas cannot hold maps, so a Scope cannot place its
+// Dictionaries in an arena. Instead it recycles them: Release clears every
+// Dictionary created in the scope and hands it back to the ScopePool, whose
+// next scopes reuse the already-grown maps instead of allocating new ones.
+
+// ScopePool recycles the Dictionaries of released Scopes. A ScopePool is usually
+// kept in a package-level variable and shared by all requests; it is safe for
+// concurrent use.
+type ScopePool[K comparable, V any] struct {
+	pool      sync.Pool
+	maxRetain int
+}
+
+// NewScopePool creates a ScopePool.
+//
+// Parameters:
+//   - maxRetain: Dictionaries that held more than maxRetain entries are dropped on
+//     release instead of being recycled, so one unusually large request does not pin
+//     memory forever. Zero or less means no limit.
+//
+// Returns:
+//   - A new ScopePool.
+//
+// Example:
+//
+//	var rowPool = NewScopePool[string, any](1024)
+func NewScopePool[K comparable, V any](maxRetain int) *ScopePool[K, V] {
+	return &ScopePool[K, V]{maxRetain: maxRetain}
+}
+
+// Scope starts a new scope drawing Dictionaries from the pool.
+//
+// Example:
+//
+//	func handle(w http.ResponseWriter, r *http.Request) {
+//		scope := rowPool.Scope()
+//		defer scope.Release()
+//		row := scope.New()
+//		// ... fill and use row for the duration of the request
+//	}
+func (p *ScopePool[K, V]) Scope() *Scope[K, V] {
+	return &Scope[K, V]{pool: p}
+}
+
+// Scope tracks the short-lived Dictionaries created for one unit of work, such as a
+// request, and releases them together. A Scope is not safe for concurrent use.
+type Scope[K comparable, V any] struct {
+	pool  *ScopePool[K, V]
+	dicts []Dictionary[K, V]
+}
+
+// New returns an empty Dictionary owned by the scope, reusing a recycled one when available.
+// The Dictionary must not be used after the scope is released.
+//
+// Returns:
+//   - An empty Dictionary.
+func (s *Scope[K, V]) New() Dictionary[K, V] {
+	d, ok := s.pool.pool.Get().(Dictionary[K, V])
+	if !ok {
+		d = make(Dictionary[K, V])
+	}
+	s.dicts = append(s.dicts, d)
+	return d
+}
+
+// Len returns the number of Dictionaries currently owned by the scope.
+func (s *Scope[K, V]) Len() int {
+	return len(s.dicts)
+}
+
+// Release clears every Dictionary owned by the scope and returns them to the pool.
+// Any reference to them kept past this point will observe them being emptied and reused.
+// The scope can be used again after Release.
+func (s *Scope[K, V]) Release() {
+	for i, d := range s.dicts {
+		if s.pool.maxRetain <= 0 || len(d) <= s.pool.maxRetain {
+			clear(d)
+			s.pool.pool.Put(d)
+		}
+		s.dicts[i] = nil
+	}
+	s.dicts = s.dicts[:0]
+}
@@ -0,0 +1,344 @@
+// Package shards exports a large Dictionary as a set of shard files partitioned by key
+// hash, together with a manifest, so a fleet can distribute a static dataset and each
+// node loads only its own partition.
+//
+// A key belongs to shard floor(h * n / 2^64), where h is the 64-bit FNV-1a hash of the
+// key's JSON encoding and n the number of shards. Shards therefore cover contiguous,
+// equally sized hash ranges, and the assignment is the same in every process and on
+// every platform. Shard files are gob-encoded Dictionaries named after their index and
+// checksum; the manifest is JSON and records the entry count and SHA-256 checksum of
+// every shard.
+package shards
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"math/bits"
+	"os"
+	"path/filepath"
+
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/errs"
+	"github.com/bhanurp/gotypes/profiling"
+)
+
+// ManifestFile is the name of the manifest written next to the shard files.
+const ManifestFile = "manifest.json"
+
+// formatVersion is the version of the layout written by Export.
+const formatVersion = 1
+
+// Manifest describes an exported Dictionary.
+type Manifest struct {
+	Version int     `json:"version"`
+	Hash    string  `json:"hash"`
+	Entries int     `json:"entries"`
+	Shards  []Shard `json:"shards"`
+}
+
+// Shard describes one shard file.
+type Shard struct {
+	Index   int    `json:"index"`
+	File    string `json:"file"`
+	Entries int    `json:"entries"`
+	// HashStart and HashEnd bound the key hashes held by the shard, inclusive.
+	HashStart uint64 `json:"hashStart"`
+	HashEnd   uint64 `json:"hashEnd"`
+	SHA256    string `json:"sha256"`
+}
+
+// ShardOf returns the shard a key belongs to when a Dictionary is split into n shards.
+//
+// Parameters:
+//   - key: The key to be placed.
+//   - n: The number of shards.
+//
+// Returns:
+//   - int: The shard index in [0, n).
+//   - error: An error if the key cannot be encoded as JSON, or one wrapping
+//     errs.ErrOutOfRange if n is not positive.
+//
+// Example:
+//
+//	shard, err := ShardOf("user-42", 16)
+func ShardOf[K comparable](key K, n int) (int, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("shards: %d shards: %w", n, errs.ErrOutOfRange)
+	}
+	h, err := keyHash(key)
+	if err != nil {
+		return 0, err
+	}
+	return shardOfHash(h, n), nil
+}
+
+// keyHash returns the FNV-1a hash of the JSON encoding of key.
+func keyHash(key any) (uint64, error) {
+	b, err := json.Marshal(key)
+	if err != nil {
+		return 0, fmt.Errorf("shards: hash key %v: %w", key, err)
+	}
+	h := fnv.New64a()
+	h.Write(b)
+	return h.Sum64(), nil
+}
+
+// shardOfHash maps a hash to its shard: floor(h * n / 2^64).
+func shardOfHash(h uint64, n int) int {
+	hi, _ := bits.Mul64(h, uint64(n))
+	return int(hi)
+}
+
+// hashRange returns the inclusive bounds of the hashes belonging to shard i of n.
+func hashRange(i, n int) (uint64, uint64) {
+	start := func(i int) uint64 {
+		// The smallest h with floor(h * n / 2^64) >= i is ceil(i * 2^64 / n).
+		q, r := bits.Div64(uint64(i), 0, uint64(n))
+		if r != 0 {
+			q++
+		}
+		return q
+	}
+	if i == n-1 {
+		return start(i), ^uint64(0)
+	}
+	return start(i), start(i+1) - 1
+}
+
+// Export splits d into n shard files written to dir, followed by the manifest. The
+// directory is created if needed. Exporting over an earlier export replaces it: readers
+// see either the old manifest or the new one, never a manifest whose shards are
+// incomplete, and shard files of the old export are removed once the new manifest is in
+// place.
+//
+// Parameters:
+//   - dir: The directory to write to.
+//   - d: The Dictionary to be exported. K must be encodable as JSON and K and V as gob.
+//   - n: The number of shards.
+//
+// Returns:
+//   - Manifest: The manifest that was written.
+//   - error: An error if n is not positive, a key or value cannot be encoded, or a file cannot be written.
+//
+// Example:
+//
+//	manifest, err := Export("/data/geoip", table, 64)
+func Export[K comparable, V any](dir string, d dictionary.Dictionary[K, V], n int) (m Manifest, err error) {
+	profiling.Do("shards.Export", func() int {
+		m, err = export(dir, d, n)
+		return len(d)
+	})
+	return m, err
+}
+
+// export implements Export.
+func export[K comparable, V any](dir string, d dictionary.Dictionary[K, V], n int) (Manifest, error) {
+	if n <= 0 {
+		return Manifest{}, fmt.Errorf("shards: %d shards: %w", n, errs.ErrOutOfRange)
+	}
+	parts := make([]dictionary.Dictionary[K, V], n)
+	for i := range parts {
+		parts[i] = dictionary.DefaultDictionary[K, V]()
+	}
+	for k, v := range d {
+		h, err := keyHash(k)
+		if err != nil {
+			return Manifest{}, err
+		}
+		parts[shardOfHash(h, n)][k] = v
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return Manifest{}, fmt.Errorf("shards: %w", err)
+	}
+	m := Manifest{Version: formatVersion, Hash: "fnv1a64-json", Entries: len(d), Shards: make([]Shard, n)}
+	written := make(map[string]bool, n)
+	for i, part := range parts {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(part); err != nil {
+			return Manifest{}, fmt.Errorf("shards: encode shard %d: %w", i, err)
+		}
+		sum := sha256.Sum256(buf.Bytes())
+		checksum := hex.EncodeToString(sum[:])
+		// The checksum in the name keeps the shards of an earlier export, which its
+		// manifest still points to, from being overwritten.
+		name := fmt.Sprintf("shard-%05d-of-%05d-%s.gob", i, n, checksum[:16])
+		if err := writeFile(dir, name, buf.Bytes()); err != nil {
+			return Manifest{}, err
+		}
+		written[name] = true
+		lo, hi := hashRange(i, n)
+		m.Shards[i] = Shard{
+			Index: i, File: name, Entries: len(part),
+			HashStart: lo, HashEnd: hi,
+			SHA256: checksum,
+		}
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return Manifest{}, fmt.Errorf("shards: %w", err)
+	}
+	// The manifest goes last, once every shard it names is in place.
+	if err := writeFile(dir, ManifestFile, append(data, '\n')); err != nil {
+		return Manifest{}, err
+	}
+	if err := syncDir(dir); err != nil {
+		return Manifest{}, err
+	}
+	return m, removeStale(dir, written)
+}
+
+// writeFile writes data to a temporary file in dir, syncs it and renames it to name, so
+// the file named name is never seen incomplete.
+func writeFile(dir, name string, data []byte) error {
+	f, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("shards: %w", err)
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filepath.Join(dir, name))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("shards: %w", err)
+	}
+	return nil
+}
+
+// syncDir flushes the directory entries of dir, making the renames into it durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("shards: %w", err)
+	}
+	err = d.Sync()
+	if closeErr := d.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("shards: %w", err)
+	}
+	return nil
+}
+
+// removeStale deletes the shard files in dir that are not in keep, left by earlier exports.
+func removeStale(dir string, keep map[string]bool) error {
+	stale, err := filepath.Glob(filepath.Join(dir, "shard-*.gob"))
+	if err != nil {
+		return fmt.Errorf("shards: %w", err)
+	}
+	for _, path := range stale {
+		if keep[filepath.Base(path)] {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("shards: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReadManifest reads the manifest of an export.
+//
+// Parameters:
+//   - fsys: The file system holding the export, for example os.DirFS(dir).
+//
+// Returns:
+//   - Manifest: The manifest.
+//   - error: An error wrapping errs.ErrIncompatible if the manifest has an unknown version, or
+//     an error if it cannot be read.
+func ReadManifest(fsys fs.FS) (Manifest, error) {
+	data, err := fs.ReadFile(fsys, ManifestFile)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("shards: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Manifest{}, fmt.Errorf("shards: manifest: %w", err)
+	}
+	if m.Version != formatVersion {
+		return Manifest{}, fmt.Errorf("shards: manifest version %d: %w", m.Version, errs.ErrIncompatible)
+	}
+	return m, nil
+}
+
+// Load reads the selected shards of an export into one Dictionary, verifying each against
+// its manifest checksum. With no shard indexes, every shard is loaded.
+//
+// Parameters:
+//   - fsys: The file system holding the export, for example os.DirFS(dir).
+//   - shards: The indexes of the shards to load.
+//
+// Returns:
+//   - dictionary.Dictionary[K, V]: The entries of the selected shards.
+//   - error: An error wrapping errs.ErrOutOfRange for an unknown shard index, errs.ErrChecksumMismatch
+//     for a corrupt shard, or an error if a file cannot be read or decoded.
+//
+// Example:
+//
+//	m, _ := ReadManifest(os.DirFS("/data/geoip"))
+//	mine, err := Load[string, Location](os.DirFS("/data/geoip"), nodeIndex%len(m.Shards))
+func Load[K comparable, V any](fsys fs.FS, shards ...int) (d dictionary.Dictionary[K, V], err error) {
+	profiling.Do("shards.Load", func() int {
+		d, err = load[K, V](fsys, shards)
+		return len(d)
+	})
+	return d, err
+}
+
+// load implements Load.
+func load[K comparable, V any](fsys fs.FS, shards []int) (dictionary.Dictionary[K, V], error) {
+	m, err := ReadManifest(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if len(shards) == 0 {
+		shards = make([]int, len(m.Shards))
+		for i := range shards {
+			shards[i] = i
+		}
+	}
+	total := 0
+	for _, i := range shards {
+		if i < 0 || i >= len(m.Shards) {
+			return nil, fmt.Errorf("shards: shard %d of %d: %w", i, len(m.Shards), errs.ErrOutOfRange)
+		}
+		total += m.Shards[i].Entries
+	}
+
+	d := dictionary.NewDictionaryWithCapacity[K, V](total)
+	for _, i := range shards {
+		s := m.Shards[i]
+		data, err := fs.ReadFile(fsys, s.File)
+		if err != nil {
+			return nil, fmt.Errorf("shards: %w", err)
+		}
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != s.SHA256 {
+			return nil, fmt.Errorf("shards: %s: %w", s.File, errs.ErrChecksumMismatch)
+		}
+		var part dictionary.Dictionary[K, V]
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&part); err != nil {
+			return nil, fmt.Errorf("shards: decode %s: %w", s.File, err)
+		}
+		for k, v := range part {
+			d[k] = v
+		}
+	}
+	return d, nil
+}
@@ -0,0 +1,150 @@
+package dictionary
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+)
+
+// SortedKeys returns the keys of the Dictionary in ascending order.
+//
+// Parameters:
+//   - d: The Dictionary whose keys are to be returned.
+//
+// Returns:
+//   - []K: A sorted slice of keys.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"two": 2, "one": 1}
+//	keys := SortedKeys(dict) // keys will be ["one", "two"]
+func SortedKeys[K cmp.Ordered, V any](d Dictionary[K, V]) []K {
+	keys := d.GetKeys()
+	slices.Sort(keys)
+	return keys
+}
+
+// SortedEntries returns the entries of the Dictionary in ascending key order.
+//
+// Parameters:
+//   - d: The Dictionary whose entries are to be returned.
+//
+// Returns:
+//   - []Entry[K, V]: A slice of entries sorted by key.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"two": 2, "one": 1}
+//	entries := SortedEntries(dict) // entries will be [{one 1} {two 2}]
+func SortedEntries[K cmp.Ordered, V any](d Dictionary[K, V]) []Entry[K, V] {
+	entries := d.ToPairs()
+	slices.SortFunc(entries, func(a, b Entry[K, V]) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return entries
+}
+
+// SortedEntriesFunc returns the entries of the Dictionary ordered by the given less function.
+// Entries that less considers equal appear in unspecified order, so less should break ties
+// when a deterministic result is required.
+//
+// Parameters:
+//   - less: The function reporting whether entry a must sort before entry b.
+//
+// Returns:
+//   - []Entry[K, V]: A slice of entries sorted by less.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "two": 2}
+//	entries := dict.SortedEntriesFunc(func(a, b Entry[string, int]) bool {
+//		return a.Value > b.Value
+//	})
+//	// entries will be [{two 2} {one 1}]
+func (d Dictionary[K, V]) SortedEntriesFunc(less func(a, b Entry[K, V]) bool) []Entry[K, V] {
+	entries := d.ToPairs()
+	sort.Slice(entries, func(i, j int) bool {
+		return less(entries[i], entries[j])
+	})
+	return entries
+}
+
+// MinBy returns the entry whose value is smallest according to less, without sorting.
+// When several values are equally small, which of their entries is returned is unspecified.
+//
+// Parameters:
+//   - less: The function reporting whether value a is smaller than value b.
+//
+// Returns:
+//   - Entry[K, V]: The entry with the smallest value, or the zero Entry if the Dictionary is empty.
+//   - bool: True if the Dictionary has at least one entry, false otherwise.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "two": 2}
+//	entry, ok := dict.MinBy(func(a, b int) bool { return a < b }) // entry will be {one 1}, ok will be true
+func (d Dictionary[K, V]) MinBy(less func(a, b V) bool) (Entry[K, V], bool) {
+	var best Entry[K, V]
+	found := false
+	for k, v := range d {
+		if !found || less(v, best.Value) {
+			best = Entry[K, V]{Key: k, Value: v}
+			found = true
+		}
+	}
+	return best, found
+}
+
+// MaxBy returns the entry whose value is largest according to less, without sorting.
+// When several values are equally large, which of their entries is returned is unspecified.
+//
+// Parameters:
+//   - less: The function reporting whether value a is smaller than value b.
+//
+// Returns:
+//   - Entry[K, V]: The entry with the largest value, or the zero Entry if the Dictionary is empty.
+//   - bool: True if the Dictionary has at least one entry, false otherwise.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "two": 2}
+//	entry, ok := dict.MaxBy(func(a, b int) bool { return a < b }) // entry will be {two 2}, ok will be true
+func (d Dictionary[K, V]) MaxBy(less func(a, b V) bool) (Entry[K, V], bool) {
+	return d.MinBy(func(a, b V) bool { return less(b, a) })
+}
+
+// MinByValue returns the entry with the smallest value of a Dictionary with ordered values.
+// It is MinBy using the natural order of V.
+//
+// Parameters:
+//   - d: The Dictionary to be searched.
+//
+// Returns:
+//   - Entry[K, V]: The entry with the smallest value, or the zero Entry if the Dictionary is empty.
+//   - bool: True if the Dictionary has at least one entry, false otherwise.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "two": 2}
+//	entry, ok := MinByValue(dict) // entry will be {one 1}, ok will be true
+func MinByValue[K comparable, V cmp.Ordered](d Dictionary[K, V]) (Entry[K, V], bool) {
+	return d.MinBy(cmp.Less[V])
+}
+
+// MaxByValue returns the entry with the largest value of a Dictionary with ordered values.
+// It is MaxBy using the natural order of V.
+//
+// Parameters:
+//   - d: The Dictionary to be searched.
+//
+// Returns:
+//   - Entry[K, V]: The entry with the largest value, or the zero Entry if the Dictionary is empty.
+//   - bool: True if the Dictionary has at least one entry, false otherwise.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "two": 2}
+//	entry, ok := MaxByValue(dict) // entry will be {two 2}, ok will be true
+func MaxByValue[K comparable, V cmp.Ordered](d Dictionary[K, V]) (Entry[K, V], bool) {
+	return d.MaxBy(cmp.Less[V])
+}
@@ -0,0 +1,137 @@
+package sorteddictionary
+
+// First returns the entry with the smallest key, or false if the dictionary is empty.
+//
+// Example:
+//
+//	k, v, ok := d.First()
+func (d *SortedDictionary[K, V]) First() (K, V, bool) {
+	n := d.root
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return entryOf(n)
+}
+
+// Last returns the entry with the largest key, or false if the dictionary is empty.
+func (d *SortedDictionary[K, V]) Last() (K, V, bool) {
+	n := d.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return entryOf(n)
+}
+
+// PopFirst removes and returns the entry with the smallest key, or false if the dictionary is empty.
+//
+// Example:
+//
+//	for {
+//		t, job, ok := queue.PopFirst()
+//		if !ok || t.After(now) {
+//			break
+//		}
+//		run(job)
+//	}
+func (d *SortedDictionary[K, V]) PopFirst() (K, V, bool) {
+	k, v, ok := d.First()
+	if ok {
+		d.DeleteValue(k)
+	}
+	return k, v, ok
+}
+
+// PopLast removes and returns the entry with the largest key, or false if the dictionary is empty.
+func (d *SortedDictionary[K, V]) PopLast() (K, V, bool) {
+	k, v, ok := d.Last()
+	if ok {
+		d.DeleteValue(k)
+	}
+	return k, v, ok
+}
+
+// Floor returns the entry with the largest key less than or equal to key.
+//
+// Parameters:
+//   - key: The key to search from.
+//
+// Returns:
+//   - K: The key found.
+//   - V: Its value.
+//   - bool: True if such an entry exists, false otherwise.
+//
+// Example:
+//
+//	// With keys 10, 20 and 30:
+//	k, _, _ := d.Floor(25) // k will be 20
+func (d *SortedDictionary[K, V]) Floor(key K) (K, V, bool) {
+	return d.search(key, true, true)
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to key.
+//
+// Example:
+//
+//	// With keys 10, 20 and 30:
+//	k, _, _ := d.Ceiling(25) // k will be 30
+func (d *SortedDictionary[K, V]) Ceiling(key K) (K, V, bool) {
+	return d.search(key, false, true)
+}
+
+// Lower returns the entry with the largest key strictly less than key.
+//
+// Example:
+//
+//	// With keys 10, 20 and 30:
+//	k, _, _ := d.Lower(20) // k will be 10
+func (d *SortedDictionary[K, V]) Lower(key K) (K, V, bool) {
+	return d.search(key, true, false)
+}
+
+// Higher returns the entry with the smallest key strictly greater than key.
+//
+// Example:
+//
+//	// With keys 10, 20 and 30:
+//	k, _, _ := d.Higher(20) // k will be 30
+func (d *SortedDictionary[K, V]) Higher(key K) (K, V, bool) {
+	return d.search(key, false, false)
+}
+
+// search finds the closest key below (or above) key, including key itself if inclusive.
+func (d *SortedDictionary[K, V]) search(key K, below, inclusive bool) (K, V, bool) {
+	var best *node[K, V]
+	n := d.root
+	for n != nil {
+		c := d.compare(n.key, key)
+		if c == 0 && inclusive {
+			return entryOf(n)
+		}
+		if below {
+			if c < 0 {
+				best, n = n, n.right
+			} else {
+				n = n.left
+			}
+		} else {
+			if c > 0 {
+				best, n = n, n.left
+			} else {
+				n = n.right
+			}
+		}
+	}
+	return entryOf(best)
+}
+
+// entryOf returns the entry of n, or false if n is nil.
+func entryOf[K comparable, V any](n *node[K, V]) (K, V, bool) {
+	if n == nil {
+		var (
+			zk K
+			zv V
+		)
+		return zk, zv, false
+	}
+	return n.key, n.value, true
+}
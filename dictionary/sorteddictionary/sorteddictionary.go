@@ -0,0 +1,355 @@
+// Package sorteddictionary provides a Dictionary that keeps its keys sorted, backed by an
+// AVL tree, for in-order iteration and range scans that plain maps cannot do.
+package sorteddictionary
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/profiling"
+)
+
+// node is a node of the AVL tree.
+type node[K comparable, V any] struct {
+	key         K
+	value       V
+	left, right *node[K, V]
+	height      int8
+}
+
+// SortedDictionary is a Dictionary whose entries are ordered by key. Lookups, inserts and
+// deletes take O(log n) time, and iteration visits keys in ascending order of the
+// comparator. A SortedDictionary is not safe for concurrent use.
+type SortedDictionary[K comparable, V any] struct {
+	root    *node[K, V]
+	size    int
+	compare func(a, b K) int
+}
+
+var (
+	_ collection.OrderedMap[string, int]  = (*SortedDictionary[string, int])(nil)
+	_ collection.WritableMap[string, int] = (*SortedDictionary[string, int])(nil)
+)
+
+// New creates an empty SortedDictionary ordered by the natural order of K.
+//
+// Returns:
+//   - A new empty SortedDictionary.
+//
+// Example:
+//
+//	d := New[string, int]()
+//	d.SetValue("b", 2)
+//	d.SetValue("a", 1)
+//	d.GetKeys() // ["a", "b"]
+func New[K cmp.Ordered, V any]() *SortedDictionary[K, V] {
+	return NewFunc[K, V](cmp.Compare[K])
+}
+
+// NewFunc creates an empty SortedDictionary ordered by compare, which must return a
+// negative number, zero or a positive number as a sorts before, equal to or after b, and
+// must be consistent with == on K.
+//
+// Parameters:
+//   - compare: The function ordering the keys.
+//
+// Returns:
+//   - A new empty SortedDictionary.
+//
+// Example:
+//
+//	byTime := NewFunc[time.Time, Event](func(a, b time.Time) int { return a.Compare(b) })
+func NewFunc[K comparable, V any](compare func(a, b K) int) *SortedDictionary[K, V] {
+	return &SortedDictionary[K, V]{compare: compare}
+}
+
+// FromDictionary creates a SortedDictionary holding the entries of d, ordered by the
+// natural order of K.
+func FromDictionary[K cmp.Ordered, V any](d dictionary.Dictionary[K, V]) *SortedDictionary[K, V] {
+	s := New[K, V]()
+	profiling.Do("sorteddictionary.FromDictionary", func() int {
+		for k, v := range d {
+			s.SetValue(k, v)
+		}
+		return len(d)
+	})
+	return s
+}
+
+// GetValue retrieves the value associated with the specified key, or the zero value if it is absent.
+func (d *SortedDictionary[K, V]) GetValue(key K) V {
+	v, _ := d.Lookup(key)
+	return v
+}
+
+// Lookup retrieves the value associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (d *SortedDictionary[K, V]) Lookup(key K) (V, bool) {
+	if n := d.find(key); n != nil {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// ContainsKey checks if the specified key is present.
+func (d *SortedDictionary[K, V]) ContainsKey(key K) bool {
+	return d.find(key) != nil
+}
+
+// find returns the node of key, or nil.
+func (d *SortedDictionary[K, V]) find(key K) *node[K, V] {
+	n := d.root
+	for n != nil {
+		c := d.compare(key, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// SetValue sets the value for a given key.
+func (d *SortedDictionary[K, V]) SetValue(key K, value V) {
+	d.root = d.insert(d.root, key, value)
+}
+
+// insert adds or replaces key under n and returns the new root of the subtree.
+func (d *SortedDictionary[K, V]) insert(n *node[K, V], key K, value V) *node[K, V] {
+	if n == nil {
+		d.size++
+		return &node[K, V]{key: key, value: value, height: 1}
+	}
+	c := d.compare(key, n.key)
+	switch {
+	case c < 0:
+		n.left = d.insert(n.left, key, value)
+	case c > 0:
+		n.right = d.insert(n.right, key, value)
+	default:
+		n.value = value
+		return n
+	}
+	return rebalance(n)
+}
+
+// DeleteValue removes the specified key. If the key does not exist, nothing changes.
+func (d *SortedDictionary[K, V]) DeleteValue(key K) {
+	d.root, _ = d.remove(d.root, key)
+}
+
+// remove deletes key under n and returns the new root of the subtree and whether the key was found.
+func (d *SortedDictionary[K, V]) remove(n *node[K, V], key K) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var found bool
+	c := d.compare(key, n.key)
+	switch {
+	case c < 0:
+		n.left, found = d.remove(n.left, key)
+	case c > 0:
+		n.right, found = d.remove(n.right, key)
+	default:
+		d.size--
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		var successor *node[K, V]
+		n.right, successor = removeMin(n.right)
+		successor.left, successor.right = n.left, n.right
+		return rebalance(successor), true
+	}
+	if !found {
+		return n, false
+	}
+	return rebalance(n), true
+}
+
+// removeMin detaches the smallest node under n and returns the new root of the subtree and that node.
+func removeMin[K comparable, V any](n *node[K, V]) (*node[K, V], *node[K, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var smallest *node[K, V]
+	n.left, smallest = removeMin(n.left)
+	return rebalance(n), smallest
+}
+
+// height returns the height of n, which is 0 for nil.
+func height[K comparable, V any](n *node[K, V]) int8 {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// fix recomputes the height of n from its children.
+func fix[K comparable, V any](n *node[K, V]) {
+	n.height = max(height(n.left), height(n.right)) + 1
+}
+
+// rotateRight lifts the left child of n and returns it.
+func rotateRight[K comparable, V any](n *node[K, V]) *node[K, V] {
+	l := n.left
+	n.left, l.right = l.right, n
+	fix(n)
+	fix(l)
+	return l
+}
+
+// rotateLeft lifts the right child of n and returns it.
+func rotateLeft[K comparable, V any](n *node[K, V]) *node[K, V] {
+	r := n.right
+	n.right, r.left = r.left, n
+	fix(n)
+	fix(r)
+	return r
+}
+
+// rebalance restores the AVL invariant at n and returns the new root of the subtree.
+func rebalance[K comparable, V any](n *node[K, V]) *node[K, V] {
+	fix(n)
+	switch balance := height(n.left) - height(n.right); {
+	case balance > 1:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case balance < -1:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
+
+// Ascend calls fn for each entry in ascending key order until fn returns false.
+func (d *SortedDictionary[K, V]) Ascend(fn func(key K, value V) bool) {
+	d.All()(fn)
+}
+
+// Descend calls fn for each entry in descending key order until fn returns false.
+func (d *SortedDictionary[K, V]) Descend(fn func(key K, value V) bool) {
+	var walk func(n *node[K, V]) bool
+	walk = func(n *node[K, V]) bool {
+		return n == nil || walk(n.right) && fn(n.key, n.value) && walk(n.left)
+	}
+	walk(d.root)
+}
+
+// All returns an iterator over the entries in ascending key order. The dictionary must not
+// be modified during iteration.
+//
+// Example:
+//
+//	for k, v := range d.All() {
+//		fmt.Println(k, v)
+//	}
+func (d *SortedDictionary[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var walk func(n *node[K, V]) bool
+		walk = func(n *node[K, V]) bool {
+			return n == nil || walk(n.left) && yield(n.key, n.value) && walk(n.right)
+		}
+		walk(d.root)
+	}
+}
+
+// Range returns an iterator over the entries whose keys lie in the half-open interval
+// [lo, hi), in ascending key order. Subtrees outside the interval are skipped, so a scan
+// takes O(log n + m) time for m matching entries. The dictionary must not be modified
+// during iteration.
+//
+// Parameters:
+//   - lo: The inclusive lower bound.
+//   - hi: The exclusive upper bound.
+//
+// Returns:
+//   - iter.Seq2[K, V]: The entries in range.
+//
+// Example:
+//
+//	for t, e := range events.Range(start, end) {
+//		fmt.Println(t, e)
+//	}
+func (d *SortedDictionary[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var walk func(n *node[K, V]) bool
+		walk = func(n *node[K, V]) bool {
+			if n == nil {
+				return true
+			}
+			aboveLo := d.compare(n.key, lo) >= 0
+			belowHi := d.compare(n.key, hi) < 0
+			if aboveLo && !walk(n.left) {
+				return false
+			}
+			if aboveLo && belowHi && !yield(n.key, n.value) {
+				return false
+			}
+			return !belowHi || walk(n.right)
+		}
+		walk(d.root)
+	}
+}
+
+// GetKeys returns the keys in ascending order.
+func (d *SortedDictionary[K, V]) GetKeys() []K {
+	keys := make([]K, 0, d.size)
+	for k := range d.All() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// GetValues returns the values in ascending order of their keys.
+func (d *SortedDictionary[K, V]) GetValues() []V {
+	values := make([]V, 0, d.size)
+	for _, v := range d.All() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// GetLength returns the number of entries.
+func (d *SortedDictionary[K, V]) GetLength() int {
+	return d.size
+}
+
+// ClearDictionary removes every entry.
+func (d *SortedDictionary[K, V]) ClearDictionary() {
+	d.root, d.size = nil, 0
+}
+
+// ToEntries returns the entries in ascending key order.
+func (d *SortedDictionary[K, V]) ToEntries() []dictionary.Entry[K, V] {
+	entries := make([]dictionary.Entry[K, V], 0, d.size)
+	for k, v := range d.All() {
+		entries = append(entries, dictionary.Entry[K, V]{Key: k, Value: v})
+	}
+	return entries
+}
+
+// ToDictionary returns the entries as a plain, unordered Dictionary.
+func (d *SortedDictionary[K, V]) ToDictionary() dictionary.Dictionary[K, V] {
+	out := make(dictionary.Dictionary[K, V], d.size)
+	for k, v := range d.All() {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package dictionary
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/bhanurp/gotypes/errs"
+)
+
+var (
+	_ driver.Valuer = Dictionary[string, any](nil)
+	_ sql.Scanner   = (*Dictionary[string, any])(nil)
+)
+
+// Value implements driver.Valuer, so a Dictionary can be written to a JSON or JSONB column.
+// The Dictionary is encoded as by MarshalJSON and passed to the driver as a string, which
+// both PostgreSQL and MySQL accept for JSON columns.
+//
+// Returns:
+//   - driver.Value: The JSON text, or nil (SQL NULL) if the Dictionary is nil.
+//   - error: An error if the Dictionary cannot be encoded.
+//
+// Example:
+//
+//	attrs := Dictionary[string, any]{"color": "red"}
+//	_, err := db.Exec(`INSERT INTO items (id, attrs) VALUES ($1, $2)`, id, attrs)
+func (d Dictionary[K, V]) Value() (driver.Value, error) {
+	if d == nil {
+		return nil, nil
+	}
+	data, err := d.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner, so a JSON or JSONB column can be read into a Dictionary. It
+// replaces the contents of the Dictionary; SQL NULL sets it to nil.
+//
+// Parameters:
+//   - src: The column value, as []byte, string or nil.
+//
+// Returns:
+//   - error: An error wrapping errs.ErrUnsupportedType if src has another type, or an error if
+//     it is not a JSON object of the right types.
+//
+// Example:
+//
+//	var attrs Dictionary[string, any]
+//	err := db.QueryRow(`SELECT attrs FROM items WHERE id = $1`, id).Scan(&attrs)
+func (d *Dictionary[K, V]) Scan(src any) error {
+	var data []byte
+	switch s := src.(type) {
+	case nil:
+		*d = nil
+		return nil
+	case []byte:
+		data = s
+	case string:
+		data = []byte(s)
+	default:
+		return fmt.Errorf("dictionary: cannot scan %T into a Dictionary: %w", src, errs.ErrUnsupportedType)
+	}
+	var scanned Dictionary[K, V]
+	if err := scanned.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*d = scanned
+	return nil
+}
@@ -0,0 +1,134 @@
+// Package stringdict adds the lookups that string-keyed dictionaries such as HTTP headers,
+// environment variables and configuration keys need: case-insensitive access, prefix
+// queries and glob matching. The functions accept any key type whose underlying type is
+// string.
+package stringdict
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/bhanurp/gotypes/dictionary"
+)
+
+// GetFold retrieves the value of the key equal to key under Unicode case folding. An exact
+// match is preferred; otherwise, if several keys differ from key only by case, the smallest
+// of them is used so the result does not depend on map order. It takes linear time unless
+// the exact key is present.
+//
+// Parameters:
+//   - d: The Dictionary to be searched.
+//   - key: The key to look up, in any case.
+//
+// Returns:
+//   - V: The value of the matching key, or the zero value if there is none.
+//   - bool: True if a matching key was found, false otherwise.
+//
+// Example:
+//
+//	headers := dictionary.Dictionary[string, string]{"Content-Type": "text/plain"}
+//	v, ok := GetFold(headers, "content-type") // v will be "text/plain", ok will be true
+func GetFold[K ~string, V any](d dictionary.Dictionary[K, V], key string) (V, bool) {
+	k, ok := FoldKey(d, key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return d[k], true
+}
+
+// FoldKey returns the stored key that GetFold would use for key.
+//
+// Returns:
+//   - K: The stored key, or "" if there is none.
+//   - bool: True if a matching key was found, false otherwise.
+//
+// Example:
+//
+//	k, _ := FoldKey(headers, "CONTENT-TYPE") // k will be "Content-Type"
+func FoldKey[K ~string, V any](d dictionary.Dictionary[K, V], key string) (K, bool) {
+	if _, ok := d[K(key)]; ok {
+		return K(key), true
+	}
+	var (
+		best  K
+		found bool
+	)
+	for k := range d {
+		if strings.EqualFold(string(k), key) && (!found || k < best) {
+			best, found = k, true
+		}
+	}
+	return best, found
+}
+
+// KeysWithPrefix returns the keys that start with prefix, in ascending order.
+//
+// Parameters:
+//   - d: The Dictionary to be searched.
+//   - prefix: The prefix the keys must start with.
+//
+// Returns:
+//   - []K: The sorted matching keys.
+//
+// Example:
+//
+//	env := dictionary.Dictionary[string, string]{"APP_PORT": "80", "APP_HOST": "x", "HOME": "/root"}
+//	keys := KeysWithPrefix(env, "APP_") // keys will be ["APP_HOST", "APP_PORT"]
+func KeysWithPrefix[K ~string, V any](d dictionary.Dictionary[K, V], prefix string) []K {
+	var keys []K
+	for k := range d {
+		if strings.HasPrefix(string(k), prefix) {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// WithPrefix returns a new Dictionary of the entries whose keys start with prefix, with
+// the prefix removed from the keys.
+//
+// Example:
+//
+//	app := WithPrefix(env, "APP_") // app will be {"HOST": "x", "PORT": "80"}
+func WithPrefix[K ~string, V any](d dictionary.Dictionary[K, V], prefix string) dictionary.Dictionary[K, V] {
+	out := make(dictionary.Dictionary[K, V])
+	for k, v := range d {
+		if rest, ok := strings.CutPrefix(string(k), prefix); ok {
+			out[K(rest)] = v
+		}
+	}
+	return out
+}
+
+// MatchGlob returns the keys matching the shell pattern, in ascending order. The syntax is
+// that of path.Match: '*' matches any run of characters other than '/', '?' matches one
+// such character, and '[...]' matches a character class.
+//
+// Parameters:
+//   - d: The Dictionary to be searched.
+//   - pattern: The glob pattern.
+//
+// Returns:
+//   - []K: The sorted matching keys.
+//   - error: An error wrapping path.ErrBadPattern if the pattern is malformed, nil otherwise.
+//
+// Example:
+//
+//	keys, err := MatchGlob(config, "db.*.host") // for example ["db.primary.host", "db.replica.host"]
+func MatchGlob[K ~string, V any](d dictionary.Dictionary[K, V], pattern string) ([]K, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("stringdict: pattern %q: %w", pattern, err)
+	}
+	var keys []K
+	for k := range d {
+		if ok, _ := path.Match(pattern, string(k)); ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys, nil
+}
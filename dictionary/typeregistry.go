@@ -0,0 +1,206 @@
+package dictionary
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// TypeRegistry names the concrete types that may be stored in a Dictionary[string, any], so
+// such Dictionaries can be cloned and serialized without losing the dynamic type of their
+// values. Plain JSON decoding turns every struct into map[string]any and every number into
+// float64; a TypeRegistry tags each encoded value with the registered name of its type and
+// restores that type on decoding.
+//
+// A new TypeRegistry already knows the predeclared types bool, string, the integer and
+// floating-point types and byte slices under their Go names. It is safe for concurrent use.
+type TypeRegistry struct {
+	mu     sync.RWMutex
+	byName map[string]reflect.Type
+	byType map[reflect.Type]string
+}
+
+// taggedValue is the encoding of a single value: its registered type name and its JSON
+// encoding. A nil value has an empty type name.
+type taggedValue struct {
+	Type  string          `json:"type,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+// NewTypeRegistry creates a TypeRegistry holding the predeclared types.
+//
+// Returns:
+//   - *TypeRegistry: A new TypeRegistry.
+//
+// Example:
+//
+//	reg := NewTypeRegistry()
+//	err := RegisterType[Point](reg, "point")
+func NewTypeRegistry() *TypeRegistry {
+	r := &TypeRegistry{
+		byName: make(map[string]reflect.Type),
+		byType: make(map[reflect.Type]string),
+	}
+	for _, sample := range []any{
+		false, "", []byte(nil),
+		int(0), int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0), uintptr(0),
+		float32(0), float64(0),
+	} {
+		t := reflect.TypeOf(sample)
+		r.byName[t.String()] = t
+		r.byType[t] = t.String()
+	}
+	return r
+}
+
+// RegisterType records T under the given name. Values of type T stored in a
+// Dictionary[string, any] are then encoded with that name and decoded back into T.
+// Names must be stable across the programs exchanging data, so they are chosen by the
+// caller rather than derived from the Go package path.
+//
+// Parameters:
+//   - r: The TypeRegistry to add the type to.
+//   - name: The name identifying T in encoded data.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrDuplicateKey if the name or the type is
+//     already registered, nil otherwise.
+//
+// Example:
+//
+//	type Point struct{ X, Y int }
+//	err := RegisterType[Point](reg, "point")
+func RegisterType[T any](r *TypeRegistry, name string) error {
+	t := reflect.TypeFor[T]()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byName[name]; ok {
+		return errs.NewKeyError("register type", name, errs.ErrDuplicateKey)
+	}
+	if existing, ok := r.byType[t]; ok {
+		return errs.NewKeyError("register type", name, fmt.Errorf("%w: %s is registered as %q", errs.ErrDuplicateKey, t, existing))
+	}
+	r.byName[name] = t
+	r.byType[t] = name
+	return nil
+}
+
+// nameOf returns the registered name of the dynamic type of v.
+func (r *TypeRegistry) nameOf(key string, v any) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	r.mu.RLock()
+	name, ok := r.byType[reflect.TypeOf(v)]
+	r.mu.RUnlock()
+	if !ok {
+		return "", errs.NewKeyError("lookup type", key, fmt.Errorf("dictionary: type %T is not registered: %w", v, errs.ErrUnsupportedType))
+	}
+	return name, nil
+}
+
+// Clone returns a deep copy of d, as DeepCopy does, after checking that every value is nil
+// or of a registered type. The copy holds values of the same concrete types as the original.
+//
+// Parameters:
+//   - d: The Dictionary to be copied.
+//
+// Returns:
+//   - Dictionary[string, any]: A deep copy of d.
+//   - error: A *errs.KeyError wrapping errs.ErrUnsupportedType naming the first key whose value
+//     has an unregistered type, nil otherwise.
+//
+// Example:
+//
+//	dict := Dictionary[string, any]{"origin": Point{0, 0}}
+//	copy, err := reg.Clone(dict)
+//	// copy["origin"] is a Point
+func (r *TypeRegistry) Clone(d Dictionary[string, any]) (Dictionary[string, any], error) {
+	for k, v := range d {
+		if _, err := r.nameOf(k, v); err != nil {
+			return nil, err
+		}
+	}
+	return d.DeepCopy(), nil
+}
+
+// Marshal encodes d as a JSON object whose members hold the registered type name and the
+// JSON encoding of each value, with members sorted by key.
+//
+// Parameters:
+//   - d: The Dictionary to be encoded.
+//
+// Returns:
+//   - []byte: The tagged JSON encoding of d, or null if d is nil.
+//   - error: A *errs.KeyError wrapping errs.ErrUnsupportedType if a value has an unregistered
+//     type, or an error if a value cannot be encoded.
+//
+// Example:
+//
+//	data, err := reg.Marshal(Dictionary[string, any]{"origin": Point{1, 2}})
+//	// data is {"origin":{"type":"point","value":{"X":1,"Y":2}}}
+func (r *TypeRegistry) Marshal(d Dictionary[string, any]) ([]byte, error) {
+	if d == nil {
+		return []byte("null"), nil
+	}
+	tagged := make(Dictionary[string, taggedValue], len(d))
+	for k, v := range d {
+		name, err := r.nameOf(k, v)
+		if err != nil {
+			return nil, err
+		}
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("dictionary: marshal value for key %q: %w", k, err)
+		}
+		tagged[k] = taggedValue{Type: name, Value: raw}
+	}
+	return json.Marshal(tagged)
+}
+
+// Unmarshal decodes data produced by Marshal, restoring each value as its registered type.
+//
+// Parameters:
+//   - data: The tagged JSON encoding of a Dictionary.
+//
+// Returns:
+//   - Dictionary[string, any]: The decoded Dictionary, or nil if data is null.
+//   - error: A *errs.KeyError wrapping errs.ErrKeyNotFound if a type name is not registered,
+//     or an error if data is malformed.
+//
+// Example:
+//
+//	dict, err := reg.Unmarshal(data)
+//	p := dict["origin"].(Point)
+func (r *TypeRegistry) Unmarshal(data []byte) (Dictionary[string, any], error) {
+	var tagged map[string]taggedValue
+	if err := json.Unmarshal(data, &tagged); err != nil {
+		return nil, fmt.Errorf("dictionary: %w", err)
+	}
+	if tagged == nil {
+		return nil, nil
+	}
+	d := make(Dictionary[string, any], len(tagged))
+	for k, tv := range tagged {
+		if tv.Type == "" {
+			d[k] = nil
+			continue
+		}
+		r.mu.RLock()
+		t, ok := r.byName[tv.Type]
+		r.mu.RUnlock()
+		if !ok {
+			return nil, errs.NewKeyError("lookup type", tv.Type, errs.ErrKeyNotFound)
+		}
+		ptr := reflect.New(t)
+		if err := json.Unmarshal(tv.Value, ptr.Interface()); err != nil {
+			return nil, fmt.Errorf("dictionary: unmarshal value for key %q: %w", k, err)
+		}
+		d[k] = ptr.Elem().Interface()
+	}
+	return d, nil
+}
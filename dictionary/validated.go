@@ -0,0 +1,176 @@
+package dictionary
+
+import (
+	"fmt"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// ValidatedDictionary is a Dictionary that checks every key and value before storing it,
+// so a config-style map can never hold an entry that breaks its rules. Rejected writes
+// leave the dictionary unchanged. A ValidatedDictionary is not safe for concurrent use.
+type ValidatedDictionary[K comparable, V any] struct {
+	entries       Dictionary[K, V]
+	validateKey   func(K) error
+	validateValue func(K, V) error
+}
+
+var _ collection.MutableMap[string, int] = (*ValidatedDictionary[string, int])(nil)
+
+// NewValidatedDictionary creates an empty ValidatedDictionary.
+//
+// Parameters:
+//   - validateKey: The function rejecting invalid keys with an error; nil accepts every key.
+//   - validateValue: The function rejecting invalid values for a key with an error; nil accepts every value.
+//
+// Returns:
+//   - A new empty ValidatedDictionary.
+//
+// Example:
+//
+//	limits := NewValidatedDictionary(
+//		func(k string) error {
+//			if k == "" {
+//				return errors.New("empty name")
+//			}
+//			return nil
+//		},
+//		func(k string, v int) error {
+//			if v < 0 {
+//				return fmt.Errorf("negative limit %d", v)
+//			}
+//			return nil
+//		},
+//	)
+//	err := limits.SetValue("conns", -1) // errors.Is(err, errs.ErrInvalidEntry) will be true
+func NewValidatedDictionary[K comparable, V any](validateKey func(K) error, validateValue func(K, V) error) *ValidatedDictionary[K, V] {
+	return &ValidatedDictionary[K, V]{
+		entries:       DefaultDictionary[K, V](),
+		validateKey:   validateKey,
+		validateValue: validateValue,
+	}
+}
+
+// Validate checks key and value against the rules without storing them.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrInvalidEntry and the rule's error if the entry
+//     is rejected, nil otherwise.
+func (d *ValidatedDictionary[K, V]) Validate(key K, value V) error {
+	return d.validate("validate", key, value)
+}
+
+// GetValue retrieves the value associated with the specified key, or the zero value if it is absent.
+func (d *ValidatedDictionary[K, V]) GetValue(key K) V {
+	return d.entries[key]
+}
+
+// Lookup retrieves the value associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (d *ValidatedDictionary[K, V]) Lookup(key K) (V, bool) {
+	v, ok := d.entries[key]
+	return v, ok
+}
+
+// ContainsKey checks if the specified key is present.
+func (d *ValidatedDictionary[K, V]) ContainsKey(key K) bool {
+	return d.entries.ContainsKey(key)
+}
+
+// SetValue sets the value for a given key if both pass validation.
+//
+// Parameters:
+//   - key: The key for which the value needs to be set.
+//   - value: The value to be set for the given key.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrInvalidEntry and the rule's error if the entry
+//     is rejected, nil otherwise.
+func (d *ValidatedDictionary[K, V]) SetValue(key K, value V) error {
+	if err := d.validate("set", key, value); err != nil {
+		return err
+	}
+	d.entries[key] = value
+	return nil
+}
+
+// Update replaces the value for a given key with the result of fn, if the result passes
+// validation. fn receives the current value (or the zero value) and whether the key was present.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrInvalidEntry and the rule's error if the new
+//     value is rejected, nil otherwise.
+func (d *ValidatedDictionary[K, V]) Update(key K, fn func(old V, exists bool) V) error {
+	old, ok := d.entries[key]
+	value := fn(old, ok)
+	if err := d.validate("update", key, value); err != nil {
+		return err
+	}
+	d.entries[key] = value
+	return nil
+}
+
+// MergeDictionaries sets every entry of d2. All entries are validated first, and if any is
+// rejected nothing is stored.
+//
+// Parameters:
+//   - d2: The entries to be merged.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrInvalidEntry and the rule's error for a
+//     rejected entry, nil otherwise.
+func (d *ValidatedDictionary[K, V]) MergeDictionaries(d2 Dictionary[K, V]) error {
+	for k, v := range d2 {
+		if err := d.validate("merge", k, v); err != nil {
+			return err
+		}
+	}
+	for k, v := range d2 {
+		d.entries[k] = v
+	}
+	return nil
+}
+
+// DeleteValue removes the specified key. If the key does not exist, nothing changes.
+func (d *ValidatedDictionary[K, V]) DeleteValue(key K) {
+	d.entries.DeleteValue(key)
+}
+
+// GetKeys returns the keys in unspecified order.
+func (d *ValidatedDictionary[K, V]) GetKeys() []K {
+	return d.entries.GetKeys()
+}
+
+// GetLength returns the number of entries.
+func (d *ValidatedDictionary[K, V]) GetLength() int {
+	return len(d.entries)
+}
+
+// ClearDictionary removes every entry.
+func (d *ValidatedDictionary[K, V]) ClearDictionary() {
+	d.entries.ClearDictionary()
+}
+
+// ToDictionary returns a copy of the entries as a plain Dictionary.
+func (d *ValidatedDictionary[K, V]) ToDictionary() Dictionary[K, V] {
+	return d.entries.CopyDictionary()
+}
+
+// validate applies the rules to one entry.
+func (d *ValidatedDictionary[K, V]) validate(op string, key K, value V) error {
+	if d.validateKey != nil {
+		if err := d.validateKey(key); err != nil {
+			return errs.NewKeyError(op, key, fmt.Errorf("%w: key: %w", errs.ErrInvalidEntry, err))
+		}
+	}
+	if d.validateValue != nil {
+		if err := d.validateValue(key, value); err != nil {
+			return errs.NewKeyError(op, key, fmt.Errorf("%w: value: %w", errs.ErrInvalidEntry, err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package dictionary
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// Versioned is a value together with the generation of the write that stored it.
+type Versioned[V any] struct {
+	Value      V
+	Generation uint64
+}
+
+// VersionedDictionary stamps every entry with a generation so callers can update it with
+// optimistic concurrency control: read the value and its generation, compute the new value,
+// and write it back with SetIfGeneration, which fails if another writer got there first.
+//
+// Generations are drawn from a single counter for the whole dictionary and are never
+// reused, so an entry that is deleted and recreated cannot be mistaken for the original.
+// Generation 0 stands for "absent". A VersionedDictionary is safe for concurrent use.
+type VersionedDictionary[K comparable, V any] struct {
+	mu      sync.RWMutex
+	entries Dictionary[K, Versioned[V]]
+	gen     uint64
+}
+
+var _ collection.Map[string, int] = (*VersionedDictionary[string, int])(nil)
+
+// NewVersionedDictionary creates an empty VersionedDictionary.
+//
+// Returns:
+//   - A new empty VersionedDictionary.
+//
+// Example:
+//
+//	balances := NewVersionedDictionary[string, int]()
+//	for {
+//		v, gen, _ := balances.Get("alice")
+//		if _, err := balances.SetIfGeneration("alice", v+10, gen); err == nil {
+//			break
+//		}
+//		// errors.Is(err, errs.ErrVersionConflict): someone else wrote first, retry
+//	}
+func NewVersionedDictionary[K comparable, V any]() *VersionedDictionary[K, V] {
+	return &VersionedDictionary[K, V]{entries: DefaultDictionary[K, Versioned[V]]()}
+}
+
+// Get retrieves the value and generation associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - uint64: The generation of the value, or 0 if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (d *VersionedDictionary[K, V]) Get(key K) (V, uint64, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	e, ok := d.entries[key]
+	return e.Value, e.Generation, ok
+}
+
+// Lookup retrieves the value associated with the specified key, without its generation.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent.
+//   - bool: True if the key is present, false otherwise.
+func (d *VersionedDictionary[K, V]) Lookup(key K) (V, bool) {
+	v, _, ok := d.Get(key)
+	return v, ok
+}
+
+// ContainsKey checks if the specified key is present.
+func (d *VersionedDictionary[K, V]) ContainsKey(key K) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, ok := d.entries[key]
+	return ok
+}
+
+// Generation returns the current generation of the specified key, or 0 if it is absent.
+func (d *VersionedDictionary[K, V]) Generation(key K) uint64 {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.entries[key].Generation
+}
+
+// SetValue stores value under key unconditionally.
+//
+// Returns:
+//   - uint64: The generation of the new value.
+func (d *VersionedDictionary[K, V]) SetValue(key K, value V) uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.store(key, value)
+}
+
+// SetIfGeneration stores value under key only if the key's current generation equals expected.
+// Pass 0 to require that the key is absent.
+//
+// Parameters:
+//   - key: The key for which the value needs to be set.
+//   - value: The value to be set for the given key.
+//   - expected: The generation the caller last observed.
+//
+// Returns:
+//   - uint64: The generation of the new value, or the current generation on conflict.
+//   - error: A *errs.KeyError wrapping errs.ErrVersionConflict if the generation differs, nil otherwise.
+func (d *VersionedDictionary[K, V]) SetIfGeneration(key K, value V, expected uint64) (uint64, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.check("set", key, expected); err != nil {
+		return d.entries[key].Generation, err
+	}
+	return d.store(key, value), nil
+}
+
+// DeleteValue removes the specified key unconditionally.
+func (d *VersionedDictionary[K, V]) DeleteValue(key K) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries.DeleteValue(key)
+}
+
+// DeleteIfGeneration removes the specified key only if its current generation equals expected.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrVersionConflict if the generation differs, nil otherwise.
+func (d *VersionedDictionary[K, V]) DeleteIfGeneration(key K, expected uint64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.check("delete", key, expected); err != nil {
+		return err
+	}
+	d.entries.DeleteValue(key)
+	return nil
+}
+
+// GetKeys returns the keys in unspecified order.
+func (d *VersionedDictionary[K, V]) GetKeys() []K {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.entries.GetKeys()
+}
+
+// GetLength returns the number of entries.
+func (d *VersionedDictionary[K, V]) GetLength() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.entries.GetLength()
+}
+
+// Snapshot returns a copy of every entry with its generation.
+func (d *VersionedDictionary[K, V]) Snapshot() Dictionary[K, Versioned[V]] {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.entries.CopyDictionary()
+}
+
+// check verifies the expected generation of key. The caller must hold d.mu.
+func (d *VersionedDictionary[K, V]) check(op string, key K, expected uint64) error {
+	if current := d.entries[key].Generation; current != expected {
+		return errs.NewKeyError(op, key, fmt.Errorf("generation %d, expected %d: %w", current, expected, errs.ErrVersionConflict))
+	}
+	return nil
+}
+
+// store writes value under key with a fresh generation. The caller must hold d.mu.
+func (d *VersionedDictionary[K, V]) store(key K, value V) uint64 {
+	d.gen++
+	d.entries[key] = Versioned[V]{Value: value, Generation: d.gen}
+	return d.gen
+}
@@ -0,0 +1,118 @@
+package dictionary
+
+import (
+	"iter"
+
+	"github.com/bhanurp/gotypes/collection"
+)
+
+// View is a read-only, filtered projection of a Dictionary. It holds no entries of its own:
+// every read consults the underlying Dictionary and the predicate, so later changes to the
+// Dictionary show through and creating a View costs nothing regardless of size. Operations
+// that visit every entry, such as GetLength, take linear time.
+type View[K comparable, V any] struct {
+	d    Dictionary[K, V]
+	pred func(K, V) bool
+}
+
+var _ collection.Map[string, int] = View[string, int]{}
+
+// Where returns a View of the entries for which pred returns true.
+//
+// Parameters:
+//   - pred: The predicate selecting the visible entries.
+//
+// Returns:
+//   - View[K, V]: A live view of the matching entries.
+//
+// Example:
+//
+//	prices := Dictionary[string, int]{"apple": 3, "truffle": 900}
+//	cheap := prices.Where(func(_ string, p int) bool { return p < 10 })
+//	cheap.ContainsKey("truffle") // false
+//	prices["bread"] = 2
+//	cheap.GetLength() // 2
+func (d Dictionary[K, V]) Where(pred func(K, V) bool) View[K, V] {
+	return View[K, V]{d: d, pred: pred}
+}
+
+// Where narrows the View to the entries that also satisfy pred.
+//
+// Example:
+//
+//	cheapFruit := cheap.Where(func(name string, _ int) bool { return isFruit(name) })
+func (v View[K, V]) Where(pred func(K, V) bool) View[K, V] {
+	outer := v.pred
+	return View[K, V]{d: v.d, pred: func(k K, val V) bool { return outer(k, val) && pred(k, val) }}
+}
+
+// GetValue retrieves the value associated with the specified key, or the zero value if the
+// key is absent or filtered out.
+func (v View[K, V]) GetValue(key K) V {
+	val, _ := v.Lookup(key)
+	return val
+}
+
+// Lookup retrieves the value associated with the specified key.
+//
+// Returns:
+//   - V: The value, or the zero value if the key is absent or filtered out.
+//   - bool: True if the key is visible in the View, false otherwise.
+func (v View[K, V]) Lookup(key K) (V, bool) {
+	val, ok := v.d[key]
+	if !ok || !v.pred(key, val) {
+		var zero V
+		return zero, false
+	}
+	return val, true
+}
+
+// ContainsKey checks if the specified key is visible in the View.
+func (v View[K, V]) ContainsKey(key K) bool {
+	_, ok := v.Lookup(key)
+	return ok
+}
+
+// Entries returns an iterator over the visible entries, in unspecified order.
+//
+// Example:
+//
+//	for name, price := range cheap.Entries() {
+//		fmt.Println(name, price)
+//	}
+func (v View[K, V]) Entries() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, val := range v.d {
+			if v.pred(k, val) && !yield(k, val) {
+				return
+			}
+		}
+	}
+}
+
+// GetKeys returns the visible keys in unspecified order.
+func (v View[K, V]) GetKeys() []K {
+	var keys []K
+	for k := range v.Entries() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// GetLength returns the number of visible entries. It takes linear time.
+func (v View[K, V]) GetLength() int {
+	n := 0
+	for range v.Entries() {
+		n++
+	}
+	return n
+}
+
+// ToDictionary copies the visible entries into a new Dictionary.
+func (v View[K, V]) ToDictionary() Dictionary[K, V] {
+	out := make(Dictionary[K, V])
+	for k, val := range v.Entries() {
+		out[k] = val
+	}
+	return out
+}
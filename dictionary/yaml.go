@@ -0,0 +1,51 @@
+package dictionary
+
+// The YAML methods below follow the interfaces of gopkg.in/yaml.v2 and
+// gopkg.in/yaml.v3 (which still honors the v2-style unmarshaler), so this
+// package does not need to depend on a YAML library itself.
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// The Dictionary is encoded as a YAML mapping; the YAML libraries sort the keys,
+// so the output is deterministic.
+//
+// Returns:
+//   - any: The plain map to be encoded in place of the Dictionary.
+//   - error: Always nil.
+//
+// Example:
+//
+//	dict := Dictionary[string, int]{"one": 1, "two": 2}
+//	out, _ := yaml.Marshal(dict)
+//	// out is "one: 1\ntwo: 2\n"
+func (d Dictionary[K, V]) MarshalYAML() (any, error) {
+	return map[K]V(d), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes a YAML mapping into the Dictionary. Entries are added to any already
+// present, and a nil Dictionary is allocated first.
+//
+// Parameters:
+//   - unmarshal: The function supplied by the YAML library to decode the node.
+//
+// Returns:
+//   - error: An error if the node is not a mapping or its keys or values cannot be decoded.
+//
+// Example:
+//
+//	var dict Dictionary[int, string]
+//	err := yaml.Unmarshal([]byte("1: one\n2: two\n"), &dict)
+//	// dict is Dictionary[int, string]{1: "one", 2: "two"}
+func (d *Dictionary[K, V]) UnmarshalYAML(unmarshal func(any) error) error {
+	var m map[K]V
+	if err := unmarshal(&m); err != nil {
+		return err
+	}
+	if *d == nil {
+		*d = make(Dictionary[K, V], len(m))
+	}
+	for k, v := range m {
+		(*d)[k] = v
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Package dp provides helpers for memoized recursive algorithms such as
+// dynamic programming solutions.
+package dp
+
+import (
+	"fmt"
+
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// Options bounds the resources a Memo may use. Zero values mean unlimited.
+type Options struct {
+	// MaxDepth limits how deeply Solve may recurse.
+	MaxDepth int
+	// MaxSize limits how many results the Memo may cache.
+	MaxSize int
+}
+
+// Func computes the value for key. It must obtain sub-results through recurse,
+// never by calling itself directly, so that they are memoized and checked for cycles.
+type Func[K comparable, V any] func(key K, recurse func(K) V) V
+
+// Memo caches the results of a recursive function. Results are kept across calls to
+// Solve, so one Memo should only ever be used with a single function.
+// A Memo is not safe for concurrent use.
+type Memo[K comparable, V any] struct {
+	fn      Func[K, V]
+	opts    Options
+	results dictionary.Dictionary[K, V]
+	active  dictionary.Dictionary[K, struct{}]
+	depth   int
+}
+
+// New creates a Memo for fn.
+//
+// Parameters:
+//   - fn: The recursive function to be memoized.
+//   - opts: Optional bounds on recursion depth and cache size.
+//
+// Returns:
+//   - A new Memo with an empty cache.
+//
+// Example:
+//
+//	fib := New(func(n int, recurse func(int) int) int {
+//		if n < 2 {
+//			return n
+//		}
+//		return recurse(n-1) + recurse(n-2)
+//	}, Options{})
+//	v, err := fib.Solve(90) // v will be 2880067194370816120
+func New[K comparable, V any](fn Func[K, V], opts Options) *Memo[K, V] {
+	return &Memo[K, V]{
+		fn:      fn,
+		opts:    opts,
+		results: dictionary.DefaultDictionary[K, V](),
+		active:  dictionary.DefaultDictionary[K, struct{}](),
+	}
+}
+
+// abort carries an error out of a recursion through a panic.
+type abort struct {
+	err error
+}
+
+// Solve returns the value for key, computing and caching it and any sub-results it needs.
+// If the computation fails, results cached before the failure are kept.
+//
+// Parameters:
+//   - key: The key to be solved.
+//
+// Returns:
+//   - V: The value for key, or the zero value on error.
+//   - error: A *errs.KeyError wrapping errs.ErrCycleDetected if the recursion revisits a key it
+//     is still computing, or errs.ErrCapacityExceeded if MaxDepth or MaxSize is exceeded; nil otherwise.
+func (m *Memo[K, V]) Solve(key K) (v V, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// The recursion was unwound, so no key is still being computed, whether fn
+			// failed or panicked; the Memo stays usable by a caller that recovers.
+			m.active.ClearDictionary()
+			m.depth = 0
+			a, ok := r.(abort)
+			if !ok {
+				panic(r)
+			}
+			var zero V
+			v, err = zero, a.err
+		}
+	}()
+	return m.solve(key), nil
+}
+
+// solve computes key, panicking with abort on failure.
+func (m *Memo[K, V]) solve(key K) V {
+	if v, ok := m.results[key]; ok {
+		return v
+	}
+	if m.active.ContainsKey(key) {
+		panic(abort{errs.NewKeyError("solve", key, errs.ErrCycleDetected)})
+	}
+	if m.opts.MaxDepth > 0 && m.depth >= m.opts.MaxDepth {
+		panic(abort{errs.NewKeyError("solve", key, fmt.Errorf("max depth %d: %w", m.opts.MaxDepth, errs.ErrCapacityExceeded))})
+	}
+
+	m.active[key] = struct{}{}
+	m.depth++
+	v := m.fn(key, m.solve)
+	m.depth--
+	m.active.DeleteValue(key)
+
+	if m.opts.MaxSize > 0 && len(m.results) >= m.opts.MaxSize {
+		panic(abort{errs.NewKeyError("solve", key, fmt.Errorf("max size %d: %w", m.opts.MaxSize, errs.ErrCapacityExceeded))})
+	}
+	m.results[key] = v
+	return v
+}
+
+// Get returns the cached value for key without computing it.
+//
+// Returns:
+//   - V: The cached value, or the zero value if key has not been solved.
+//   - bool: True if a value was cached, false otherwise.
+func (m *Memo[K, V]) Get(key K) (V, bool) {
+	v, ok := m.results[key]
+	return v, ok
+}
+
+// Len returns the number of cached results.
+func (m *Memo[K, V]) Len() int {
+	return m.results.GetLength()
+}
+
+// Reset discards every cached result.
+func (m *Memo[K, V]) Reset() {
+	m.results.ClearDictionary()
+}
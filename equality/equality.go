@@ -0,0 +1,341 @@
+// Package equality compares values deeply, as reflect.DeepEqual does, but with
+// semantics suited to application data:
+//
+//   - Types with an Equal method taking their own type, such as time.Time, are
+//     compared with it, so two instants in different locations are equal.
+//   - Comparators registered with Register take precedence for their type.
+//   - Struct fields tagged `equal:"-"` are ignored.
+//   - Floats can be compared with a tolerance, and NaN can be made equal to NaN.
+//
+// Types built only from booleans, numbers and strings are compared with ==,
+// skipping reflection entirely. Otherwise the rules of reflect.DeepEqual
+// apply: nil and empty slices or maps differ, functions are equal only when
+// both are nil, and cyclic pointers are handled.
+package equality
+
+import (
+	"math"
+	"reflect"
+	"sync"
+)
+
+// Equaler is implemented by types that define their own notion of equality.
+// Equal must be reflexive and symmetric.
+type Equaler[T any] interface {
+	Equal(other T) bool
+}
+
+// Option changes how values are compared.
+type Option func(*options)
+
+type options struct {
+	tolerance float64
+	nanEqual  bool
+}
+
+// FloatTolerance makes floating-point numbers, including the parts of complex numbers,
+// equal when they differ by at most tol.
+//
+// Parameters:
+//   - tol: The largest absolute difference still considered equal.
+//
+// Example:
+//
+//	Equal(0.1+0.2, 0.3, FloatTolerance(1e-9)) // true
+func FloatTolerance(tol float64) Option {
+	return func(o *options) { o.tolerance = math.Abs(tol) }
+}
+
+// NaNEqual makes NaN equal to NaN, which it never is under ==.
+//
+// Example:
+//
+//	Equal(math.NaN(), math.NaN(), NaNEqual()) // true
+func NaNEqual() Option {
+	return func(o *options) { o.nanEqual = true }
+}
+
+var (
+	registryMu  sync.RWMutex
+	comparators = make(map[reflect.Type]func(a, b reflect.Value) bool)
+)
+
+// Register sets the comparator used for values of type T wherever they occur, including
+// inside slices, maps and structs. It overrides an Equal method of T and replaces any
+// comparator registered for T before. Register is meant to be called from init functions.
+//
+// Parameters:
+//   - eq: The function reporting whether two values of type T are equal.
+//
+// Example:
+//
+//	equality.Register(func(a, b *regexp.Regexp) bool { return a.String() == b.String() })
+func Register[T any](eq func(a, b T) bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	comparators[reflect.TypeFor[T]()] = func(a, b reflect.Value) bool {
+		return eq(a.Interface().(T), b.Interface().(T))
+	}
+}
+
+// Equal reports whether a and b are deeply equal under the rules of this package.
+//
+// Parameters:
+//   - a: The first value.
+//   - b: The second value.
+//   - opts: Options changing the comparison.
+//
+// Returns:
+//   - bool: True if the values are equal, false otherwise.
+//
+// Example:
+//
+//	t1 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+//	t2 := t1.In(time.FixedZone("CET", 3600))
+//	reflect.DeepEqual(t1, t2) // false
+//	Equal(t1, t2)            // true
+func Equal[T any](a, b T, opts ...Option) bool {
+	return Func[T](opts...)(a, b)
+}
+
+// Func returns a function comparing values of type T, resolving the options and the fast
+// path once so it can be called repeatedly, for example for every value of a map.
+//
+// Parameters:
+//   - opts: Options changing the comparison.
+//
+// Returns:
+//   - func(a, b T) bool: The comparison function.
+//
+// Example:
+//
+//	eq := Func[[]float64](FloatTolerance(1e-6))
+//	same := eq(expected, actual)
+func Func[T any](opts ...Option) func(a, b T) bool {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	t := reflect.TypeFor[T]()
+	if o.tolerance == 0 && !o.nanEqual && plainComparable(t) && !hasComparator(t) {
+		return func(a, b T) bool { return any(a) == any(b) }
+	}
+	return func(a, b T) bool {
+		c := comparer{opts: o}
+		return c.equal(reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem())
+	}
+}
+
+// plainComparable reports whether == gives the result of this package for every value of t
+// when no options are set: t is built only from booleans, numbers and strings, has no Equal
+// method and no ignored fields.
+func plainComparable(t reflect.Type) bool {
+	if equalMethod(t).Func.IsValid() {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return plainComparable(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.Tag.Get("equal") == "-" || !plainComparable(f.Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// hasComparator reports whether a comparator is registered for t or any type it contains
+// by value.
+func hasComparator(t reflect.Type) bool {
+	registryMu.RLock()
+	_, ok := comparators[t]
+	registryMu.RUnlock()
+	if ok {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Array:
+		return hasComparator(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasComparator(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// equalMethod returns the method Equal(T) bool of t, or the zero Method if it has none.
+func equalMethod(t reflect.Type) reflect.Method {
+	if t.Kind() == reflect.Interface {
+		// Interface values are compared through their dynamic value instead.
+		return reflect.Method{}
+	}
+	m, ok := t.MethodByName("Equal")
+	if !ok {
+		return reflect.Method{}
+	}
+	// Method types of a reflect.Type include the receiver as first argument.
+	mt := m.Type
+	if mt.NumIn() != 2 || mt.In(1) != t || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool {
+		return reflect.Method{}
+	}
+	return m
+}
+
+// visit identifies a pair of pointers being compared, to stop at cycles.
+type visit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+// comparer holds the state of a single deep comparison.
+type comparer struct {
+	opts    options
+	visited map[visit]bool
+}
+
+func (c *comparer) equal(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+	t := a.Type()
+
+	if a.CanInterface() {
+		registryMu.RLock()
+		eq, ok := comparators[t]
+		registryMu.RUnlock()
+		if ok {
+			return eq(a, b)
+		}
+		if m := equalMethod(t); m.Func.IsValid() {
+			if (a.Kind() == reflect.Pointer || a.Kind() == reflect.Interface) && (a.IsNil() || b.IsNil()) {
+				return a.IsNil() == b.IsNil()
+			}
+			return m.Func.Call([]reflect.Value{a, b})[0].Bool()
+		}
+	}
+
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return c.floatEqual(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		x, y := a.Complex(), b.Complex()
+		return c.floatEqual(real(x), real(y)) && c.floatEqual(imag(x), imag(y))
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	case reflect.Func:
+		return a.IsNil() && b.IsNil()
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return c.equal(a.Elem(), b.Elem())
+	case reflect.Pointer:
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+		if a.IsNil() || b.IsNil() {
+			return false
+		}
+		if c.seen(a, b) {
+			return true
+		}
+		return c.equal(a.Elem(), b.Elem())
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if !c.equal(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice:
+		if a.IsNil() != b.IsNil() || a.Len() != b.Len() {
+			return false
+		}
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+		if c.seen(a, b) {
+			return true
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !c.equal(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() || a.Len() != b.Len() {
+			return false
+		}
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+		if c.seen(a, b) {
+			return true
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			v := b.MapIndex(iter.Key())
+			if !v.IsValid() || !c.equal(iter.Value(), v) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if t.Field(i).Tag.Get("equal") == "-" {
+				continue
+			}
+			if !c.equal(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// floatEqual compares two floats under the tolerance and NaN options.
+func (c *comparer) floatEqual(x, y float64) bool {
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return c.opts.nanEqual && math.IsNaN(x) && math.IsNaN(y)
+	}
+	return x == y || math.Abs(x-y) <= c.opts.tolerance
+}
+
+// seen records that a and b are being compared and reports whether they already were,
+// in which case the comparison is assumed to hold, as reflect.DeepEqual does for cycles.
+func (c *comparer) seen(a, b reflect.Value) bool {
+	if c.visited == nil {
+		c.visited = make(map[visit]bool)
+	}
+	v := visit{a.Pointer(), b.Pointer(), a.Type()}
+	if c.visited[v] {
+		return true
+	}
+	c.visited[v] = true
+	return false
+}
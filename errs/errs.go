@@ -0,0 +1,103 @@
+// Package errs defines the sentinel errors shared by the gotypes packages.
+//
+// Errors returned by the packages in this module wrap one of the sentinels
+// below, so callers should branch on them with errors.Is rather than by
+// comparing error values or messages. Errors concerning a particular key are
+// returned as a *KeyError, which can be retrieved with errors.As to inspect
+// the operation and key that failed.
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrKeyNotFound is returned when an operation requires a key that is not present.
+	ErrKeyNotFound = errors.New("key not found")
+
+	// ErrDuplicateKey is returned when an operation would overwrite a key that must be unique.
+	ErrDuplicateKey = errors.New("duplicate key")
+
+	// ErrCapacityExceeded is returned when an operation would grow a bounded collection past its limit.
+	ErrCapacityExceeded = errors.New("capacity exceeded")
+
+	// ErrClosed is returned when an operation is attempted on a collection that has been closed.
+	ErrClosed = errors.New("closed")
+
+	// ErrVersionConflict is returned when an optimistic update finds that the entry changed since it was read.
+	ErrVersionConflict = errors.New("version conflict")
+
+	// ErrLengthMismatch is returned when slices that must be paired up have different lengths.
+	ErrLengthMismatch = errors.New("length mismatch")
+
+	// ErrOutOfRange is returned when a value falls outside the range a type can represent.
+	ErrOutOfRange = errors.New("out of range")
+
+	// ErrNilCollection is returned when a mutating operation is called on a nil collection.
+	ErrNilCollection = errors.New("nil collection")
+
+	// ErrCycleDetected is returned when a recursive or graph operation revisits a key it is still resolving.
+	ErrCycleDetected = errors.New("cycle detected")
+
+	// ErrInvalidEntry is returned when a key or value is rejected by a validation rule.
+	ErrInvalidEntry = errors.New("invalid entry")
+
+	// ErrIncompatible is returned when two collections cannot be combined because their layouts differ.
+	ErrIncompatible = errors.New("incompatible")
+
+	// ErrChecksumMismatch is returned when stored data does not match the checksum recorded for it.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+
+	// ErrUnsupportedType is returned when a value of a Go type an operation cannot handle is given to it.
+	ErrUnsupportedType = errors.New("unsupported type")
+
+	// ErrUnsupportedKey is returned when a key cannot be used in a map, for example because it is not hashable.
+	ErrUnsupportedKey = errors.New("unsupported key")
+
+	// ErrMalformed is returned when encoded input is not valid in its format.
+	ErrMalformed = errors.New("malformed input")
+
+	// ErrTypeMismatch is returned when a value is accessed as a type other than the one it holds.
+	ErrTypeMismatch = errors.New("type mismatch")
+
+	// ErrUnsupportedMediaType is returned when no codec is registered for a content type.
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+	// ErrNotAcceptable is returned when no registered codec matches what a client accepts.
+	ErrNotAcceptable = errors.New("not acceptable")
+
+	// ErrInvalidPath is returned for a path that is empty or has an empty segment.
+	ErrInvalidPath = errors.New("invalid path")
+)
+
+// KeyError records a failed operation and the key it was performed on.
+//
+// Example:
+//
+//	_, err := dict.Get("missing")
+//	var keyErr *errs.KeyError
+//	if errors.As(err, &keyErr) {
+//		fmt.Println(keyErr.Key) // Output: missing
+//	}
+//	errors.Is(err, errs.ErrKeyNotFound) // true
+type KeyError struct {
+	Op  string
+	Key any
+	Err error
+}
+
+// NewKeyError returns a *KeyError for the given operation, key and underlying error.
+func NewKeyError(op string, key any, err error) *KeyError {
+	return &KeyError{Op: op, Key: key, Err: err}
+}
+
+// Error implements the error interface.
+func (e *KeyError) Error() string {
+	return fmt.Sprintf("%s %v: %v", e.Op, e.Key, e.Err)
+}
+
+// Unwrap returns the underlying error so errors.Is can match the sentinel.
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
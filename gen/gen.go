@@ -0,0 +1,272 @@
+// Package gen produces deterministic pseudo-random typed data for seeding tests and
+// benchmarks. Generators are plain functions of a *rand.Rand, so the same seed always
+// yields the same data, and they compose: slices, structs, Dictionaries, Sets and
+// SortedDictionaries are built from generators of their parts.
+package gen
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/dictionary/sorteddictionary"
+	"github.com/bhanurp/gotypes/errs"
+	"github.com/bhanurp/gotypes/set"
+)
+
+// Generator produces a value of type T from a source of randomness.
+type Generator[T any] func(r *rand.Rand) T
+
+// NewRand returns a random source seeded with seed, for reproducible generation.
+//
+// Example:
+//
+//	r := gen.NewRand(42)
+//	n := gen.IntRange(1, 6)(r)
+func NewRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+}
+
+// Take returns n values produced by g.
+//
+// Example:
+//
+//	ids := gen.Pattern("id-####").Take(r, 3)
+func (g Generator[T]) Take(r *rand.Rand, n int) []T {
+	out := make([]T, max(n, 0))
+	for i := range out {
+		out[i] = g(r)
+	}
+	return out
+}
+
+// Constant returns a generator that always produces v.
+func Constant[T any](v T) Generator[T] {
+	return func(*rand.Rand) T { return v }
+}
+
+// IntRange returns a generator of integers in the closed interval [lo, hi].
+// If lo is greater than hi, the bounds are swapped.
+func IntRange(lo, hi int) Generator[int] {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	span := uint64(hi) - uint64(lo) + 1
+	return func(r *rand.Rand) int {
+		if span == 0 { // the full range of int
+			return int(r.Uint64())
+		}
+		return lo + int(r.Uint64N(span))
+	}
+}
+
+// Float64Range returns a generator of floats in the half-open interval [lo, hi).
+func Float64Range(lo, hi float64) Generator[float64] {
+	return func(r *rand.Rand) float64 {
+		return lo + r.Float64()*(hi-lo)
+	}
+}
+
+// Bool returns a generator of booleans that are true with probability p.
+func Bool(p float64) Generator[bool] {
+	return func(r *rand.Rand) bool {
+		return r.Float64() < p
+	}
+}
+
+// OneOf returns a generator picking uniformly among values. It panics if values is empty.
+func OneOf[T any](values ...T) Generator[T] {
+	if len(values) == 0 {
+		panic("gen: OneOf needs at least one value")
+	}
+	return func(r *rand.Rand) T {
+		return values[r.IntN(len(values))]
+	}
+}
+
+// Alphabets for String.
+const (
+	Lower        = "abcdefghijklmnopqrstuvwxyz"
+	Upper        = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Digits       = "0123456789"
+	Alphanumeric = Lower + Upper + Digits
+)
+
+// String returns a generator of strings of runes drawn from alphabet, with a length in
+// the closed interval [minLen, maxLen]. It panics if alphabet is empty.
+//
+// Example:
+//
+//	names := gen.String(gen.Lower, 3, 8)
+func String(alphabet string, minLen, maxLen int) Generator[string] {
+	if alphabet == "" {
+		panic("gen: String needs a non-empty alphabet")
+	}
+	runes := []rune(alphabet)
+	length := IntRange(max(minLen, 0), max(maxLen, 0))
+	return func(r *rand.Rand) string {
+		var b strings.Builder
+		for range length(r) {
+			b.WriteRune(runes[r.IntN(len(runes))])
+		}
+		return b.String()
+	}
+}
+
+// Pattern returns a generator of strings shaped by pattern, in which '#' becomes a random
+// digit, '?' a random lowercase letter, '*' a random alphanumeric character, and every
+// other rune is copied. A backslash makes the following rune literal.
+//
+// Example:
+//
+//	phones := gen.Pattern("+1-###-###-####")
+//	skus := gen.Pattern("SKU-??##")
+func Pattern(pattern string) Generator[string] {
+	return func(r *rand.Rand) string {
+		var b strings.Builder
+		escaped := false
+		for _, c := range pattern {
+			if escaped {
+				b.WriteRune(c)
+				escaped = false
+				continue
+			}
+			switch c {
+			case '\\':
+				escaped = true
+			case '#':
+				b.WriteByte(Digits[r.IntN(len(Digits))])
+			case '?':
+				b.WriteByte(Lower[r.IntN(len(Lower))])
+			case '*':
+				b.WriteByte(Alphanumeric[r.IntN(len(Alphanumeric))])
+			default:
+				b.WriteRune(c)
+			}
+		}
+		return b.String()
+	}
+}
+
+// SliceOf returns a generator of slices whose length lies in [minLen, maxLen] and whose
+// elements are produced by elem.
+func SliceOf[T any](elem Generator[T], minLen, maxLen int) Generator[[]T] {
+	length := IntRange(max(minLen, 0), max(maxLen, 0))
+	return func(r *rand.Rand) []T {
+		return elem.Take(r, length(r))
+	}
+}
+
+// Field fills in part of a struct of type T.
+type Field[T any] func(r *rand.Rand, v *T)
+
+// FieldOf returns a Field that stores a value produced by g using assign.
+//
+// Example:
+//
+//	name := gen.FieldOf(func(u *User, s string) { u.Name = s }, gen.String(gen.Lower, 3, 10))
+func FieldOf[T, F any](assign func(v *T, f F), g Generator[F]) Field[T] {
+	return func(r *rand.Rand, v *T) {
+		assign(v, g(r))
+	}
+}
+
+// Struct returns a generator of T values built by applying fields, in order, to the zero value.
+//
+// Example:
+//
+//	users := gen.Struct(
+//		gen.FieldOf(func(u *User, s string) { u.Name = s }, gen.String(gen.Lower, 3, 10)),
+//		gen.FieldOf(func(u *User, n int) { u.Age = n }, gen.IntRange(18, 90)),
+//	)
+//	u := users(r)
+func Struct[T any](fields ...Field[T]) Generator[T] {
+	return func(r *rand.Rand) T {
+		var v T
+		for _, f := range fields {
+			f(r, &v)
+		}
+		return v
+	}
+}
+
+// maxAttemptsPerKey bounds how often Dictionary retries keys that were already produced.
+const maxAttemptsPerKey = 100
+
+// Dictionary returns a Dictionary with n entries whose keys and values are produced by the
+// given generators. Duplicate keys are redrawn.
+//
+// Parameters:
+//   - r: The source of randomness.
+//   - n: The number of entries.
+//   - keys: The generator of keys; it must be able to produce n distinct keys.
+//   - values: The generator of values.
+//
+// Returns:
+//   - dictionary.Dictionary[K, V]: The populated Dictionary.
+//   - error: An error wrapping errs.ErrCapacityExceeded if keys keeps producing duplicates
+//     before n distinct keys are found, nil otherwise.
+//
+// Example:
+//
+//	r := gen.NewRand(1)
+//	users, err := gen.Dictionary(r, 10_000, gen.Pattern("user-######"), gen.IntRange(18, 90))
+func Dictionary[K comparable, V any](r *rand.Rand, n int, keys Generator[K], values Generator[V]) (dictionary.Dictionary[K, V], error) {
+	d := dictionary.NewDictionaryWithCapacity[K, V](n)
+	for attempts := 0; len(d) < n; attempts++ {
+		if attempts >= n*maxAttemptsPerKey {
+			return d, fmt.Errorf("gen: only %d distinct keys of %d after %d attempts: %w", len(d), n, attempts, errs.ErrCapacityExceeded)
+		}
+		k := keys(r)
+		if _, dup := d[k]; dup {
+			continue
+		}
+		d[k] = values(r)
+	}
+	return d, nil
+}
+
+// SetOf returns a Set with n elements produced by elems. Duplicate elements are redrawn.
+//
+// Parameters:
+//   - r: The source of randomness.
+//   - n: The number of elements.
+//   - elems: The generator of elements; it must be able to produce n distinct elements.
+//
+// Returns:
+//   - set.Set[T]: The populated Set.
+//   - error: An error wrapping errs.ErrCapacityExceeded if elems keeps producing duplicates
+//     before n distinct elements are found, nil otherwise.
+//
+// Example:
+//
+//	tags, err := gen.SetOf(gen.NewRand(1), 50, gen.String(gen.Lower, 4, 8))
+func SetOf[T comparable](r *rand.Rand, n int, elems Generator[T]) (set.Set[T], error) {
+	d, err := Dictionary(r, n, elems, Constant(struct{}{}))
+	return set.Set[T](d), err
+}
+
+// SortedDictionary returns a SortedDictionary with n entries whose keys and values are
+// produced by the given generators. Duplicate keys are redrawn, as in Dictionary.
+//
+// Parameters:
+//   - r: The source of randomness.
+//   - n: The number of entries.
+//   - keys: The generator of keys; it must be able to produce n distinct keys.
+//   - values: The generator of values.
+//
+// Returns:
+//   - *sorteddictionary.SortedDictionary[K, V]: The populated SortedDictionary.
+//   - error: An error wrapping errs.ErrCapacityExceeded if keys keeps producing duplicates
+//     before n distinct keys are found, nil otherwise.
+//
+// Example:
+//
+//	r := gen.NewRand(1)
+//	prices, err := gen.SortedDictionary(r, 1_000, gen.Pattern("SKU-??##"), gen.Float64Range(1, 100))
+func SortedDictionary[K cmp.Ordered, V any](r *rand.Rand, n int, keys Generator[K], values Generator[V]) (*sorteddictionary.SortedDictionary[K, V], error) {
+	d, err := Dictionary(r, n, keys, values)
+	return sorteddictionary.FromDictionary(d), err
+}
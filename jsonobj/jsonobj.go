@@ -0,0 +1,278 @@
+// Package jsonobj provides Object, a JSON object that remembers the order of its
+// members, for tools that must read and rewrite JSON documents without
+// reordering keys.
+package jsonobj
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// DuplicatePolicy decides what UnmarshalJSON does with a member name that appears twice.
+type DuplicatePolicy int
+
+const (
+	// RejectDuplicates fails decoding with an error wrapping errs.ErrDuplicateKey.
+	RejectDuplicates DuplicatePolicy = iota
+	// KeepFirst ignores later occurrences of a name.
+	KeepFirst
+	// KeepLast uses the value of the last occurrence, at the position of the first one,
+	// which is how JavaScript's JSON.parse behaves.
+	KeepLast
+)
+
+// Object is a JSON object whose members keep the order in which they were decoded or added.
+// Member values are held as compact json.RawMessage, so nested objects can be decoded into
+// further Objects on demand, and marshaling an Object produces the same bytes every time.
+//
+// The zero value is an empty Object that rejects duplicate names. An Object is not safe for
+// concurrent use.
+type Object struct {
+	// Duplicates is the policy UnmarshalJSON applies to repeated member names.
+	Duplicates DuplicatePolicy
+
+	members []dictionary.Entry[string, json.RawMessage]
+	index   dictionary.Dictionary[string, int]
+}
+
+var (
+	_ collection.OrderedMap[string, json.RawMessage] = (*Object)(nil)
+	_ collection.MutableMap[string, json.RawMessage] = (*Object)(nil)
+)
+
+// Lookup returns the raw value of the named member.
+//
+// Returns:
+//   - json.RawMessage: The compact JSON encoding of the value, or nil if the member is absent.
+//   - bool: True if the member is present, false otherwise.
+func (o *Object) Lookup(key string) (json.RawMessage, bool) {
+	i, ok := o.index[key]
+	if !ok {
+		return nil, false
+	}
+	return o.members[i].Value, true
+}
+
+// Get decodes the value of the named member into the value pointed to by v.
+//
+// Parameters:
+//   - key: The member name.
+//   - v: A pointer to the destination.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrKeyNotFound if the member is absent, or the
+//     error from json.Unmarshal.
+//
+// Example:
+//
+//	var version int
+//	err := obj.Get("version", &version)
+func (o *Object) Get(key string, v any) error {
+	raw, ok := o.Lookup(key)
+	if !ok {
+		return errs.NewKeyError("get", key, errs.ErrKeyNotFound)
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// ContainsKey reports whether the named member is present.
+func (o *Object) ContainsKey(key string) bool {
+	_, ok := o.index[key]
+	return ok
+}
+
+// SetValue stores a raw JSON value under key. An existing member keeps its position;
+// a new member is appended.
+//
+// Parameters:
+//   - key: The member name.
+//   - value: The JSON encoding of the value. It is stored in compact form.
+//
+// Returns:
+//   - error: An error if value is not valid JSON, nil otherwise.
+func (o *Object) SetValue(key string, value json.RawMessage) error {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, value); err != nil {
+		return fmt.Errorf("jsonobj: value for key %q: %w", key, err)
+	}
+	o.store(key, buf.Bytes())
+	return nil
+}
+
+// Set encodes v with encoding/json and stores it under key, as SetValue does.
+//
+// Parameters:
+//   - key: The member name.
+//   - v: The value to encode.
+//
+// Returns:
+//   - error: An error if v cannot be encoded, nil otherwise.
+//
+// Example:
+//
+//	err := obj.Set("version", 2)
+func (o *Object) Set(key string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("jsonobj: value for key %q: %w", key, err)
+	}
+	return o.SetValue(key, raw)
+}
+
+// DeleteValue removes the named member. Later members move up one position, which takes
+// time linear in their number.
+func (o *Object) DeleteValue(key string) {
+	i, ok := o.index[key]
+	if !ok {
+		return
+	}
+	o.members = append(o.members[:i], o.members[i+1:]...)
+	o.index.DeleteValue(key)
+	for j := i; j < len(o.members); j++ {
+		o.index[o.members[j].Key] = j
+	}
+}
+
+// GetKeys returns the member names in order.
+func (o *Object) GetKeys() []string {
+	keys := make([]string, len(o.members))
+	for i, m := range o.members {
+		keys[i] = m.Key
+	}
+	return keys
+}
+
+// GetLength returns the number of members.
+func (o *Object) GetLength() int {
+	return len(o.members)
+}
+
+// First returns the first member, or false if the Object is empty.
+func (o *Object) First() (string, json.RawMessage, bool) {
+	if len(o.members) == 0 {
+		return "", nil, false
+	}
+	m := o.members[0]
+	return m.Key, m.Value, true
+}
+
+// Last returns the last member, or false if the Object is empty.
+func (o *Object) Last() (string, json.RawMessage, bool) {
+	if len(o.members) == 0 {
+		return "", nil, false
+	}
+	m := o.members[len(o.members)-1]
+	return m.Key, m.Value, true
+}
+
+// Ascend calls fn for each member in order until fn returns false.
+func (o *Object) Ascend(fn func(key string, value json.RawMessage) bool) {
+	for _, m := range o.members {
+		if !fn(m.Key, m.Value) {
+			return
+		}
+	}
+}
+
+// MarshalJSON implements json.Marshaler, writing the members in order without any
+// whitespace. Member names are escaped as encoding/json escapes strings.
+//
+// Returns:
+//   - []byte: The JSON encoding of the Object.
+//   - error: Always nil; member values were validated when they were stored.
+//
+// Example:
+//
+//	var obj Object
+//	_ = json.Unmarshal([]byte(`{"b": 1, "a": [2, 3]}`), &obj)
+//	data, _ := json.Marshal(&obj) // data is {"b":1,"a":[2,3]}
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range o.members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		// Encoding a string cannot fail.
+		key, _ := json.Marshal(m.Key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(m.Value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the members of the Object with those
+// of the JSON object in data, in their order of appearance. Repeated names are handled
+// according to o.Duplicates. As with encoding/json, a JSON null leaves the Object unchanged.
+//
+// Parameters:
+//   - data: The JSON encoding of an object, or null.
+//
+// Returns:
+//   - error: An error wrapping errs.ErrTypeMismatch if data holds another JSON value, an
+//     error if it is not valid JSON, or a *errs.KeyError wrapping errs.ErrDuplicateKey if a
+//     name repeats under RejectDuplicates.
+func (o *Object) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("jsonobj: %w", err)
+	}
+	if tok == nil {
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("jsonobj: expected object, found %v: %w", tok, errs.ErrTypeMismatch)
+	}
+
+	o.members = o.members[:0]
+	o.index = nil
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("jsonobj: %w", err)
+		}
+		key := tok.(string) // object keys are always strings
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return fmt.Errorf("jsonobj: value for key %q: %w", key, err)
+		}
+		if o.ContainsKey(key) {
+			switch o.Duplicates {
+			case KeepFirst:
+				continue
+			case KeepLast:
+			default:
+				return errs.NewKeyError("unmarshal", key, errs.ErrDuplicateKey)
+			}
+		}
+		var buf bytes.Buffer
+		// The decoder has validated raw, so compacting it cannot fail.
+		_ = json.Compact(&buf, raw)
+		o.store(key, buf.Bytes())
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("jsonobj: %w", err)
+	}
+	return nil
+}
+
+// store sets the value of a member, appending it if it is new.
+func (o *Object) store(key string, value json.RawMessage) {
+	if i, ok := o.index[key]; ok {
+		o.members[i].Value = value
+		return
+	}
+	if o.index == nil {
+		o.index = make(dictionary.Dictionary[string, int])
+	}
+	o.index[key] = len(o.members)
+	o.members = append(o.members, dictionary.Entry[string, json.RawMessage]{Key: key, Value: value})
+}
@@ -0,0 +1,310 @@
+// Package leaderboard ranks participants by score and answers rank, top-n and
+// neighbourhood queries, as needed by gaming and gamification backends.
+package leaderboard
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/profiling"
+)
+
+// TiePolicy decides how participants with equal scores are ordered and ranked.
+type TiePolicy int
+
+const (
+	// FirstReached ranks the participant who reached the score first higher.
+	// Every participant has a distinct rank.
+	FirstReached TiePolicy = iota
+	// LastReached ranks the participant who reached the score most recently higher.
+	// Every participant has a distinct rank.
+	LastReached
+	// SharedRank gives participants with equal scores the same rank, skipping the ranks
+	// after them ("1224" ranking). They are listed in the order they reached the score.
+	SharedRank
+)
+
+// Entry is a participant with their score and rank.
+type Entry[ID comparable] struct {
+	ID    ID
+	Score float64
+	// Rank is the 1-based position of the participant.
+	Rank int
+}
+
+// slot is a participant as stored in the ranking.
+type slot[ID comparable] struct {
+	id    ID
+	score float64
+	seq   uint64 // when the current score was reached
+}
+
+// Board keeps participants sorted by descending score, with an index from ID to their
+// position. Rank queries take O(log n); score updates take O(n) to shift the ranking.
+// A Board is safe for concurrent use.
+type Board[ID comparable] struct {
+	mu     sync.RWMutex
+	policy TiePolicy
+	ranked []slot[ID]
+	index  dictionary.Dictionary[ID, slot[ID]]
+	seq    uint64
+}
+
+// New creates an empty Board using the given tie policy.
+//
+// Returns:
+//   - A new empty Board.
+//
+// Example:
+//
+//	b := New[string](FirstReached)
+//	b.SetScore("ann", 120)
+//	b.SetScore("bob", 95)
+//	rank, _ := b.Rank("bob") // rank will be 2
+func New[ID comparable](policy TiePolicy) *Board[ID] {
+	return &Board[ID]{policy: policy, index: dictionary.DefaultDictionary[ID, slot[ID]]()}
+}
+
+// before reports whether a is ranked above b.
+func (b *Board[ID]) before(x, y slot[ID]) bool {
+	if c := cmp.Compare(x.score, y.score); c != 0 {
+		return c > 0
+	}
+	if b.policy == LastReached {
+		return x.seq > y.seq
+	}
+	return x.seq < y.seq
+}
+
+// position returns the index of s in the ranking.
+func (b *Board[ID]) position(s slot[ID]) int {
+	return sort.Search(len(b.ranked), func(i int) bool { return !b.before(b.ranked[i], s) })
+}
+
+// rankAt returns the rank of the participant at index i.
+func (b *Board[ID]) rankAt(i int) int {
+	if b.policy != SharedRank {
+		return i + 1
+	}
+	score := b.ranked[i].score
+	return sort.Search(i, func(j int) bool { return cmp.Compare(b.ranked[j].score, score) <= 0 }) + 1
+}
+
+// entryAt returns the participant at index i as an Entry.
+func (b *Board[ID]) entryAt(i int) Entry[ID] {
+	s := b.ranked[i]
+	return Entry[ID]{ID: s.id, Score: s.score, Rank: b.rankAt(i)}
+}
+
+// SetScore sets the score of a participant, adding them if they are new. Setting the score a
+// participant already has does not change when they reached it.
+//
+// Parameters:
+//   - id: The participant.
+//   - score: The new score; higher scores rank higher.
+func (b *Board[ID]) SetScore(id ID, score float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.setLocked(id, score)
+}
+
+func (b *Board[ID]) setLocked(id ID, score float64) {
+	if old, ok := b.index[id]; ok {
+		if old.score == score {
+			return
+		}
+		b.removeLocked(old)
+	}
+	b.seq++
+	s := slot[ID]{id: id, score: score, seq: b.seq}
+	b.ranked = slices.Insert(b.ranked, b.position(s), s)
+	b.index[id] = s
+}
+
+// AddScore adds delta to the score of a participant, starting from zero if they are new.
+//
+// Returns:
+//   - float64: The new score.
+func (b *Board[ID]) AddScore(id ID, delta float64) float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	score := b.index[id].score + delta
+	b.setLocked(id, score)
+	return score
+}
+
+// Remove removes a participant.
+//
+// Returns:
+//   - bool: True if the participant was present, false otherwise.
+func (b *Board[ID]) Remove(id ID) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s, ok := b.index[id]
+	if ok {
+		b.removeLocked(s)
+		b.index.DeleteValue(id)
+	}
+	return ok
+}
+
+func (b *Board[ID]) removeLocked(s slot[ID]) {
+	i := b.position(s)
+	b.ranked = slices.Delete(b.ranked, i, i+1)
+}
+
+// Score returns the score of a participant.
+//
+// Returns:
+//   - float64: The score, or 0 if the participant is absent.
+//   - bool: True if the participant is present, false otherwise.
+func (b *Board[ID]) Score(id ID) (float64, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	s, ok := b.index[id]
+	return s.score, ok
+}
+
+// Rank returns the 1-based rank of a participant.
+//
+// Returns:
+//   - int: The rank, or 0 if the participant is absent.
+//   - bool: True if the participant is present, false otherwise.
+func (b *Board[ID]) Rank(id ID) (int, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	s, ok := b.index[id]
+	if !ok {
+		return 0, false
+	}
+	return b.rankAt(b.position(s)), true
+}
+
+// Len returns the number of participants.
+func (b *Board[ID]) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.ranked)
+}
+
+// Top returns the n highest-ranked participants.
+//
+// Example:
+//
+//	podium := b.Top(3)
+func (b *Board[ID]) Top(n int) []Entry[ID] {
+	return b.Page(0, n)
+}
+
+// Page returns up to limit participants starting at the 0-based position offset, for
+// paginating through the whole ranking.
+//
+// Parameters:
+//   - offset: The number of participants to skip.
+//   - limit: The maximum number of participants to return.
+//
+// Returns:
+//   - []Entry[ID]: The participants in rank order, empty if offset is past the end.
+//
+// Example:
+//
+//	page3 := b.Page(40, 20) // ranks 41 to 60
+func (b *Board[ID]) Page(offset, limit int) []Entry[ID] {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	lo := max(offset, 0)
+	hi := min(lo+max(limit, 0), len(b.ranked))
+	return b.entries(lo, hi)
+}
+
+// Around returns a participant together with up to k participants ranked directly above
+// and k directly below them.
+//
+// Parameters:
+//   - id: The participant.
+//   - k: The number of neighbours on each side.
+//
+// Returns:
+//   - []Entry[ID]: The participants in rank order, or nil if id is absent.
+//
+// Example:
+//
+//	nearby := b.Around("bob", 2) // up to five entries with bob in the middle
+func (b *Board[ID]) Around(id ID, k int) []Entry[ID] {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	s, ok := b.index[id]
+	if !ok {
+		return nil
+	}
+	i := b.position(s)
+	k = max(k, 0)
+	return b.entries(max(i-k, 0), min(i+k+1, len(b.ranked)))
+}
+
+func (b *Board[ID]) entries(lo, hi int) []Entry[ID] {
+	if lo >= hi {
+		return []Entry[ID]{}
+	}
+	out := make([]Entry[ID], 0, hi-lo)
+	for i := lo; i < hi; i++ {
+		out = append(out, b.entryAt(i))
+	}
+	return out
+}
+
+// Snapshot returns every participant in rank order, for persisting the Board.
+func (b *Board[ID]) Snapshot() []Entry[ID] {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.entries(0, len(b.ranked))
+}
+
+// Restore replaces the participants with those of a snapshot. Participants with equal
+// scores keep their relative order from the snapshot; ranks in it are ignored. A
+// participant listed twice keeps the score and position of their last entry.
+//
+// Example:
+//
+//	saved := b.Snapshot()
+//	restored := New[string](FirstReached)
+//	restored.Restore(saved)
+//
+//	dup := New[string](LastReached)
+//	dup.Restore([]Entry[string]{{ID: "ann", Score: 5}, {ID: "bob", Score: 7}, {ID: "ann", Score: 9}})
+//	// dup.Snapshot() lists ann with 9, then bob with 7
+func (b *Board[ID]) Restore(entries []Entry[ID]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	profiling.Do("leaderboard.Restore", func() int {
+		b.restoreLocked(entries)
+		return len(entries)
+	})
+}
+
+// restoreLocked implements Restore. The caller must hold b.mu.
+func (b *Board[ID]) restoreLocked(entries []Entry[ID]) {
+	b.ranked = make([]slot[ID], 0, len(entries))
+	b.index = dictionary.NewDictionaryWithCapacity[ID, slot[ID]](len(entries))
+	// Walk the snapshot backwards so only the last entry of a participant is kept; this
+	// leaves ordered reversed.
+	seen := dictionary.NewDictionaryWithCapacity[ID, bool](len(entries))
+	ordered := make([]Entry[ID], 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		if e := entries[i]; !seen[e.ID] {
+			seen[e.ID] = true
+			ordered = append(ordered, e)
+		}
+	}
+	// Under LastReached, later insertions rank higher among equal scores, so the reversed
+	// order is the one to insert in.
+	if b.policy != LastReached {
+		slices.Reverse(ordered)
+	}
+	for _, e := range ordered {
+		b.setLocked(e.ID, e.Score)
+	}
+}
@@ -0,0 +1,303 @@
+// Package mailbox provides per-key bounded priority inboxes that are drained
+// fairly across keys.
+//
+// Each key owns a priority queue of pending items limited to a fixed capacity.
+// Draining visits the keys round-robin and takes the highest-priority item of
+// each in turn, so a single busy key cannot monopolize processing.
+package mailbox
+
+import (
+	"container/heap"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// OverflowPolicy decides what happens when an item is pushed to a full inbox.
+type OverflowPolicy int
+
+const (
+	// Reject refuses the new item; Push returns an error wrapping errs.ErrCapacityExceeded.
+	Reject OverflowPolicy = iota
+	// DropLowest discards the lowest-priority item, which may be the new item itself.
+	DropLowest
+	// DropOldest discards the item that has been waiting the longest.
+	DropOldest
+)
+
+// Options configures a Mailbox.
+type Options struct {
+	// Capacity is the maximum number of pending items per key. Values below 1 mean 1.
+	Capacity int
+	// Overflow is applied when an item is pushed to a full inbox.
+	Overflow OverflowPolicy
+	// TTL, when positive, discards items that have waited longer than TTL instead of
+	// delivering them.
+	TTL time.Duration
+}
+
+// item is a pending value with the bookkeeping needed for ordering and expiry.
+type item[T any] struct {
+	value    T
+	seq      uint64
+	deadline time.Time
+}
+
+// inbox is the priority queue of a single key. It implements heap.Interface
+// with the highest-priority item at the root.
+type inbox[T any] struct {
+	items  []item[T]
+	higher func(a, b T) bool
+	queued bool // whether the key is currently in the round-robin queue
+}
+
+func (b *inbox[T]) Len() int { return len(b.items) }
+
+func (b *inbox[T]) Less(i, j int) bool {
+	x, y := b.items[i], b.items[j]
+	if b.higher(x.value, y.value) {
+		return true
+	}
+	if b.higher(y.value, x.value) {
+		return false
+	}
+	return x.seq < y.seq // equal priority: first in, first out
+}
+
+func (b *inbox[T]) Swap(i, j int) { b.items[i], b.items[j] = b.items[j], b.items[i] }
+
+func (b *inbox[T]) Push(x any) { b.items = append(b.items, x.(item[T])) }
+
+func (b *inbox[T]) Pop() any {
+	last := b.items[len(b.items)-1]
+	b.items = b.items[:len(b.items)-1]
+	return last
+}
+
+// victim returns the index of the item the overflow policy would discard.
+func (b *inbox[T]) victim(policy OverflowPolicy) int {
+	v := 0
+	for i := 1; i < len(b.items); i++ {
+		switch policy {
+		case DropOldest:
+			if b.items[i].seq < b.items[v].seq {
+				v = i
+			}
+		default:
+			if b.Less(v, i) {
+				v = i
+			}
+		}
+	}
+	return v
+}
+
+// Mailbox holds a bounded priority inbox per key. It is safe for concurrent use.
+type Mailbox[K comparable, T any] struct {
+	mu      sync.Mutex
+	opts    Options
+	higher  func(a, b T) bool
+	boxes   dictionary.Dictionary[K, *inbox[T]]
+	order   []K // keys with pending items, in round-robin order
+	seq     uint64
+	size    int
+	dropped uint64
+	expired uint64
+}
+
+// New creates an empty Mailbox.
+//
+// Parameters:
+//   - higher: Reports whether a has higher priority than b. Items of equal priority are
+//     delivered in the order they were pushed.
+//   - opts: The capacity, overflow policy and optional TTL.
+//
+// Returns:
+//   - A new empty Mailbox.
+//
+// Example:
+//
+//	mb := New[string, Job](func(a, b Job) bool { return a.Priority > b.Priority }, Options{Capacity: 100})
+//	mb.Push("tenant-a", job)
+//	key, job, ok := mb.Next()
+func New[K comparable, T any](higher func(a, b T) bool, opts Options) *Mailbox[K, T] {
+	opts.Capacity = max(opts.Capacity, 1)
+	return &Mailbox[K, T]{
+		opts:   opts,
+		higher: higher,
+		boxes:  dictionary.DefaultDictionary[K, *inbox[T]](),
+	}
+}
+
+// Push adds an item to the inbox of key, applying the overflow policy if it is full.
+//
+// Parameters:
+//   - key: The key whose inbox receives the item.
+//   - value: The item to be queued.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrCapacityExceeded if the inbox is full and the
+//     policy is Reject or the new item was the one discarded, nil otherwise.
+func (m *Mailbox[K, T]) Push(key K, value T) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.boxes[key]
+	if !ok {
+		b = &inbox[T]{higher: m.higher}
+		m.boxes[key] = b
+	}
+	m.seq++
+	it := item[T]{value: value, seq: m.seq}
+	if m.opts.TTL > 0 {
+		it.deadline = time.Now().Add(m.opts.TTL)
+	}
+
+	if b.Len() >= m.opts.Capacity {
+		if m.opts.Overflow == Reject {
+			m.dropped++
+			return errs.NewKeyError("push", key, fmt.Errorf("inbox holds %d items: %w", b.Len(), errs.ErrCapacityExceeded))
+		}
+		v := b.victim(m.opts.Overflow)
+		if m.opts.Overflow == DropLowest && !b.higher(value, b.items[v].value) {
+			// The new item would rank last; drop it instead.
+			m.dropped++
+			return errs.NewKeyError("push", key, fmt.Errorf("item has the lowest priority: %w", errs.ErrCapacityExceeded))
+		}
+		heap.Remove(b, v)
+		m.size--
+		m.dropped++
+	}
+
+	heap.Push(b, it)
+	m.size++
+	if !b.queued {
+		b.queued = true
+		m.order = append(m.order, key)
+	}
+	return nil
+}
+
+// Next removes and returns the highest-priority item of the next key in round-robin order.
+// Expired items are discarded along the way.
+//
+// Returns:
+//   - K: The key the item was queued under.
+//   - T: The item.
+//   - bool: False if no items are pending, true otherwise.
+func (m *Mailbox[K, T]) Next() (K, T, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for len(m.order) > 0 {
+		key := m.order[0]
+		m.order = m.order[1:]
+		b := m.boxes[key]
+		it, ok := m.popLive(b, now)
+		if b.Len() > 0 {
+			m.order = append(m.order, key)
+		} else {
+			b.queued = false
+			m.boxes.DeleteValue(key)
+		}
+		if ok {
+			return key, it.value, true
+		}
+	}
+	var (
+		zeroK K
+		zeroT T
+	)
+	return zeroK, zeroT, false
+}
+
+// Pop removes and returns the highest-priority item of a specific key, bypassing
+// the round-robin order.
+//
+// Returns:
+//   - T: The item, or the zero value if none is pending.
+//   - bool: False if the key has no pending items, true otherwise.
+func (m *Mailbox[K, T]) Pop(key K) (T, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.boxes[key]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	it, ok := m.popLive(b, time.Now())
+	if b.Len() == 0 {
+		m.boxes.DeleteValue(key)
+		b.queued = false
+		m.removeFromOrder(key)
+	}
+	return it.value, ok
+}
+
+// popLive pops items from b until it finds one that has not expired. The caller must hold m.mu.
+func (m *Mailbox[K, T]) popLive(b *inbox[T], now time.Time) (item[T], bool) {
+	for b.Len() > 0 {
+		it := heap.Pop(b).(item[T])
+		m.size--
+		if !it.deadline.IsZero() && now.After(it.deadline) {
+			m.expired++
+			continue
+		}
+		return it, true
+	}
+	return item[T]{}, false
+}
+
+// removeFromOrder deletes key from the round-robin queue. The caller must hold m.mu.
+func (m *Mailbox[K, T]) removeFromOrder(key K) {
+	for i, k := range m.order {
+		if k == key {
+			m.order = append(m.order[:i], m.order[i+1:]...)
+			return
+		}
+	}
+}
+
+// Len returns the total number of pending items, including any that have expired
+// but not yet been discarded.
+func (m *Mailbox[K, T]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.size
+}
+
+// LenOf returns the number of pending items for key.
+func (m *Mailbox[K, T]) LenOf(key K) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if b, ok := m.boxes[key]; ok {
+		return b.Len()
+	}
+	return 0
+}
+
+// Keys returns the keys with pending items, in the order Next will visit them.
+func (m *Mailbox[K, T]) Keys() []K {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]K(nil), m.order...)
+}
+
+// Dropped returns how many items have been refused or discarded by the overflow policy.
+func (m *Mailbox[K, T]) Dropped() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.dropped
+}
+
+// Expired returns how many items have been discarded because their TTL elapsed.
+func (m *Mailbox[K, T]) Expired() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.expired
+}
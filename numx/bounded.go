@@ -0,0 +1,239 @@
+package numx
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// Percent is a percentage clamped to the closed interval [0, 100].
+// Arithmetic on a Percent saturates at the bounds instead of overflowing them.
+type Percent float64
+
+// NewPercent returns v as a Percent, clamped to [0, 100]. NaN becomes 0.
+//
+// Example:
+//
+//	p := NewPercent(120) // p will be 100
+func NewPercent(v float64) Percent {
+	return Percent(clampUnit(v, 100))
+}
+
+// Float64 returns the percentage as a plain float64.
+func (p Percent) Float64() float64 {
+	return float64(p)
+}
+
+// Ratio returns the percentage as a Ratio in [0, 1].
+func (p Percent) Ratio() Ratio {
+	return NewRatio(float64(p) / 100)
+}
+
+// Add returns p plus q, saturating at 100.
+func (p Percent) Add(q Percent) Percent {
+	return NewPercent(float64(p) + float64(q))
+}
+
+// Sub returns p minus q, saturating at 0.
+func (p Percent) Sub(q Percent) Percent {
+	return NewPercent(float64(p) - float64(q))
+}
+
+// Scale returns p multiplied by f, clamped to [0, 100].
+func (p Percent) Scale(f float64) Percent {
+	return NewPercent(float64(p) * f)
+}
+
+// Of returns the share of x represented by p.
+//
+// Example:
+//
+//	v := NewPercent(25).Of(200) // v will be 50
+func (p Percent) Of(x float64) float64 {
+	return x * float64(p) / 100
+}
+
+// String returns the percentage formatted with a trailing percent sign.
+func (p Percent) String() string {
+	return fmt.Sprintf("%g%%", float64(p))
+}
+
+// MarshalJSON encodes the percentage as a JSON number.
+func (p Percent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(float64(p))
+}
+
+// UnmarshalJSON decodes a JSON number into p.
+// Values outside [0, 100] are rejected with an error wrapping errs.ErrOutOfRange.
+func (p *Percent) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalInRange(data, 100)
+	if err != nil {
+		return fmt.Errorf("percent: %w", err)
+	}
+	*p = Percent(v)
+	return nil
+}
+
+// Ratio is a fraction clamped to the closed interval [0, 1].
+// Arithmetic on a Ratio saturates at the bounds instead of overflowing them.
+type Ratio float64
+
+// NewRatio returns v as a Ratio, clamped to [0, 1]. NaN becomes 0.
+//
+// Example:
+//
+//	r := NewRatio(-0.5) // r will be 0
+func NewRatio(v float64) Ratio {
+	return Ratio(clampUnit(v, 1))
+}
+
+// Float64 returns the ratio as a plain float64.
+func (r Ratio) Float64() float64 {
+	return float64(r)
+}
+
+// Percent returns the ratio as a Percent in [0, 100].
+func (r Ratio) Percent() Percent {
+	return NewPercent(float64(r) * 100)
+}
+
+// Add returns r plus q, saturating at 1.
+func (r Ratio) Add(q Ratio) Ratio {
+	return NewRatio(float64(r) + float64(q))
+}
+
+// Sub returns r minus q, saturating at 0.
+func (r Ratio) Sub(q Ratio) Ratio {
+	return NewRatio(float64(r) - float64(q))
+}
+
+// Mul returns the product of r and q, which always stays within [0, 1].
+func (r Ratio) Mul(q Ratio) Ratio {
+	return Ratio(float64(r) * float64(q))
+}
+
+// Complement returns 1 minus r.
+func (r Ratio) Complement() Ratio {
+	return Ratio(1 - float64(r))
+}
+
+// Of returns the share of x represented by r.
+//
+// Example:
+//
+//	v := NewRatio(0.1).Of(50) // v will be 5
+func (r Ratio) Of(x float64) float64 {
+	return x * float64(r)
+}
+
+// MarshalJSON encodes the ratio as a JSON number.
+func (r Ratio) MarshalJSON() ([]byte, error) {
+	return json.Marshal(float64(r))
+}
+
+// UnmarshalJSON decodes a JSON number into r.
+// Values outside [0, 1] are rejected with an error wrapping errs.ErrOutOfRange.
+func (r *Ratio) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalInRange(data, 1)
+	if err != nil {
+		return fmt.Errorf("ratio: %w", err)
+	}
+	*r = Ratio(v)
+	return nil
+}
+
+// Angle is an angle in degrees, normalized to the half-open interval [0, 360).
+// Arithmetic on an Angle wraps around the circle.
+type Angle float64
+
+// NewAngle returns deg as an Angle wrapped into [0, 360). NaN and infinities become 0.
+//
+// Example:
+//
+//	a := NewAngle(-90) // a will be 270
+func NewAngle(deg float64) Angle {
+	if math.IsNaN(deg) || math.IsInf(deg, 0) {
+		return 0
+	}
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	if deg >= 360 {
+		// Adding 360 to a tiny negative remainder can round up to exactly 360.
+		deg = 0
+	}
+	return Angle(deg)
+}
+
+// AngleFromRadians returns rad as an Angle wrapped into [0, 360).
+func AngleFromRadians(rad float64) Angle {
+	return NewAngle(rad * 180 / math.Pi)
+}
+
+// Degrees returns the angle in degrees.
+func (a Angle) Degrees() float64 {
+	return float64(a)
+}
+
+// Radians returns the angle in radians.
+func (a Angle) Radians() float64 {
+	return float64(a) * math.Pi / 180
+}
+
+// Add returns a plus b, wrapped around the circle.
+func (a Angle) Add(b Angle) Angle {
+	return NewAngle(float64(a) + float64(b))
+}
+
+// Sub returns a minus b, wrapped around the circle.
+func (a Angle) Sub(b Angle) Angle {
+	return NewAngle(float64(a) - float64(b))
+}
+
+// Distance returns the smallest angle between a and b, in [0, 180].
+//
+// Example:
+//
+//	d := NewAngle(350).Distance(NewAngle(10)) // d will be 20
+func (a Angle) Distance(b Angle) float64 {
+	d := float64(a.Sub(b))
+	return min(d, 360-d)
+}
+
+// MarshalJSON encodes the angle as a JSON number of degrees.
+func (a Angle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(float64(a))
+}
+
+// UnmarshalJSON decodes a JSON number of degrees into a, wrapping it into [0, 360).
+func (a *Angle) UnmarshalJSON(data []byte) error {
+	var v float64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("angle: %w", err)
+	}
+	*a = NewAngle(v)
+	return nil
+}
+
+// clampUnit clamps v to [0, hi], mapping NaN to 0.
+func clampUnit(v, hi float64) float64 {
+	if math.IsNaN(v) {
+		return 0
+	}
+	return Clamp(v, 0, hi)
+}
+
+// unmarshalInRange decodes a JSON number and checks that it lies within [0, hi].
+func unmarshalInRange(data []byte, hi float64) (float64, error) {
+	var v float64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return 0, err
+	}
+	if v < 0 || v > hi {
+		return 0, fmt.Errorf("%v not in [0, %v]: %w", v, hi, errs.ErrOutOfRange)
+	}
+	return v, nil
+}
@@ -0,0 +1,237 @@
+// Package numx provides generic numeric helpers such as Clamp, MinOf, MaxOf,
+// Abs, Sign, Lerp and tolerant floating-point comparison.
+package numx
+
+import (
+	"math"
+	"unsafe"
+)
+
+// Signed is a constraint matching all signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is a constraint matching all unsigned integer types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is a constraint matching all integer types.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is a constraint matching all floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// SignedNumber is a constraint matching all numeric types that can be negative.
+type SignedNumber interface {
+	Signed | Float
+}
+
+// Number is a constraint matching all integer and floating-point types.
+type Number interface {
+	Integer | Float
+}
+
+// Clamp limits v to the closed interval [lo, hi].
+// If lo is greater than hi, the bounds are swapped rather than panicking.
+//
+// Parameters:
+//   - v: The value to be clamped.
+//   - lo: The lower bound.
+//   - hi: The upper bound.
+//
+// Returns:
+//   - The value of v limited to [lo, hi].
+//
+// Example:
+//
+//	c := Clamp(15, 0, 10) // c will be 10
+func Clamp[T Number](v, lo, hi T) T {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return min(max(v, lo), hi)
+}
+
+// MinOf returns the smallest of the given values.
+// As with the builtin min, the result is NaN if any floating-point argument is NaN.
+//
+// Parameters:
+//   - first: The first value.
+//   - rest: Any further values.
+//
+// Returns:
+//   - The smallest value.
+//
+// Example:
+//
+//	m := MinOf(3, 1, 2) // m will be 1
+func MinOf[T Number](first T, rest ...T) T {
+	m := first
+	for _, v := range rest {
+		m = min(m, v)
+	}
+	return m
+}
+
+// MaxOf returns the largest of the given values.
+// As with the builtin max, the result is NaN if any floating-point argument is NaN.
+//
+// Parameters:
+//   - first: The first value.
+//   - rest: Any further values.
+//
+// Returns:
+//   - The largest value.
+//
+// Example:
+//
+//	m := MaxOf(3, 1, 2) // m will be 3
+func MaxOf[T Number](first T, rest ...T) T {
+	m := first
+	for _, v := range rest {
+		m = max(m, v)
+	}
+	return m
+}
+
+// Abs returns the absolute value of v.
+// For signed integers the absolute value of the most negative value overflows
+// and is returned unchanged, matching two's complement arithmetic.
+//
+// Example:
+//
+//	a := Abs(-3) // a will be 3
+func Abs[T SignedNumber](v T) T {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// Sign returns -1 if v is negative, 1 if v is positive and 0 otherwise (including NaN).
+//
+// Example:
+//
+//	s := Sign(-2.5) // s will be -1
+func Sign[T SignedNumber](v T) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Lerp linearly interpolates between a and b by the factor t.
+// A factor of 0 returns a and a factor of 1 returns b; t is not clamped.
+//
+// Example:
+//
+//	v := Lerp(10.0, 20.0, 0.25) // v will be 12.5
+func Lerp[T Float](a, b, t T) T {
+	return a + (b-a)*t
+}
+
+// AlmostEqual reports whether a and b differ by at most epsilon, either in
+// absolute terms or relative to the larger of their magnitudes.
+// As with AlmostEqualULP, infinities are only almost equal to themselves and
+// NaN is never almost equal to anything.
+//
+// Parameters:
+//   - a: The first value.
+//   - b: The second value.
+//   - epsilon: The tolerated difference.
+//
+// Returns:
+//   - bool: True if the values are within tolerance, false otherwise.
+//
+// Example:
+//
+//	AlmostEqual(0.1+0.2, 0.3, 1e-9)                // true
+//	AlmostEqual(1e9, 1e9+1, 1e-6)                  // true: relative difference 1e-9
+//	AlmostEqual(math.Inf(1), math.Inf(1), 1e-9)    // true
+//	AlmostEqual(math.Inf(1), 1, 1e-9)              // false
+//	AlmostEqual(math.Inf(1), math.Inf(-1), 1e-9)   // false
+//	AlmostEqual(math.NaN(), math.NaN(), 1e-9)      // false
+func AlmostEqual[T Float](a, b, epsilon T) bool {
+	if a == b {
+		return true
+	}
+	// Past this point an infinity differs from b, and the relative check below would
+	// compare Inf <= Inf.
+	if math.IsInf(float64(a), 0) || math.IsInf(float64(b), 0) {
+		return false
+	}
+	diff := Abs(a - b)
+	if diff <= epsilon {
+		return true
+	}
+	return diff <= epsilon*max(Abs(a), Abs(b))
+}
+
+// AlmostEqualULP reports whether a and b are at most maxULPs representable
+// values apart. Positive and negative zero are equal, infinities are only
+// equal to themselves and NaN is never equal to anything.
+//
+// Parameters:
+//   - a: The first value.
+//   - b: The second value.
+//   - maxULPs: The tolerated distance in units in the last place.
+//
+// Returns:
+//   - bool: True if the values are within maxULPs of each other, false otherwise.
+//
+// Example:
+//
+//	ok := AlmostEqualULP(0.1+0.2, 0.3, 4) // ok will be true
+func AlmostEqualULP[T Float](a, b T, maxULPs uint64) bool {
+	if a != a || b != b {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	return ULPDistance(a, b) <= maxULPs
+}
+
+// ULPDistance returns the number of representable values between a and b.
+// The distance is computed in the precision of T, so float32 values are not
+// widened to float64 first. The result is meaningless if either value is NaN.
+func ULPDistance[T Float](a, b T) uint64 {
+	var x, y int64
+	if unsafe.Sizeof(a) == 4 {
+		x, y = orderedBits32(float32(a)), orderedBits32(float32(b))
+	} else {
+		x, y = orderedBits64(float64(a)), orderedBits64(float64(b))
+	}
+	if x < y {
+		x, y = y, x
+	}
+	return uint64(x) - uint64(y)
+}
+
+// orderedBits64 maps f onto an integer line on which adjacent floats are adjacent integers.
+func orderedBits64(f float64) int64 {
+	b := int64(math.Float64bits(f))
+	if b < 0 {
+		b = math.MinInt64 - b
+	}
+	return b
+}
+
+// orderedBits32 maps f onto an integer line on which adjacent floats are adjacent integers.
+func orderedBits32(f float32) int64 {
+	b := int32(math.Float32bits(f))
+	if b < 0 {
+		b = math.MinInt32 - b
+	}
+	return int64(b)
+}
@@ -0,0 +1,294 @@
+// Package outbox provides local reliable delivery. Messages are written to a
+// write-ahead log before Enqueue returns, and a dispatcher delivers them at least
+// once, retrying failed deliveries with exponential backoff until they are
+// acknowledged. Messages not yet acknowledged survive a restart and are delivered
+// again after the Outbox is reopened.
+package outbox
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// Default backoff bounds, used when Options leaves them unset.
+const (
+	DefaultMinBackoff = 100 * time.Millisecond
+	DefaultMaxBackoff = time.Minute
+)
+
+// Message is a message held by an Outbox.
+type Message struct {
+	// ID identifies the message. IDs increase in the order messages are enqueued.
+	ID uint64
+	// Payload is the content given to Enqueue.
+	Payload []byte
+	// Attempts is the number of failed deliveries since the Outbox was opened.
+	Attempts int
+}
+
+// Options configures an Outbox.
+type Options struct {
+	// Deliver sends a message. Returning nil acknowledges it, removing it from the
+	// Outbox; an error schedules another attempt. It is required. Since a message is
+	// delivered again if the process stops before its acknowledgment is logged, the
+	// receiver should tolerate duplicates, for example by their ID.
+	Deliver func(ctx context.Context, m Message) error
+	// MinBackoff is the delay before the first retry; each further failure doubles it,
+	// up to MaxBackoff. Zero or less means DefaultMinBackoff and DefaultMaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// scheduled is a message waiting in the delay queue until due.
+type scheduled struct {
+	id  uint64
+	due time.Time
+}
+
+// delayQueue is a min-heap of messages by due time, then by ID.
+type delayQueue []scheduled
+
+func (q delayQueue) Len() int { return len(q) }
+
+func (q delayQueue) Less(i, j int) bool {
+	if !q[i].due.Equal(q[j].due) {
+		return q[i].due.Before(q[j].due)
+	}
+	return q[i].id < q[j].id
+}
+
+func (q delayQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *delayQueue) Push(x any)   { *q = append(*q, x.(scheduled)) }
+
+func (q *delayQueue) Pop() any {
+	old := *q
+	s := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return s
+}
+
+// Outbox durably queues messages and delivers them with retries. It is safe for
+// concurrent use.
+type Outbox struct {
+	mu       sync.Mutex
+	log      *wal
+	payloads dictionary.Dictionary[uint64, []byte]
+	attempts dictionary.Dictionary[uint64, int]
+	queue    delayQueue
+	lastID   uint64
+	opts     Options
+
+	wake   chan struct{}
+	closed chan struct{}
+}
+
+// Open opens the Outbox logged at path, creating the log if needed. Messages left in
+// the log by an earlier run are due for delivery immediately, in ID order. It panics if
+// opts.Deliver is nil.
+//
+// Parameters:
+//   - path: The file of the write-ahead log. It should be used by nothing else.
+//   - opts: The delivery function and backoff bounds.
+//
+// Returns:
+//   - *Outbox: The Outbox. Call Run to start delivering.
+//   - error: An error if the log cannot be opened or read.
+//
+// Example:
+//
+//	box, err := Open(filepath.Join(stateDir, "webhooks.log"), Options{
+//		Deliver: func(ctx context.Context, m Message) error {
+//			return postWebhook(ctx, m.ID, m.Payload)
+//		},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer box.Close()
+//	go box.Run(ctx)
+//	id, err := box.Enqueue(event)
+func Open(path string, opts Options) (*Outbox, error) {
+	if opts.Deliver == nil {
+		panic("outbox: Open needs a Deliver function")
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff, opts.MaxBackoff = DefaultMinBackoff, DefaultMaxBackoff
+	}
+	opts.MaxBackoff = max(opts.MaxBackoff, opts.MinBackoff)
+	log, payloads, lastID, err := openWAL(path)
+	if err != nil {
+		return nil, err
+	}
+	o := &Outbox{
+		log:      log,
+		payloads: payloads,
+		attempts: dictionary.DefaultDictionary[uint64, int](),
+		lastID:   lastID,
+		opts:     opts,
+		wake:     make(chan struct{}, 1),
+		closed:   make(chan struct{}),
+	}
+	now := time.Now()
+	for id := range payloads {
+		o.queue = append(o.queue, scheduled{id: id, due: now})
+	}
+	heap.Init(&o.queue)
+	return o, nil
+}
+
+// Enqueue adds a message, returning once it is logged and synced to disk.
+//
+// Parameters:
+//   - payload: The content of the message. It is copied.
+//
+// Returns:
+//   - uint64: The ID of the message.
+//   - error: An error wrapping errs.ErrClosed if the Outbox is closed, or an error if the
+//     message cannot be logged.
+func (o *Outbox) Enqueue(payload []byte) (uint64, error) {
+	payload = append([]byte(nil), payload...)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.isClosed() {
+		return 0, fmt.Errorf("outbox: enqueue: %w", errs.ErrClosed)
+	}
+	id := o.lastID + 1
+	if err := o.log.append(recordEnqueue, id, payload); err != nil {
+		return 0, err
+	}
+	o.lastID = id
+	o.payloads[id] = payload
+	heap.Push(&o.queue, scheduled{id: id, due: time.Now()})
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	return id, nil
+}
+
+// Run delivers due messages one at a time until ctx is done or the Outbox is closed.
+// Several goroutines may call Run to deliver messages concurrently; each message is
+// delivered by one of them at a time.
+//
+// Parameters:
+//   - ctx: Bounds the dispatcher and is passed to Deliver.
+//
+// Returns:
+//   - error: ctx.Err() if ctx ended, nil if the Outbox was closed, or an error if an
+//     acknowledgment cannot be logged. The message is then delivered again after a restart.
+func (o *Outbox) Run(ctx context.Context) error {
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		m, wait, ok := o.next()
+		if ok {
+			if err := o.deliver(ctx, m); err != nil {
+				return err
+			}
+			continue
+		}
+		if wait >= 0 {
+			timer.Reset(wait)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-o.closed:
+			return nil
+		case <-o.wake:
+		case <-timer.C:
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+	}
+}
+
+// next takes the first due message off the delay queue. If none is due, it returns how
+// long until the first one is, or -1 if the queue is empty.
+func (o *Outbox) next() (Message, time.Duration, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.isClosed() || len(o.queue) == 0 {
+		return Message{}, -1, false
+	}
+	if wait := time.Until(o.queue[0].due); wait > 0 {
+		return Message{}, wait, false
+	}
+	s := heap.Pop(&o.queue).(scheduled)
+	return Message{ID: s.id, Payload: o.payloads[s.id], Attempts: o.attempts[s.id]}, 0, true
+}
+
+// deliver calls Deliver for m, then logs its acknowledgment or schedules a retry.
+func (o *Outbox) deliver(ctx context.Context, m Message) error {
+	err := o.opts.Deliver(ctx, m)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if err != nil {
+		o.attempts[m.ID]++
+		heap.Push(&o.queue, scheduled{id: m.ID, due: time.Now().Add(o.backoff(o.attempts[m.ID]))})
+		return nil
+	}
+	delete(o.payloads, m.ID)
+	delete(o.attempts, m.ID)
+	if o.isClosed() {
+		// The log is closed; the message is delivered again after a restart.
+		return nil
+	}
+	if err := o.log.append(recordAck, m.ID, nil); err != nil {
+		return err
+	}
+	return o.log.compact(o.payloads, o.lastID)
+}
+
+// backoff returns the delay before the retry following the given number of failures.
+func (o *Outbox) backoff(failures int) time.Duration {
+	d := o.opts.MinBackoff
+	for i := 1; i < failures && d < o.opts.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, o.opts.MaxBackoff)
+}
+
+// GetLength returns the number of messages not yet acknowledged.
+func (o *Outbox) GetLength() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.payloads)
+}
+
+// Close stops the dispatchers and closes the log. Deliveries in progress complete, but
+// their acknowledgments are not logged, so those messages are delivered again after a
+// restart. Calling Close more than once is harmless.
+//
+// Returns:
+//   - error: An error if the log cannot be closed.
+func (o *Outbox) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.isClosed() {
+		return nil
+	}
+	close(o.closed)
+	return o.log.close()
+}
+
+// isClosed reports whether Close was called. The caller must hold o.mu.
+func (o *Outbox) isClosed() bool {
+	select {
+	case <-o.closed:
+		return true
+	default:
+		return false
+	}
+}
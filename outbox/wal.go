@@ -0,0 +1,203 @@
+package outbox
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/bhanurp/gotypes/dictionary"
+)
+
+// Record kinds of the log.
+const (
+	recordEnqueue byte = 1
+	recordAck     byte = 2
+)
+
+// recordHeaderSize is the size of the kind, ID and payload length that start a record.
+const recordHeaderSize = 1 + 8 + 4
+
+// compactMinRecords is the number of records below which the log is never compacted.
+const compactMinRecords = 1024
+
+// wal is the write-ahead log of an Outbox: an append-only file of records, each
+//
+//	kind (1 byte) | ID (8 bytes) | payload length (4 bytes) | payload | CRC-32 (4 bytes)
+//
+// with integers in big-endian order and the CRC covering the bytes before it. An enqueue
+// record carries a message and an ack record removes it. Every append is synced before
+// it returns.
+type wal struct {
+	path    string
+	f       *os.File
+	records int
+}
+
+// openWAL opens the log at path, creating it if needed, and replays it. A record cut
+// short or corrupted, as a crash during an append leaves it, ends the log: the file is
+// truncated to the records before it.
+//
+// Returns:
+//   - *wal: The log, positioned for appending.
+//   - dictionary.Dictionary[uint64, []byte]: The payloads of the messages not acknowledged.
+//   - uint64: The largest ID in the log, or 0 if it is empty.
+//   - error: An error if the file cannot be opened, read or truncated.
+func openWAL(path string) (*wal, dictionary.Dictionary[uint64, []byte], uint64, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("outbox: %w", err)
+	}
+	w := &wal{path: path, f: f}
+	pending := dictionary.DefaultDictionary[uint64, []byte]()
+	var lastID uint64
+	var good int64
+	r := bufio.NewReader(f)
+	for {
+		kind, id, payload, n, err := readRecord(r)
+		if err != nil {
+			break
+		}
+		good += n
+		w.records++
+		lastID = max(lastID, id)
+		switch kind {
+		case recordEnqueue:
+			pending[id] = payload
+		case recordAck:
+			delete(pending, id)
+		}
+	}
+	if err := f.Truncate(good); err != nil {
+		f.Close()
+		return nil, nil, 0, fmt.Errorf("outbox: %w", err)
+	}
+	if _, err := f.Seek(good, io.SeekStart); err != nil {
+		f.Close()
+		return nil, nil, 0, fmt.Errorf("outbox: %w", err)
+	}
+	return w, pending, lastID, nil
+}
+
+// errBadRecord ends a replay at a record that is cut short or fails its checksum.
+var errBadRecord = errors.New("outbox: bad record")
+
+// readRecord reads one record, returning its kind, ID, payload and size in bytes.
+func readRecord(r io.Reader) (byte, uint64, []byte, int64, error) {
+	var header [recordHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, 0, nil, 0, errBadRecord
+	}
+	kind, id := header[0], binary.BigEndian.Uint64(header[1:9])
+	size := binary.BigEndian.Uint32(header[9:13])
+	if kind != recordEnqueue && kind != recordAck {
+		return 0, 0, nil, 0, errBadRecord
+	}
+	// Reading through a LimitReader keeps a corrupted length from allocating gigabytes.
+	payload, err := io.ReadAll(io.LimitReader(r, int64(size)))
+	if err != nil || len(payload) != int(size) {
+		return 0, 0, nil, 0, errBadRecord
+	}
+	var sum [4]byte
+	if _, err := io.ReadFull(r, sum[:]); err != nil {
+		return 0, 0, nil, 0, errBadRecord
+	}
+	crc := crc32.Update(crc32.ChecksumIEEE(header[:]), crc32.IEEETable, payload)
+	if binary.BigEndian.Uint32(sum[:]) != crc {
+		return 0, 0, nil, 0, errBadRecord
+	}
+	return kind, id, payload, int64(recordHeaderSize + len(payload) + len(sum)), nil
+}
+
+// encodeRecord appends the encoding of a record to buf.
+func encodeRecord(buf []byte, kind byte, id uint64, payload []byte) []byte {
+	start := len(buf)
+	buf = append(buf, kind)
+	buf = binary.BigEndian.AppendUint64(buf, id)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(payload)))
+	buf = append(buf, payload...)
+	return binary.BigEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf[start:]))
+}
+
+// append writes a record and syncs the file.
+func (w *wal) append(kind byte, id uint64, payload []byte) error {
+	if _, err := w.f.Write(encodeRecord(nil, kind, id, payload)); err != nil {
+		return fmt.Errorf("outbox: %w", err)
+	}
+	if err := w.f.Sync(); err != nil {
+		return fmt.Errorf("outbox: %w", err)
+	}
+	w.records++
+	return nil
+}
+
+// compact rewrites the log with only the pending messages once acknowledged ones make up
+// most of it. The new log is written to a temporary file and renamed over the old one,
+// so a crash leaves one or the other. If lastID is no longer pending, an ack record for it
+// ends the new log, so IDs are not reused after a restart.
+func (w *wal) compact(pending dictionary.Dictionary[uint64, []byte], lastID uint64) error {
+	if w.records < compactMinRecords || w.records < 2*len(pending) {
+		return nil
+	}
+	var buf []byte
+	ids := pending.GetKeys()
+	slices.Sort(ids)
+	for _, id := range ids {
+		buf = encodeRecord(buf, recordEnqueue, id, pending[id])
+	}
+	records := len(ids)
+	if _, ok := pending[lastID]; !ok && lastID > 0 {
+		buf = encodeRecord(buf, recordAck, lastID, nil)
+		records++
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(w.path), filepath.Base(w.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("outbox: %w", err)
+	}
+	_, err = tmp.Write(buf)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), w.path)
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("outbox: %w", err)
+	}
+	// The renamed file is positioned at its end, ready for appends. It replaces the old
+	// one, now unlinked, even if the rename cannot be made durable.
+	w.f.Close()
+	w.f, w.records = tmp, records
+	return syncDir(filepath.Dir(w.path))
+}
+
+// close closes the file.
+func (w *wal) close() error {
+	if err := w.f.Close(); err != nil {
+		return fmt.Errorf("outbox: %w", err)
+	}
+	return nil
+}
+
+// syncDir flushes the directory entries of dir, making a rename into it durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("outbox: %w", err)
+	}
+	err = d.Sync()
+	if closeErr := d.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("outbox: %w", err)
+	}
+	return nil
+}
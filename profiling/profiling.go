@@ -0,0 +1,144 @@
+// Package profiling instruments the expensive operations of gotypes, such as deep copies,
+// parallel passes over a Dictionary, and saving or loading snapshots. When enabled,
+// per-operation counters record how often each operation ran, for how long, and over how
+// many items. Operations that take a context.Context also run under the pprof label
+// LabelKey naming them, added to the labels of that context, so CPU and allocation profiles
+// attribute their time to the library operation instead of to opaque runtime frames.
+//
+// Instrumentation is off by default and then costs one atomic load per operation.
+//
+// Operations that do not take a context are counted but not labelled: pprof cannot tell
+// them the labels of the calling goroutine, and labelling them would replace those labels.
+package profiling
+
+import (
+	"context"
+	"runtime/pprof"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LabelKey is the pprof label key whose value names the running operation.
+const LabelKey = "gotypes.op"
+
+// Stats holds the counters of one operation.
+type Stats struct {
+	// Calls is the number of times the operation ran.
+	Calls uint64
+	// Duration is the total wall-clock time spent in the operation.
+	Duration time.Duration
+	// Items is the total number of entries the operation processed.
+	Items uint64
+}
+
+// counters is the live, atomically updated form of Stats.
+type counters struct {
+	calls, nanos, items atomic.Uint64
+}
+
+var (
+	enabled atomic.Bool
+	ops     sync.Map // string -> *counters
+)
+
+// Enable turns instrumentation on.
+func Enable() { enabled.Store(true) }
+
+// Disable turns instrumentation off. Counters keep their values.
+func Disable() { enabled.Store(false) }
+
+// Enabled reports whether instrumentation is on.
+func Enabled() bool { return enabled.Load() }
+
+// Do runs fn as the operation op, on the calling goroutine. When instrumentation is on, the
+// call is counted, but the pprof labels of the goroutine are left alone, since Do cannot
+// know them to restore them. Use DoContext for operations that have a context to label.
+//
+// Parameters:
+//   - op: The name of the operation, for example "dictionary.DeepCopy".
+//   - fn: The operation, returning the number of entries it processed.
+//
+// Example:
+//
+//	profiling.Do("shards.Export", func() int {
+//		m, err = export(dir, d, n)
+//		return len(d)
+//	})
+func Do(op string, fn func() int) {
+	if !enabled.Load() {
+		fn()
+		return
+	}
+	start := time.Now()
+	record(op, fn(), start)
+}
+
+// DoContext runs fn as the operation op. When instrumentation is on, fn runs under the
+// labels of ctx plus LabelKey set to op, and receives the labelled context so goroutines it
+// starts carry the labels too; otherwise it receives ctx.
+//
+// Parameters:
+//   - ctx: The context of the operation.
+//   - op: The name of the operation, for example "dictionary.ParallelForEach".
+//   - fn: The operation, returning the number of entries it processed.
+//
+// Example:
+//
+//	profiling.DoContext(ctx, "dictionary.ParallelForEach", func(ctx context.Context) int {
+//		err = parallelChunks(ctx, len(entries), workers, work)
+//		return len(entries)
+//	})
+func DoContext(ctx context.Context, op string, fn func(ctx context.Context) int) {
+	if !enabled.Load() {
+		fn(ctx)
+		return
+	}
+	start := time.Now()
+	items := 0
+	pprof.Do(ctx, pprof.Labels(LabelKey, op), func(ctx context.Context) {
+		items = fn(ctx)
+	})
+	record(op, items, start)
+}
+
+// record adds one call of op to its counters.
+func record(op string, items int, start time.Time) {
+	c, ok := ops.Load(op)
+	if !ok {
+		c, _ = ops.LoadOrStore(op, new(counters))
+	}
+	cs := c.(*counters)
+	cs.calls.Add(1)
+	cs.nanos.Add(uint64(time.Since(start)))
+	cs.items.Add(uint64(max(items, 0)))
+}
+
+// Snapshot returns the counters of every operation that ran while instrumentation was on.
+//
+// Returns:
+//   - map[string]Stats: The counters keyed by operation name.
+//
+// Example:
+//
+//	for op, s := range profiling.Snapshot() {
+//		log.Printf("%s: %d calls, %v, %d items", op, s.Calls, s.Duration, s.Items)
+//	}
+func Snapshot() map[string]Stats {
+	out := make(map[string]Stats)
+	ops.Range(func(k, v any) bool {
+		c := v.(*counters)
+		out[k.(string)] = Stats{
+			Calls:    c.calls.Load(),
+			Duration: time.Duration(c.nanos.Load()),
+			Items:    c.items.Load(),
+		}
+		return true
+	})
+	return out
+}
+
+// Reset clears every counter.
+func Reset() {
+	ops.Clear()
+}
@@ -0,0 +1,274 @@
+// Package set provides Set, a generic set of comparable elements built on a map, with the
+// same conventions as dictionary.Dictionary.
+package set
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+
+	"github.com/bhanurp/gotypes/collection"
+)
+
+// Set is a generic set of comparable elements. Like a map, the zero value is a nil Set that
+// can be read but not added to; use New or FromSlice to create one.
+type Set[T comparable] map[T]struct{}
+
+var _ collection.Collection[string] = Set[string](nil)
+
+// New creates a Set holding the given elements.
+//
+// Parameters:
+//   - elems: The initial elements; duplicates are stored once.
+//
+// Returns:
+//   - A new Set with the given elements.
+//
+// Example:
+//
+//	s := New("a", "b", "a")
+//	fmt.Println(s.GetLength()) // Output: 2
+func New[T comparable](elems ...T) Set[T] {
+	return FromSlice(elems)
+}
+
+// FromSlice creates a Set holding the elements of a slice.
+//
+// Parameters:
+//   - elems: The slice whose elements are added; duplicates are stored once.
+//
+// Returns:
+//   - A new Set with the elements of elems.
+//
+// Example:
+//
+//	tags := FromSlice(strings.Fields("go go generics"))
+//	// tags is Set[string]{"go", "generics"}
+func FromSlice[T comparable](elems []T) Set[T] {
+	s := make(Set[T], len(elems))
+	for _, e := range elems {
+		s[e] = struct{}{}
+	}
+	return s
+}
+
+// Add inserts the given elements. Elements already present are left as they are.
+//
+// Parameters:
+//   - elems: The elements to be added.
+//
+// Example:
+//
+//	s := New[int]()
+//	s.Add(1, 2)
+func (s Set[T]) Add(elems ...T) {
+	for _, e := range elems {
+		s[e] = struct{}{}
+	}
+}
+
+// Remove deletes the given elements. Absent elements are ignored.
+//
+// Parameters:
+//   - elems: The elements to be removed.
+func (s Set[T]) Remove(elems ...T) {
+	for _, e := range elems {
+		delete(s, e)
+	}
+}
+
+// Contains checks if the element is present.
+func (s Set[T]) Contains(elem T) bool {
+	_, ok := s[elem]
+	return ok
+}
+
+// ContainsAll checks if every given element is present.
+func (s Set[T]) ContainsAll(elems ...T) bool {
+	for _, e := range elems {
+		if !s.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny checks if at least one of the given elements is present.
+func (s Set[T]) ContainsAny(elems ...T) bool {
+	for _, e := range elems {
+		if s.Contains(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLength returns the number of elements.
+func (s Set[T]) GetLength() int {
+	return len(s)
+}
+
+// Len returns the number of elements. It is the same as GetLength, under the name used by
+// the standard library.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// IsEmpty checks if the Set has no elements.
+func (s Set[T]) IsEmpty() bool {
+	return len(s) == 0
+}
+
+// ToSlice returns the elements in unspecified order.
+//
+// Example:
+//
+//	elems := New(3, 1, 2).ToSlice() // elems holds 1, 2 and 3 in any order
+func (s Set[T]) ToSlice() []T {
+	out := make([]T, 0, len(s))
+	for e := range s {
+		out = append(out, e)
+	}
+	return out
+}
+
+// Sorted returns the elements of s in ascending order.
+//
+// Example:
+//
+//	elems := Sorted(New(3, 1, 2)) // elems will be [1 2 3]
+func Sorted[T cmp.Ordered](s Set[T]) []T {
+	out := s.ToSlice()
+	slices.Sort(out)
+	return out
+}
+
+// All returns an iterator over the elements in unspecified order.
+func (s Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := range s {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes every element.
+func (s Set[T]) Clear() {
+	clear(s)
+}
+
+// Clone returns a copy of the Set, or nil if it is nil.
+func (s Set[T]) Clone() Set[T] {
+	if s == nil {
+		return nil
+	}
+	out := make(Set[T], len(s))
+	for e := range s {
+		out[e] = struct{}{}
+	}
+	return out
+}
+
+// Union returns a new Set with the elements of either Set.
+//
+// Example:
+//
+//	u := New(1, 2).Union(New(2, 3)) // u is Set[int]{1, 2, 3}
+func (s Set[T]) Union(s2 Set[T]) Set[T] {
+	out := make(Set[T], max(len(s), len(s2)))
+	for e := range s {
+		out[e] = struct{}{}
+	}
+	for e := range s2 {
+		out[e] = struct{}{}
+	}
+	return out
+}
+
+// Intersect returns a new Set with the elements present in both Sets.
+//
+// Example:
+//
+//	common := New(1, 2).Intersect(New(2, 3)) // common is Set[int]{2}
+func (s Set[T]) Intersect(s2 Set[T]) Set[T] {
+	small, large := s, s2
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	out := make(Set[T])
+	for e := range small {
+		if large.Contains(e) {
+			out[e] = struct{}{}
+		}
+	}
+	return out
+}
+
+// Difference returns a new Set with the elements of the current Set that are not in s2.
+//
+// Example:
+//
+//	diff := New(1, 2).Difference(New(2, 3)) // diff is Set[int]{1}
+func (s Set[T]) Difference(s2 Set[T]) Set[T] {
+	out := make(Set[T])
+	for e := range s {
+		if !s2.Contains(e) {
+			out[e] = struct{}{}
+		}
+	}
+	return out
+}
+
+// SymmetricDifference returns a new Set with the elements present in exactly one of the Sets.
+//
+// Example:
+//
+//	diff := New(1, 2).SymmetricDifference(New(2, 3)) // diff is Set[int]{1, 3}
+func (s Set[T]) SymmetricDifference(s2 Set[T]) Set[T] {
+	out := s.Difference(s2)
+	for e := range s2 {
+		if !s.Contains(e) {
+			out[e] = struct{}{}
+		}
+	}
+	return out
+}
+
+// IsEqual checks if both Sets hold the same elements.
+func (s Set[T]) IsEqual(s2 Set[T]) bool {
+	return len(s) == len(s2) && s.IsSubset(s2)
+}
+
+// IsSubset checks if every element of the current Set is in s2.
+func (s Set[T]) IsSubset(s2 Set[T]) bool {
+	if len(s) > len(s2) {
+		return false
+	}
+	for e := range s {
+		if !s2.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset checks if every element of s2 is in the current Set.
+func (s Set[T]) IsSuperset(s2 Set[T]) bool {
+	return s2.IsSubset(s)
+}
+
+// IsDisjoint checks if the Sets have no element in common.
+func (s Set[T]) IsDisjoint(s2 Set[T]) bool {
+	small, large := s, s2
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	for e := range small {
+		if large.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
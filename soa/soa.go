@@ -0,0 +1,227 @@
+// Package soa stores homogeneous structs column by column ("structure of
+// arrays") to improve cache locality for analytical scans.
+//
+// A Table[T] keeps one slice per exported field of T. Scans over a single
+// field, such as FilterColumn or a loop over the slice returned by Column,
+// touch only that field's memory instead of striding over whole records.
+// The struct layout is inspected once, by reflection, when the Table is
+// created; no code generation is needed.
+package soa
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// Table stores values of the struct type T as parallel column slices.
+// A Table is not safe for concurrent use.
+type Table[T any] struct {
+	names   []string
+	index   dictionary.Dictionary[string, int]
+	columns []reflect.Value // one addressable slice per field
+	n       int
+}
+
+// New creates an empty Table for the struct type T.
+//
+// Returns:
+//   - *Table[T]: A new empty Table.
+//   - error: An error wrapping errs.ErrUnsupportedType if T is not a struct or has unexported
+//     fields, which reflection could not copy into or out of the columns.
+//
+// Example:
+//
+//	type Trade struct {
+//		Symbol string
+//		Price  float64
+//		Size   int
+//	}
+//	trades, err := New[Trade]()
+func New[T any]() (*Table[T], error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("soa: %s is not a struct: %w", typ, errs.ErrUnsupportedType)
+	}
+	t := &Table[T]{index: dictionary.DefaultDictionary[string, int]()}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() {
+			return nil, fmt.Errorf("soa: %s has unexported field %s: %w", typ, f.Name, errs.ErrUnsupportedType)
+		}
+		t.index[f.Name] = i
+		t.names = append(t.names, f.Name)
+		col := reflect.New(reflect.SliceOf(f.Type)).Elem()
+		t.columns = append(t.columns, col)
+	}
+	return t, nil
+}
+
+// Len returns the number of rows.
+func (t *Table[T]) Len() int {
+	return t.n
+}
+
+// Columns returns the column names, in field order.
+func (t *Table[T]) Columns() []string {
+	return append([]string(nil), t.names...)
+}
+
+// Append adds rows to the end of the Table.
+//
+// Parameters:
+//   - rows: The values to be appended.
+func (t *Table[T]) Append(rows ...T) {
+	for _, row := range rows {
+		rv := reflect.ValueOf(row)
+		for i, col := range t.columns {
+			col.Set(reflect.Append(col, rv.Field(i)))
+		}
+	}
+	t.n += len(rows)
+}
+
+// Get reassembles the row at index i.
+//
+// Returns:
+//   - T: The row, or the zero value if i is out of range.
+//   - error: A *errs.KeyError wrapping errs.ErrOutOfRange if i is out of range, nil otherwise.
+func (t *Table[T]) Get(i int) (T, error) {
+	var row T
+	if i < 0 || i >= t.n {
+		return row, errs.NewKeyError("get", i, errs.ErrOutOfRange)
+	}
+	rv := reflect.ValueOf(&row).Elem()
+	for f, col := range t.columns {
+		rv.Field(f).Set(col.Index(i))
+	}
+	return row, nil
+}
+
+// Set overwrites the row at index i.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrOutOfRange if i is out of range, nil otherwise.
+func (t *Table[T]) Set(i int, row T) error {
+	if i < 0 || i >= t.n {
+		return errs.NewKeyError("set", i, errs.ErrOutOfRange)
+	}
+	rv := reflect.ValueOf(row)
+	for f, col := range t.columns {
+		col.Index(i).Set(rv.Field(f))
+	}
+	return nil
+}
+
+// Rows reassembles the rows at the given indices, for example those returned by FilterColumn.
+//
+// Returns:
+//   - []T: The rows, in the order of indices.
+//   - error: A *errs.KeyError wrapping errs.ErrOutOfRange if an index is out of range.
+func (t *Table[T]) Rows(indices []int) ([]T, error) {
+	rows := make([]T, len(indices))
+	for j, i := range indices {
+		row, err := t.Get(i)
+		if err != nil {
+			return nil, err
+		}
+		rows[j] = row
+	}
+	return rows, nil
+}
+
+// Truncate keeps the first n rows and drops the rest. n is clamped to [0, Len()].
+func (t *Table[T]) Truncate(n int) {
+	n = max(0, min(n, t.n))
+	for _, col := range t.columns {
+		// Zero the dropped tail so it does not keep pointers alive.
+		for i := n; i < col.Len(); i++ {
+			col.Index(i).SetZero()
+		}
+		col.SetLen(n)
+	}
+	t.n = n
+}
+
+// Column returns the backing slice of the named column without copying. The slice
+// shares memory with the Table: writes to it update the rows, and it becomes stale
+// once rows are appended.
+//
+// Parameters:
+//   - t: The Table holding the column.
+//   - name: The field name of the column.
+//
+// Returns:
+//   - []F: The column values, one per row.
+//   - error: A *errs.KeyError wrapping errs.ErrKeyNotFound if there is no such column,
+//     or an error wrapping errs.ErrTypeMismatch if F does not match the field type.
+//
+// Example:
+//
+//	prices, err := Column[Trade, float64](trades, "Price")
+//	var total float64
+//	for _, p := range prices {
+//		total += p
+//	}
+func Column[T any, F any](t *Table[T], name string) ([]F, error) {
+	i, ok := t.index[name]
+	if !ok {
+		return nil, errs.NewKeyError("column", name, errs.ErrKeyNotFound)
+	}
+	col, ok := t.columns[i].Interface().([]F)
+	if !ok {
+		return nil, fmt.Errorf("soa: column %s holds %s: %w", name, t.columns[i].Type().Elem(), errs.ErrTypeMismatch)
+	}
+	return col, nil
+}
+
+// FilterColumn scans a single column and returns the indices of the rows whose value
+// satisfies pred. Only the named column is read.
+//
+// Parameters:
+//   - t: The Table to be scanned.
+//   - name: The field name of the column.
+//   - pred: The predicate applied to each value.
+//
+// Returns:
+//   - []int: The ascending indices of matching rows.
+//   - error: The same errors as Column.
+//
+// Example:
+//
+//	big, err := FilterColumn(trades, "Size", func(size int) bool { return size >= 1000 })
+//	rows, err := trades.Rows(big)
+func FilterColumn[T any, F any](t *Table[T], name string, pred func(F) bool) ([]int, error) {
+	col, err := Column[T, F](t, name)
+	if err != nil {
+		return nil, err
+	}
+	var out []int
+	for i, v := range col {
+		if pred(v) {
+			out = append(out, i)
+		}
+	}
+	return out, nil
+}
+
+// Intersect returns the indices present in both ascending index lists, so filters on
+// different columns can be combined.
+func Intersect(a, b []int) []int {
+	var out []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
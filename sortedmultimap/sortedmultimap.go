@@ -0,0 +1,406 @@
+// Package sortedmultimap provides a map from ordered keys to lists of values.
+//
+// Keys are kept in ascending order and each key holds its values in insertion
+// order, which makes the type suitable for time-keyed event storage where
+// several events can share a timestamp.
+package sortedmultimap
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// OverflowPolicy decides what Add does when a limit of a SortedMultiMap would be exceeded.
+type OverflowPolicy int
+
+const (
+	// DropOldest makes room by removing the oldest value: the oldest value of the key when
+	// MaxValuesPerKey is reached, the oldest value of the whole map when MaxTotal is reached.
+	DropOldest OverflowPolicy = iota
+	// Reject refuses the new value, and Add returns an error.
+	Reject
+	// EvictLRUKey makes room for MaxTotal by removing every value of the least recently used
+	// key, where adding to a key or reading it with Get counts as a use. The key being added
+	// to is never evicted; if it is the only key, its oldest value is dropped instead. When
+	// MaxValuesPerKey is reached it behaves like DropOldest.
+	EvictLRUKey
+)
+
+// Limits bounds the size of a SortedMultiMap. Zero fields mean no limit.
+type Limits struct {
+	// MaxValuesPerKey is the largest number of values kept under one key.
+	MaxValuesPerKey int
+	// MaxTotal is the largest number of values kept across all keys.
+	MaxTotal int
+	// Overflow is the policy applied when a limit is reached.
+	Overflow OverflowPolicy
+}
+
+// LimitStats counts what the limits of a SortedMultiMap have discarded.
+type LimitStats struct {
+	// Rejected is the number of values refused under the Reject policy.
+	Rejected uint64
+	// DroppedValues is the number of stored values removed to make room, including those
+	// removed with evicted keys.
+	DroppedValues uint64
+	// EvictedKeys is the number of keys removed under the EvictLRUKey policy.
+	EvictedKeys uint64
+}
+
+// bucket holds every value stored under one key.
+type bucket[K any, V any] struct {
+	key    K
+	values []V
+	seqs   []uint64 // insertion sequence of each value; only kept when MaxTotal is set
+	used   uint64   // sequence of the last use, for EvictLRUKey
+}
+
+// SortedMultiMap maps keys to lists of values, keeping keys in ascending order
+// and each key's values in insertion order.
+//
+// Keys are stored in a sorted slice, so lookups take O(log n) and adding a new
+// key takes O(n) to shift later keys; adding a value to an existing key is
+// amortized O(log n). A SortedMultiMap is not safe for concurrent use.
+type SortedMultiMap[K any, V any] struct {
+	compare func(a, b K) int
+	buckets []bucket[K, V]
+	size    int
+	limits  Limits
+	stats   LimitStats
+	seq     uint64
+}
+
+// New creates an empty SortedMultiMap ordered by compare, which must return a negative
+// number when a < b, zero when a == b and a positive number when a > b.
+//
+// Example:
+//
+//	events := New[time.Time, string](time.Time.Compare)
+//	events.Add(t0, "started")
+//	events.Add(t0, "listening") // same timestamp, kept after "started"
+func New[K any, V any](compare func(a, b K) int) *SortedMultiMap[K, V] {
+	return &SortedMultiMap[K, V]{compare: compare}
+}
+
+// NewWithLimits creates an empty SortedMultiMap ordered by compare whose size is bounded by
+// limits, so per-key fan-out data such as recent events per user cannot grow without bound.
+// Finding the value or key to drop under MaxTotal takes time linear in the number of keys.
+//
+// Example:
+//
+//	recent := NewWithLimits[string, Event](strings.Compare, Limits{
+//		MaxValuesPerKey: 100,
+//		MaxTotal:        1_000_000,
+//		Overflow:        EvictLRUKey,
+//	})
+func NewWithLimits[K any, V any](compare func(a, b K) int, limits Limits) *SortedMultiMap[K, V] {
+	return &SortedMultiMap[K, V]{compare: compare, limits: limits}
+}
+
+// NewOrdered creates an empty SortedMultiMap for naturally ordered keys.
+//
+// Example:
+//
+//	m := NewOrdered[int, string]()
+//	m.Add(2, "b")
+//	m.Add(1, "a")
+//	m.Keys() // [1 2]
+func NewOrdered[K cmp.Ordered, V any]() *SortedMultiMap[K, V] {
+	return New[K, V](cmp.Compare[K])
+}
+
+// search returns the index of the bucket for key and whether it exists.
+func (m *SortedMultiMap[K, V]) search(key K) (int, bool) {
+	return slices.BinarySearchFunc(m.buckets, key, func(b bucket[K, V], k K) int {
+		return m.compare(b.key, k)
+	})
+}
+
+// lowerBound returns the index of the first bucket whose key is not less than key.
+func (m *SortedMultiMap[K, V]) lowerBound(key K) int {
+	i, _ := m.search(key)
+	return i
+}
+
+// Add appends value to the list stored under key. If the map was created with limits and
+// one would be exceeded, the overflow policy decides whether older values make room or the
+// new value is refused.
+//
+// Parameters:
+//   - key: The key to add the value under.
+//   - value: The value to be added.
+//
+// Returns:
+//   - error: A *errs.KeyError wrapping errs.ErrCapacityExceeded if the value was refused under
+//     the Reject policy, nil otherwise.
+func (m *SortedMultiMap[K, V]) Add(key K, value V) error {
+	i, ok := m.search(key)
+	lim := m.limits
+	perKeyFull := ok && lim.MaxValuesPerKey > 0 && len(m.buckets[i].values) >= lim.MaxValuesPerKey
+	totalFull := lim.MaxTotal > 0 && m.size >= lim.MaxTotal
+	if (perKeyFull || totalFull) && lim.Overflow == Reject {
+		m.stats.Rejected++
+		return errs.NewKeyError("add", key, errs.ErrCapacityExceeded)
+	}
+
+	if !ok {
+		m.buckets = slices.Insert(m.buckets, i, bucket[K, V]{key: key})
+	}
+	m.seq++
+	b := &m.buckets[i]
+	b.used = m.seq
+	if perKeyFull {
+		m.dropOldestOf(i)
+		totalFull = false
+	}
+	b.values = append(b.values, value)
+	if lim.MaxTotal > 0 {
+		b.seqs = append(b.seqs, m.seq)
+	}
+	m.size++
+	if totalFull {
+		m.makeRoom(i)
+	}
+	return nil
+}
+
+// dropOldestOf removes the oldest value of the bucket at index i.
+func (m *SortedMultiMap[K, V]) dropOldestOf(i int) {
+	b := &m.buckets[i]
+	b.values = slices.Delete(b.values, 0, 1)
+	if len(b.seqs) > 0 {
+		b.seqs = slices.Delete(b.seqs, 0, 1)
+	}
+	m.size--
+	m.stats.DroppedValues++
+}
+
+// makeRoom brings the map back to MaxTotal values after an Add to the bucket at index
+// added went over it. Under EvictLRUKey that bucket is never evicted; if it is the only
+// one left, its oldest value is dropped instead.
+func (m *SortedMultiMap[K, V]) makeRoom(added int) {
+	for m.size > m.limits.MaxTotal {
+		victim := -1
+		for i, b := range m.buckets {
+			if m.limits.Overflow == EvictLRUKey {
+				if i != added && (victim < 0 || b.used < m.buckets[victim].used) {
+					victim = i
+				}
+			} else if len(b.seqs) > 0 && (victim < 0 || b.seqs[0] < m.buckets[victim].seqs[0]) {
+				victim = i
+			}
+		}
+		if m.limits.Overflow == EvictLRUKey {
+			if victim < 0 {
+				m.dropOldestOf(added)
+				continue
+			}
+			n := len(m.buckets[victim].values)
+			m.buckets = slices.Delete(m.buckets, victim, victim+1)
+			m.size -= n
+			m.stats.DroppedValues += uint64(n)
+			m.stats.EvictedKeys++
+		} else {
+			m.dropOldestOf(victim)
+			if len(m.buckets[victim].values) > 0 {
+				continue
+			}
+			m.buckets = slices.Delete(m.buckets, victim, victim+1)
+		}
+		if victim < added {
+			added--
+		}
+	}
+}
+
+// Stats returns the counts of values discarded because of the map's limits.
+func (m *SortedMultiMap[K, V]) Stats() LimitStats {
+	return m.stats
+}
+
+// Get returns a copy of the values stored under key, in insertion order.
+//
+// Parameters:
+//   - key: The key to be looked up.
+//
+// Returns:
+//   - []V: The values stored under key, or nil if there are none.
+func (m *SortedMultiMap[K, V]) Get(key K) []V {
+	i, ok := m.search(key)
+	if !ok {
+		return nil
+	}
+	m.seq++
+	m.buckets[i].used = m.seq
+	return slices.Clone(m.buckets[i].values)
+}
+
+// ContainsKey reports whether any value is stored under key.
+func (m *SortedMultiMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.search(key)
+	return ok
+}
+
+// Remove deletes every value stored under key.
+//
+// Returns:
+//   - int: The number of values removed.
+func (m *SortedMultiMap[K, V]) Remove(key K) int {
+	i, ok := m.search(key)
+	if !ok {
+		return 0
+	}
+	n := len(m.buckets[i].values)
+	m.buckets = slices.Delete(m.buckets, i, i+1)
+	m.size -= n
+	return n
+}
+
+// RemoveRange deletes every value whose key lies in the half-open interval [lo, hi).
+// It is typically used to expire old events from a time-keyed map.
+//
+// Returns:
+//   - int: The number of values removed.
+func (m *SortedMultiMap[K, V]) RemoveRange(lo, hi K) int {
+	i, j := m.lowerBound(lo), m.lowerBound(hi)
+	if i >= j {
+		return 0
+	}
+	n := 0
+	for _, b := range m.buckets[i:j] {
+		n += len(b.values)
+	}
+	m.buckets = slices.Delete(m.buckets, i, j)
+	m.size -= n
+	return n
+}
+
+// Len returns the total number of values across all keys.
+func (m *SortedMultiMap[K, V]) Len() int {
+	return m.size
+}
+
+// KeyCount returns the number of distinct keys.
+func (m *SortedMultiMap[K, V]) KeyCount() int {
+	return len(m.buckets)
+}
+
+// Keys returns the distinct keys in ascending order.
+func (m *SortedMultiMap[K, V]) Keys() []K {
+	keys := make([]K, len(m.buckets))
+	for i, b := range m.buckets {
+		keys[i] = b.key
+	}
+	return keys
+}
+
+// First returns the smallest key and its values.
+//
+// Returns:
+//   - K: The smallest key, or the zero value if the map is empty.
+//   - []V: A copy of the values stored under that key.
+//   - bool: False if the map is empty, true otherwise.
+func (m *SortedMultiMap[K, V]) First() (K, []V, bool) {
+	if len(m.buckets) == 0 {
+		var zero K
+		return zero, nil, false
+	}
+	b := m.buckets[0]
+	return b.key, slices.Clone(b.values), true
+}
+
+// Last returns the largest key and its values.
+//
+// Returns:
+//   - K: The largest key, or the zero value if the map is empty.
+//   - []V: A copy of the values stored under that key.
+//   - bool: False if the map is empty, true otherwise.
+func (m *SortedMultiMap[K, V]) Last() (K, []V, bool) {
+	if len(m.buckets) == 0 {
+		var zero K
+		return zero, nil, false
+	}
+	b := m.buckets[len(m.buckets)-1]
+	return b.key, slices.Clone(b.values), true
+}
+
+// Ascend calls fn for every value in ascending key order, and in insertion order within a key,
+// until fn returns false.
+func (m *SortedMultiMap[K, V]) Ascend(fn func(key K, value V) bool) {
+	m.ascendFrom(0, len(m.buckets), fn)
+}
+
+// Descend calls fn for every value in descending key order, and in reverse insertion order
+// within a key, until fn returns false.
+func (m *SortedMultiMap[K, V]) Descend(fn func(key K, value V) bool) {
+	for i := len(m.buckets) - 1; i >= 0; i-- {
+		b := m.buckets[i]
+		for j := len(b.values) - 1; j >= 0; j-- {
+			if !fn(b.key, b.values[j]) {
+				return
+			}
+		}
+	}
+}
+
+// Range calls fn, in the same order as Ascend, for every value whose key lies in the
+// half-open interval [lo, hi), until fn returns false.
+//
+// Example:
+//
+//	events.Range(start, end, func(t time.Time, e string) bool {
+//		fmt.Println(t, e)
+//		return true
+//	})
+func (m *SortedMultiMap[K, V]) Range(lo, hi K, fn func(key K, value V) bool) {
+	m.ascendFrom(m.lowerBound(lo), m.lowerBound(hi), fn)
+}
+
+// Floor returns the largest key less than or equal to key.
+//
+// Returns:
+//   - K: The floor key, or the zero value if there is none.
+//   - bool: True if a floor key exists, false otherwise.
+func (m *SortedMultiMap[K, V]) Floor(key K) (K, bool) {
+	i := sort.Search(len(m.buckets), func(i int) bool { return m.compare(m.buckets[i].key, key) > 0 })
+	if i == 0 {
+		var zero K
+		return zero, false
+	}
+	return m.buckets[i-1].key, true
+}
+
+// Ceiling returns the smallest key greater than or equal to key.
+//
+// Returns:
+//   - K: The ceiling key, or the zero value if there is none.
+//   - bool: True if a ceiling key exists, false otherwise.
+func (m *SortedMultiMap[K, V]) Ceiling(key K) (K, bool) {
+	i := m.lowerBound(key)
+	if i == len(m.buckets) {
+		var zero K
+		return zero, false
+	}
+	return m.buckets[i].key, true
+}
+
+// Clear removes every key and value.
+func (m *SortedMultiMap[K, V]) Clear() {
+	m.buckets = nil
+	m.size = 0
+}
+
+// ascendFrom walks buckets[i:j] in order until fn returns false.
+func (m *SortedMultiMap[K, V]) ascendFrom(i, j int, fn func(key K, value V) bool) {
+	for ; i < j; i++ {
+		b := m.buckets[i]
+		for _, v := range b.values {
+			if !fn(b.key, v) {
+				return
+			}
+		}
+	}
+}
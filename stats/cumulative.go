@@ -0,0 +1,149 @@
+package stats
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// fenwick is a binary indexed tree over int64 counts, answering prefix sums and point
+// updates in O(log n).
+type fenwick []int64
+
+// add adds delta to position i (0-based).
+func (f fenwick) add(i int, delta int64) {
+	for i++; i <= len(f); i += i & -i {
+		f[i-1] += delta
+	}
+}
+
+// prefix returns the sum of positions [0, i).
+func (f fenwick) prefix(i int) int64 {
+	var sum int64
+	for ; i > 0; i -= i & -i {
+		sum += f[i-1]
+	}
+	return sum
+}
+
+// CumulativeCounter counts events per key in fixed time buckets and answers range totals
+// in logarithmic time, without scanning the buckets. It covers a fixed window starting at
+// a given time, such as a billing period, and is safe for concurrent use.
+type CumulativeCounter[K comparable] struct {
+	mu      sync.RWMutex
+	start   time.Time
+	width   time.Duration
+	buckets int
+	trees   dictionary.Dictionary[K, fenwick]
+}
+
+// NewCumulativeCounter creates a counter covering buckets consecutive buckets of the given
+// width, starting at start.
+//
+// Parameters:
+//   - start: The beginning of the first bucket.
+//   - width: The length of each bucket; must be positive.
+//   - buckets: The number of buckets; must be positive.
+//
+// Returns:
+//   - A new empty CumulativeCounter, or nil if width or buckets is not positive.
+//
+// Example:
+//
+//	month := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+//	usage := NewCumulativeCounter[string](month, time.Hour, 31*24)
+//	usage.Increment("tenant-a", time.Now(), 1)
+//	total := usage.Query("tenant-a", month, month.AddDate(0, 0, 7))
+func NewCumulativeCounter[K comparable](start time.Time, width time.Duration, buckets int) *CumulativeCounter[K] {
+	if width <= 0 || buckets <= 0 {
+		return nil
+	}
+	return &CumulativeCounter[K]{
+		start:   start,
+		width:   width,
+		buckets: buckets,
+		trees:   dictionary.DefaultDictionary[K, fenwick](),
+	}
+}
+
+// bucket returns the index of the bucket holding t, which may lie outside the window.
+func (c *CumulativeCounter[K]) bucket(t time.Time) int {
+	d := t.Sub(c.start)
+	if d < 0 {
+		return -1
+	}
+	return int(min(int64(d/c.width), int64(c.buckets)))
+}
+
+// Increment adds delta to the count of key in the bucket holding t.
+//
+// Parameters:
+//   - key: The key to count for.
+//   - t: The time of the events.
+//   - delta: The number of events; negative values correct earlier increments.
+//
+// Returns:
+//   - error: An error wrapping errs.ErrOutOfRange if t lies outside the window, nil otherwise.
+func (c *CumulativeCounter[K]) Increment(key K, t time.Time, delta int64) error {
+	i := c.bucket(t)
+	if i < 0 || i >= c.buckets {
+		return fmt.Errorf("stats: time %v outside counter window: %w", t, errs.ErrOutOfRange)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tree, ok := c.trees[key]
+	if !ok {
+		tree = make(fenwick, c.buckets)
+		c.trees[key] = tree
+	}
+	tree.add(i, delta)
+	return nil
+}
+
+// Query returns the total count of key in the buckets overlapping [t1, t2]. Times outside
+// the window are clamped to it, so totals are exact only to bucket granularity.
+//
+// Parameters:
+//   - key: The key to total.
+//   - t1: The start of the range.
+//   - t2: The end of the range, inclusive.
+//
+// Returns:
+//   - int64: The total, or 0 if the key has no counts or t2 is before t1.
+func (c *CumulativeCounter[K]) Query(key K, t1, t2 time.Time) int64 {
+	if t2.Before(t1) {
+		return 0
+	}
+	lo, hi := max(c.bucket(t1), 0), min(c.bucket(t2)+1, c.buckets)
+	if lo >= hi {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	tree, ok := c.trees[key]
+	if !ok {
+		return 0
+	}
+	return tree.prefix(hi) - tree.prefix(lo)
+}
+
+// Total returns the count of key over the whole window.
+func (c *CumulativeCounter[K]) Total(key K) int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	tree, ok := c.trees[key]
+	if !ok {
+		return 0
+	}
+	return tree.prefix(c.buckets)
+}
+
+// Keys returns the keys that have been incremented, in no particular order.
+func (c *CumulativeCounter[K]) Keys() []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.trees.GetKeys()
+}
@@ -0,0 +1,304 @@
+// Package stats provides lightweight statistical summaries, such as latency
+// histograms, that can be printed without wiring up a metrics system.
+package stats
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bhanurp/gotypes/errs"
+)
+
+// histogramBarWidth is the number of characters used by the longest bar in SnapshotString.
+const histogramBarWidth = 40
+
+// DefaultDurationBuckets returns the bucket upper bounds used by NewDurationHistogram:
+// 1µs doubling up to roughly 16.8s, which covers most request latencies.
+func DefaultDurationBuckets() []time.Duration {
+	return ExponentialBuckets(time.Microsecond, 2, 25)
+}
+
+// ExponentialBuckets returns n bucket upper bounds starting at start and growing by factor.
+// Bounds that would not grow because of rounding are bumped by one nanosecond so the
+// result is always strictly increasing.
+//
+// Parameters:
+//   - start: The upper bound of the first bucket; must be positive.
+//   - factor: The growth factor between consecutive bounds; must be greater than 1.
+//   - n: The number of bounds to return.
+//
+// Returns:
+//   - []time.Duration: The strictly increasing bucket upper bounds, or nil if the arguments are invalid.
+//
+// Example:
+//
+//	bounds := ExponentialBuckets(time.Millisecond, 10, 3) // bounds will be [1ms 10ms 100ms]
+func ExponentialBuckets(start time.Duration, factor float64, n int) []time.Duration {
+	if start <= 0 || factor <= 1 || n <= 0 {
+		return nil
+	}
+	bounds := make([]time.Duration, n)
+	cur := float64(start)
+	for i := range bounds {
+		b := time.Duration(math.Min(cur, math.MaxInt64))
+		if i > 0 && b <= bounds[i-1] {
+			b = bounds[i-1] + 1
+		}
+		bounds[i] = b
+		cur *= factor
+	}
+	return bounds
+}
+
+// DurationHistogram counts durations into exponential buckets.
+// It is safe for concurrent use.
+type DurationHistogram struct {
+	mu     sync.Mutex
+	bounds []time.Duration
+	counts []uint64 // len(bounds)+1; the last bucket holds values above the largest bound.
+	count  uint64
+	sum    time.Duration
+	min    time.Duration
+	max    time.Duration
+}
+
+// NewDurationHistogram creates a histogram using DefaultDurationBuckets.
+//
+// Example:
+//
+//	h := NewDurationHistogram()
+//	h.Record(3 * time.Millisecond)
+//	fmt.Println(h.SnapshotString())
+func NewDurationHistogram() *DurationHistogram {
+	return NewDurationHistogramWithBuckets(DefaultDurationBuckets())
+}
+
+// NewDurationHistogramWithBuckets creates a histogram with the given bucket upper bounds.
+// The bounds are copied and sorted; duplicates are removed.
+//
+// Parameters:
+//   - bounds: The inclusive upper bound of each bucket.
+//
+// Returns:
+//   - A new empty DurationHistogram.
+func NewDurationHistogramWithBuckets(bounds []time.Duration) *DurationHistogram {
+	b := normalizeBounds(bounds)
+	return &DurationHistogram{
+		bounds: b,
+		counts: make([]uint64, len(b)+1),
+	}
+}
+
+// normalizeBounds returns a sorted copy of bucket upper bounds without duplicates.
+func normalizeBounds[T cmp.Ordered](bounds []T) []T {
+	b := slices.Clone(bounds)
+	slices.Sort(b)
+	return slices.Compact(b)
+}
+
+// bucketOf returns the index of the bucket holding v: the first one whose upper bound is at
+// least v, or len(bounds) for the bucket above the largest bound.
+func bucketOf[T cmp.Ordered](bounds []T, v T) int {
+	return sort.Search(len(bounds), func(i int) bool { return v <= bounds[i] })
+}
+
+// Record adds a single observation to the histogram.
+// Negative durations are recorded as zero.
+//
+// Parameters:
+//   - d: The observed duration.
+func (h *DurationHistogram) Record(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	i := bucketOf(h.bounds, d)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts[i]++
+	if h.count == 0 || d < h.min {
+		h.min = d
+	}
+	if d > h.max {
+		h.max = d
+	}
+	h.count++
+	h.sum += d
+}
+
+// Since records the time elapsed since start. It is convenient with defer:
+//
+//	defer h.Since(time.Now())
+func (h *DurationHistogram) Since(start time.Time) {
+	h.Record(time.Since(start))
+}
+
+// Merge adds all observations of other into h.
+//
+// Parameters:
+//   - other: The histogram to be merged; it is not modified.
+//
+// Returns:
+//   - error: An error wrapping errs.ErrIncompatible if the histograms use different buckets,
+//     nil otherwise.
+func (h *DurationHistogram) Merge(other *DurationHistogram) error {
+	s := other.Snapshot()
+	if !slices.Equal(h.bounds, s.Bounds) {
+		return fmt.Errorf("stats: histogram buckets differ: %w", errs.ErrIncompatible)
+	}
+	if s.Count == 0 {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, c := range s.Counts {
+		h.counts[i] += c
+	}
+	if h.count == 0 || s.Min < h.min {
+		h.min = s.Min
+	}
+	if s.Max > h.max {
+		h.max = s.Max
+	}
+	h.count += s.Count
+	h.sum += s.Sum
+	return nil
+}
+
+// Reset discards all observations.
+func (h *DurationHistogram) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	clear(h.counts)
+	h.count, h.sum, h.min, h.max = 0, 0, 0, 0
+}
+
+// Snapshot returns a consistent copy of the histogram's current state.
+func (h *DurationHistogram) Snapshot() DurationSnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return DurationSnapshot{
+		Bounds: h.bounds,
+		Counts: slices.Clone(h.counts),
+		Count:  h.count,
+		Sum:    h.sum,
+		Min:    h.min,
+		Max:    h.max,
+	}
+}
+
+// SnapshotString returns an aligned textual rendering of the histogram.
+// See DurationSnapshot.String for the format.
+func (h *DurationHistogram) SnapshotString() string {
+	return h.Snapshot().String()
+}
+
+// DurationSnapshot is a point-in-time copy of a DurationHistogram.
+// Bounds must not be modified; it is shared with the histogram.
+type DurationSnapshot struct {
+	Bounds []time.Duration
+	Counts []uint64 // len(Bounds)+1; the last bucket holds values above the largest bound.
+	Count  uint64
+	Sum    time.Duration
+	Min    time.Duration
+	Max    time.Duration
+}
+
+// Mean returns the average recorded duration, or zero if nothing was recorded.
+func (s DurationSnapshot) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Sum / time.Duration(s.Count)
+}
+
+// Quantile estimates the q-th quantile (0 <= q <= 1) as the upper bound of the bucket
+// containing it, capped to the largest recorded value. It returns zero if nothing was recorded.
+//
+// Example:
+//
+//	p99 := h.Snapshot().Quantile(0.99)
+func (s DurationSnapshot) Quantile(q float64) time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	q = math.Max(0, math.Min(1, q))
+	rank := uint64(math.Ceil(q * float64(s.Count)))
+	if rank == 0 {
+		return s.Min
+	}
+	var seen uint64
+	for i, c := range s.Counts {
+		seen += c
+		if seen >= rank {
+			if i < len(s.Bounds) {
+				return min(s.Bounds[i], s.Max)
+			}
+			break
+		}
+	}
+	return s.Max
+}
+
+// String renders the non-empty range of buckets as aligned rows of the form
+//
+//	<= 1.024ms |########                                | 12
+//
+// followed by a summary line with the count, mean, min, max and common quantiles.
+func (s DurationSnapshot) String() string {
+	if s.Count == 0 {
+		return "count=0\n"
+	}
+
+	first, last := -1, -1
+	var peak uint64
+	for i, c := range s.Counts {
+		if c == 0 {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+		peak = max(peak, c)
+	}
+	if first < 0 {
+		first = 0 // no counts at all, as in a hand-built snapshot: print only the summary
+	}
+
+	labels := make([]string, 0, last-first+1)
+	labelWidth := 0
+	for i := first; i <= last; i++ {
+		var label string
+		switch {
+		case i < len(s.Bounds):
+			label = "<= " + s.Bounds[i].String()
+		case len(s.Bounds) > 0:
+			label = "> " + s.Bounds[len(s.Bounds)-1].String()
+		default:
+			label = "all" // a histogram without bounds has a single bucket
+		}
+		labels = append(labels, label)
+		labelWidth = max(labelWidth, len(label))
+	}
+
+	var b strings.Builder
+	for i := first; i <= last; i++ {
+		c := s.Counts[i]
+		bar := int(math.Round(float64(c) / float64(peak) * histogramBarWidth))
+		if c > 0 && bar == 0 {
+			bar = 1
+		}
+		fmt.Fprintf(&b, "%*s |%-*s| %d\n", labelWidth, labels[i-first], histogramBarWidth, strings.Repeat("#", bar), c)
+	}
+	fmt.Fprintf(&b, "count=%d mean=%v min=%v max=%v p50=%v p90=%v p99=%v\n",
+		s.Count, s.Mean(), s.Min, s.Max, s.Quantile(0.5), s.Quantile(0.9), s.Quantile(0.99))
+	return b.String()
+}
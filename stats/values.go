@@ -0,0 +1,45 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/bhanurp/gotypes/dictionary"
+	"github.com/bhanurp/gotypes/numx"
+)
+
+// HistogramBucket counts the values up to an inclusive upper bound.
+type HistogramBucket struct {
+	// UpperBound is the inclusive upper bound of the bucket; the last bucket's is +Inf.
+	UpperBound float64
+	Count      int
+}
+
+// Histogram counts the values of a Dictionary into buckets, as a DurationHistogram counts
+// durations. Each bucket holds the values greater than the previous bound and at most its
+// own, and a final bucket with an upper bound of +Inf holds the values above the largest
+// bound.
+//
+// Parameters:
+//   - d: The Dictionary whose values are to be counted.
+//   - bounds: The bucket upper bounds. They are copied and sorted, and duplicates are removed.
+//
+// Returns:
+//   - []HistogramBucket: One bucket per distinct bound, plus the overflow bucket.
+//
+// Example:
+//
+//	sizes := dictionary.Dictionary[string, int]{"a": 1, "b": 5, "c": 50}
+//	h := Histogram(sizes, []float64{1, 10})
+//	// h will be [{1 1} {10 1} {+Inf 1}]
+func Histogram[K comparable, V numx.Number](d dictionary.Dictionary[K, V], bounds []float64) []HistogramBucket {
+	b := normalizeBounds(bounds)
+	buckets := make([]HistogramBucket, len(b)+1)
+	for i, bound := range b {
+		buckets[i].UpperBound = bound
+	}
+	buckets[len(b)].UpperBound = math.Inf(1)
+	for _, v := range d {
+		buckets[bucketOf(b, float64(v))].Count++
+	}
+	return buckets
+}
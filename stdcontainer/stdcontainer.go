@@ -0,0 +1,406 @@
+// Package stdcontainer wraps the untyped containers of the standard library,
+// container/list, container/heap and container/ring, behind typed APIs and the
+// interfaces of package collection.
+//
+// Each wrapper can be built around an existing standard container and hands
+// the underlying value back through its Std method, so code migrating from the
+// standard containers can switch one call site at a time while sharing data
+// with code that has not been converted yet.
+package stdcontainer
+
+import (
+	"container/heap"
+	"container/list"
+	"container/ring"
+	"fmt"
+
+	"github.com/bhanurp/gotypes/collection"
+	"github.com/bhanurp/gotypes/errs"
+)
+
+var (
+	_ collection.Queue[int] = (*List[int])(nil)
+	_ collection.Queue[int] = (*Heap[int])(nil)
+	_ collection.Queue[int] = (*HeapQueue[int])(nil)
+)
+
+// List is a typed doubly linked list backed by a *list.List.
+// Used as a collection.Queue it is first-in first-out: Push appends to the back and Pop
+// removes from the front.
+type List[T any] struct {
+	l *list.List
+}
+
+// NewList creates an empty List.
+//
+// Returns:
+//   - *List[T]: A new empty List.
+//
+// Example:
+//
+//	jobs := NewList[string]()
+//	jobs.Push("build")
+func NewList[T any]() *List[T] {
+	return &List[T]{l: list.New()}
+}
+
+// FromList wraps an existing *list.List. The List and l share their elements, so changes
+// through either are visible through both.
+//
+// Parameters:
+//   - l: The list to wrap. Every element value must be of type T.
+//
+// Returns:
+//   - *List[T]: A List backed by l.
+//   - error: An error wrapping errs.ErrTypeMismatch if an element is not a T, nil otherwise.
+//
+// Example:
+//
+//	legacy := list.New()
+//	legacy.PushBack("build")
+//	jobs, err := FromList[string](legacy)
+func FromList[T any](l *list.List) (*List[T], error) {
+	for e, i := l.Front(), 0; e != nil; e, i = e.Next(), i+1 {
+		switch e.Value.(type) {
+		case nil:
+			// A nil is the zero value of an interface T; as in FromRing, read it as zero.
+		case T:
+		default:
+			return nil, fmt.Errorf("stdcontainer: element %d is %T: %w", i, e.Value, errs.ErrTypeMismatch)
+		}
+	}
+	return &List[T]{l: l}, nil
+}
+
+// Std returns the underlying *list.List. Only values of type T may be added to it while it
+// is still used through the List.
+func (l *List[T]) Std() *list.List {
+	return l.l
+}
+
+// Push appends elem to the back of the List. It never fails.
+func (l *List[T]) Push(elem T) error {
+	l.l.PushBack(elem)
+	return nil
+}
+
+// PushFront inserts elem at the front of the List.
+func (l *List[T]) PushFront(elem T) {
+	l.l.PushFront(elem)
+}
+
+// Pop removes and returns the front element, or false if the List is empty.
+func (l *List[T]) Pop() (T, bool) {
+	return l.remove(l.l.Front())
+}
+
+// PopBack removes and returns the back element, or false if the List is empty.
+func (l *List[T]) PopBack() (T, bool) {
+	return l.remove(l.l.Back())
+}
+
+// Peek returns the front element without removing it, or false if the List is empty.
+func (l *List[T]) Peek() (T, bool) {
+	return value[T](l.l.Front())
+}
+
+// PeekBack returns the back element without removing it, or false if the List is empty.
+func (l *List[T]) PeekBack() (T, bool) {
+	return value[T](l.l.Back())
+}
+
+// GetLength returns the number of elements in the List.
+func (l *List[T]) GetLength() int {
+	return l.l.Len()
+}
+
+// ToSlice returns the elements from front to back.
+func (l *List[T]) ToSlice() []T {
+	s := make([]T, 0, l.l.Len())
+	for e := l.l.Front(); e != nil; e = e.Next() {
+		v, _ := e.Value.(T)
+		s = append(s, v)
+	}
+	return s
+}
+
+func (l *List[T]) remove(e *list.Element) (T, bool) {
+	v, ok := value[T](e)
+	if ok {
+		l.l.Remove(e)
+	}
+	return v, ok
+}
+
+// value returns the value of a list element, or false if e is nil.
+func value[T any](e *list.Element) (T, bool) {
+	if e == nil {
+		var zero T
+		return zero, false
+	}
+	// The comma-ok form: a nil stored for an interface type T is not a T, but is its zero value.
+	v, _ := e.Value.(T)
+	return v, true
+}
+
+// heapSlice implements heap.Interface for a slice ordered by less.
+type heapSlice[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *heapSlice[T]) Len() int           { return len(h.items) }
+func (h *heapSlice[T]) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *heapSlice[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *heapSlice[T]) Push(x any)         { v, _ := x.(T); h.items = append(h.items, v) }
+
+func (h *heapSlice[T]) Pop() any {
+	n := len(h.items) - 1
+	x := h.items[n]
+	var zero T
+	h.items[n] = zero
+	h.items = h.items[:n]
+	return x
+}
+
+// Heap is a typed binary heap built on container/heap. Pop returns the smallest element
+// according to the less function given to NewHeap.
+type Heap[T any] struct {
+	h *heapSlice[T]
+}
+
+// NewHeap creates an empty Heap ordered by less.
+//
+// Parameters:
+//   - less: The function reporting whether a must be popped before b.
+//
+// Returns:
+//   - *Heap[T]: A new empty Heap.
+//
+// Example:
+//
+//	h := NewHeap(func(a, b int) bool { return a < b })
+//	h.Push(3)
+//	h.Push(1)
+//	v, _ := h.Pop() // v will be 1
+func NewHeap[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{h: &heapSlice[T]{less: less}}
+}
+
+// Std returns the heap.Interface backing the Heap, for use with heap.Fix, heap.Remove and
+// other functions of container/heap. Its Push method panics if given a value that is not a T.
+func (h *Heap[T]) Std() heap.Interface {
+	return h.h
+}
+
+// Push adds elem to the Heap. It never fails.
+func (h *Heap[T]) Push(elem T) error {
+	heap.Push(h.h, elem)
+	return nil
+}
+
+// Pop removes and returns the smallest element, or false if the Heap is empty.
+func (h *Heap[T]) Pop() (T, bool) {
+	if h.h.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	v, _ := heap.Pop(h.h).(T)
+	return v, true
+}
+
+// Peek returns the smallest element without removing it, or false if the Heap is empty.
+func (h *Heap[T]) Peek() (T, bool) {
+	if h.h.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.h.items[0], true
+}
+
+// GetLength returns the number of elements in the Heap.
+func (h *Heap[T]) GetLength() int {
+	return h.h.Len()
+}
+
+// ToSlice returns the elements in heap order, which is not sorted order.
+func (h *Heap[T]) ToSlice() []T {
+	return append([]T(nil), h.h.items...)
+}
+
+// HeapQueue adapts an existing heap.Interface, whose Push and Pop exchange values of
+// type T, to collection.Queue.
+type HeapQueue[T any] struct {
+	h heap.Interface
+}
+
+// FromHeap wraps an existing heap.Interface, establishing the heap invariant with heap.Init.
+// The HeapQueue and h share their elements.
+//
+// Parameters:
+//   - h: The heap to wrap. Its Pop method must return values of type T.
+//
+// Returns:
+//   - *HeapQueue[T]: A HeapQueue backed by h.
+//
+// Example:
+//
+//	q := FromHeap[int](&legacyIntHeap)
+//	v, ok := q.Pop()
+func FromHeap[T any](h heap.Interface) *HeapQueue[T] {
+	heap.Init(h)
+	return &HeapQueue[T]{h: h}
+}
+
+// Std returns the underlying heap.Interface.
+func (q *HeapQueue[T]) Std() heap.Interface {
+	return q.h
+}
+
+// Push adds elem to the heap. It never fails.
+func (q *HeapQueue[T]) Push(elem T) error {
+	heap.Push(q.h, elem)
+	return nil
+}
+
+// Pop removes and returns the smallest element, or false if the heap is empty. A nil
+// popped from the heap, such as a nil stored for an interface type T, is returned as the
+// zero value of T, and so is any value that is not a T.
+func (q *HeapQueue[T]) Pop() (T, bool) {
+	if q.h.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	v, _ := heap.Pop(q.h).(T)
+	return v, true
+}
+
+// Peek returns the smallest element without removing it, or false if the heap is empty.
+// heap.Interface gives no access to its elements, so Peek pops the element and pushes it
+// back, which costs O(log n).
+func (q *HeapQueue[T]) Peek() (T, bool) {
+	v, ok := q.Pop()
+	if ok {
+		heap.Push(q.h, v)
+	}
+	return v, ok
+}
+
+// GetLength returns the number of elements in the heap.
+func (q *HeapQueue[T]) GetLength() int {
+	return q.h.Len()
+}
+
+// Ring is a typed circular list backed by a *ring.Ring. A Ring refers to one element of
+// the circle, its current position; Next, Prev and Move return Rings at other positions of
+// the same circle.
+type Ring[T any] struct {
+	r *ring.Ring
+}
+
+// NewRing creates a Ring of n elements, each holding the zero value of T.
+//
+// Parameters:
+//   - n: The number of elements. It must be positive.
+//
+// Returns:
+//   - *Ring[T]: A new Ring positioned at its first element.
+//
+// Example:
+//
+//	last := NewRing[float64](3)
+//	for _, v := range []float64{1, 2, 3, 4} {
+//		last = last.Add(v)
+//	}
+//	// last.ToSlice() will be [2 3 4]
+func NewRing[T any](n int) *Ring[T] {
+	r := ring.New(n)
+	var zero T
+	for i := 0; i < n; i++ {
+		r.Value = zero
+		r = r.Next()
+	}
+	return &Ring[T]{r: r}
+}
+
+// FromRing wraps an existing *ring.Ring. The Ring and r share their elements.
+// Elements holding nil are set to the zero value of T.
+//
+// Parameters:
+//   - r: The ring to wrap. Every element value must be nil or of type T.
+//
+// Returns:
+//   - *Ring[T]: A Ring positioned at r.
+//   - error: An error wrapping errs.ErrTypeMismatch if an element is not a T, nil otherwise.
+func FromRing[T any](r *ring.Ring) (*Ring[T], error) {
+	var zero T
+	p := r
+	for i := 0; i < r.Len(); i, p = i+1, p.Next() {
+		switch p.Value.(type) {
+		case nil:
+			p.Value = zero
+		case T:
+		default:
+			return nil, fmt.Errorf("stdcontainer: element %d is %T: %w", i, p.Value, errs.ErrTypeMismatch)
+		}
+	}
+	return &Ring[T]{r: r}, nil
+}
+
+// Std returns the underlying *ring.Ring, positioned at the current element.
+func (r *Ring[T]) Std() *ring.Ring {
+	return r.r
+}
+
+// Value returns the current element.
+func (r *Ring[T]) Value() T {
+	v, _ := r.r.Value.(T)
+	return v
+}
+
+// Set replaces the current element.
+func (r *Ring[T]) Set(v T) {
+	r.r.Value = v
+}
+
+// Add replaces the current element and returns the Ring positioned at the next one, which
+// is the oldest element when the Ring is used to keep the last n values.
+func (r *Ring[T]) Add(v T) *Ring[T] {
+	r.r.Value = v
+	return r.Next()
+}
+
+// Next returns the Ring positioned at the next element.
+func (r *Ring[T]) Next() *Ring[T] {
+	return &Ring[T]{r: r.r.Next()}
+}
+
+// Prev returns the Ring positioned at the previous element.
+func (r *Ring[T]) Prev() *Ring[T] {
+	return &Ring[T]{r: r.r.Prev()}
+}
+
+// Move returns the Ring positioned n elements forward, or backward if n is negative.
+func (r *Ring[T]) Move(n int) *Ring[T] {
+	return &Ring[T]{r: r.r.Move(n)}
+}
+
+// GetLength returns the number of elements in the circle.
+func (r *Ring[T]) GetLength() int {
+	return r.r.Len()
+}
+
+// Do calls fn for each element, starting at the current one and moving forward.
+func (r *Ring[T]) Do(fn func(T)) {
+	r.r.Do(func(x any) {
+		v, _ := x.(T)
+		fn(v)
+	})
+}
+
+// ToSlice returns the elements, starting at the current one and moving forward.
+func (r *Ring[T]) ToSlice() []T {
+	s := make([]T, 0, r.r.Len())
+	r.Do(func(v T) { s = append(s, v) })
+	return s
+}